@@ -4,14 +4,35 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 
 	"github.com/hashicorp/terraform-provider-nomad/nomad"
 )
 
 func main() {
+	shutdownTracing, err := nomad.SetupTracing(context.Background())
+	if err != nil {
+		log.Printf("[WARN] Failed to set up tracing: %s", err)
+	}
+
+	server, err := nomad.NewMuxServer(context.Background(), nomad.Provider())
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider { return nomad.Provider() },
+		GRPCProviderFunc: func() tfprotov5.ProviderServer {
+			return server
+		},
 	})
+
+	if shutdownTracing != nil {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("[WARN] Failed to export traces: %s", err)
+		}
+	}
 }
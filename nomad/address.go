@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// normalizeAddress returns address with its IPv6 literal host, if any, in the
+// canonical form enclosed in brackets, as required in URLs. Without brackets
+// the Nomad API client fails to parse the address, or splits the last group of
+// the IPv6 address as the port, so addresses such as http://2001:db8::1 also
+// work. IPv6 addresses with a port must still be enclosed in brackets since
+// the port can't be told apart from the last group otherwise.
+func normalizeAddress(address string) (string, error) {
+	scheme, rest, ok := strings.Cut(address, "://")
+	if !ok || scheme == "unix" {
+		return address, nil
+	}
+
+	// The host is between the user info and the path of the URL.
+	authority, path := rest, ""
+	if i := strings.IndexAny(rest, "/?#"); i >= 0 {
+		authority, path = rest[:i], rest[i:]
+	}
+	userinfo, host := "", authority
+	if i := strings.LastIndex(authority, "@"); i >= 0 {
+		userinfo, host = authority[:i+1], authority[i+1:]
+	}
+
+	host, err := normalizeIPv6Host(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %q: %v", address, err)
+	}
+
+	normalized := scheme + "://" + userinfo + host + path
+	if _, err := url.Parse(normalized); err != nil {
+		return "", fmt.Errorf("invalid address %q: %v", address, err)
+	}
+	return normalized, nil
+}
+
+// normalizeIPv6Host returns host, with an optional port, with its IPv6 address
+// in canonical form and enclosed in brackets. Other hosts are returned as is.
+func normalizeIPv6Host(host string) (string, error) {
+	if !strings.HasPrefix(host, "[") {
+		if addr, err := parseIPv6(host); err == nil {
+			return formatIPv6(addr, ""), nil
+		}
+		return host, nil
+	}
+
+	end := strings.Index(host, "]")
+	if end < 0 {
+		return "", fmt.Errorf("missing ']' in host %q", host)
+	}
+	addr, err := parseIPv6(host[1:end])
+	if err != nil {
+		return "", err
+	}
+	return formatIPv6(addr, host[end+1:]), nil
+}
+
+// parseIPv6 parses an IPv6 address, with a zone escaped as in URLs or not.
+func parseIPv6(s string) (netip.Addr, error) {
+	if strings.Contains(s, "%25") {
+		unescaped, err := url.PathUnescape(s)
+		if err != nil {
+			return netip.Addr{}, err
+		}
+		s = unescaped
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if !addr.Is6() {
+		return netip.Addr{}, fmt.Errorf("%q is not an IPv6 address", s)
+	}
+	return addr, nil
+}
+
+func formatIPv6(addr netip.Addr, port string) string {
+	zone := addr.Zone()
+	ip := addr.WithZone("").String()
+	if zone != "" {
+		ip += "%25" + url.PathEscape(zone)
+	}
+	return "[" + ip + "]" + port
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+)
+
+func TestNormalizeAddress(t *testing.T) {
+	cases := []struct {
+		address string
+		want    string
+	}{
+		{address: "", want: ""},
+		{address: "http://127.0.0.1:4646", want: "http://127.0.0.1:4646"},
+		{address: "https://nomad.example.com:4646/", want: "https://nomad.example.com:4646/"},
+		{address: "unix:///var/run/nomad.sock", want: "unix:///var/run/nomad.sock"},
+
+		// IPv6 addresses are enclosed in brackets and in canonical form.
+		{address: "http://[::1]:4646", want: "http://[::1]:4646"},
+		{address: "http://::1", want: "http://[::1]"},
+		{address: "https://2001:DB8:0:0::1", want: "https://[2001:db8::1]"},
+		{address: "https://[2001:0db8::0001]:4646/prefix", want: "https://[2001:db8::1]:4646/prefix"},
+		{address: "https://user:pass@[2001:db8::1]:4646", want: "https://user:pass@[2001:db8::1]:4646"},
+		{address: "http://user@fe80::1", want: "http://user@[fe80::1]"},
+		{address: "http://[fe80::1%25eth0]:4646", want: "http://[fe80::1%25eth0]:4646"},
+		{address: "http://fe80::1%eth0", want: "http://[fe80::1%25eth0]"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.address, func(t *testing.T) {
+			got, err := normalizeAddress(tc.address)
+			must.NoError(t, err)
+			must.Eq(t, tc.want, got)
+		})
+	}
+
+	_, err := normalizeAddress("http://[2001:db8::1:4646")
+	must.ErrorContains(t, err, "missing ']'")
+
+	_, err = normalizeAddress("http://[nomad.example.com]:4646")
+	must.Error(t, err)
+}
+
+func TestNormalizeAddress_client(t *testing.T) {
+	// The Nomad API client must see the host and the port of the normalized
+	// addresses.
+	address, err := normalizeAddress("http://2001:db8::1")
+	must.NoError(t, err)
+	client, err := api.NewClient(&api.Config{Address: address})
+	must.NoError(t, err)
+	must.Eq(t, "http://[2001:db8::1]", client.Address())
+}
@@ -0,0 +1,310 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// unexpectedResponseRe matches the errors returned by the Nomad API client
+// for unexpected HTTP responses, once they have been formatted into another
+// error or a diagnostic.
+var unexpectedResponseRe = regexp.MustCompile(`(?s)Unexpected response code: (\d{3})(?: \((.*)\))?`)
+
+// apiOperation describes the operation that returned an error so its
+// diagnostic can explain what failed.
+type apiOperation struct {
+	// action is one of "create", "read", "update" or "delete".
+	action string
+
+	// typeName is the type of the resource or data source.
+	typeName string
+
+	// dataSource is set when typeName is a data source.
+	dataSource bool
+}
+
+// withAPIErrorDiagnostics wraps the CRUD functions of the resources so the
+// errors they return include the context of the Nomad API request that
+// failed. The functions that return an error are converted to their context
+// equivalent so they can return diagnostics.
+func withAPIErrorDiagnostics(resources map[string]*schema.Resource, dataSource bool) map[string]*schema.Resource {
+	for typeName, r := range resources {
+		op := func(action string) apiOperation {
+			return apiOperation{action: action, typeName: typeName, dataSource: dataSource}
+		}
+
+		if r.Create != nil {
+			r.CreateContext = apiErrorDiagnosticsFunc(r, op("create"), contextFunc(r.Create))
+			r.Create = nil
+		} else if r.CreateContext != nil {
+			r.CreateContext = apiErrorDiagnosticsFunc(r, op("create"), r.CreateContext)
+		}
+		if r.Read != nil {
+			r.ReadContext = schema.ReadContextFunc(apiErrorDiagnosticsFunc(r, op("read"), contextFunc(schema.CreateFunc(r.Read))))
+			r.Read = nil
+		} else if r.ReadContext != nil {
+			r.ReadContext = schema.ReadContextFunc(apiErrorDiagnosticsFunc(r, op("read"), schema.CreateContextFunc(r.ReadContext)))
+		}
+		if r.Update != nil {
+			r.UpdateContext = schema.UpdateContextFunc(apiErrorDiagnosticsFunc(r, op("update"), contextFunc(schema.CreateFunc(r.Update))))
+			r.Update = nil
+		} else if r.UpdateContext != nil {
+			r.UpdateContext = schema.UpdateContextFunc(apiErrorDiagnosticsFunc(r, op("update"), schema.CreateContextFunc(r.UpdateContext)))
+		}
+		if r.Delete != nil {
+			r.DeleteContext = schema.DeleteContextFunc(apiErrorDiagnosticsFunc(r, op("delete"), contextFunc(schema.CreateFunc(r.Delete))))
+			r.Delete = nil
+		} else if r.DeleteContext != nil {
+			r.DeleteContext = schema.DeleteContextFunc(apiErrorDiagnosticsFunc(r, op("delete"), schema.CreateContextFunc(r.DeleteContext)))
+		}
+	}
+	return resources
+}
+
+// withContextFuncs converts the CRUD functions of the resources that return
+// an error to their context equivalent, and folds Exists into the read, so
+// the wrappers applied over it only handle context functions.
+func withContextFuncs(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, r := range resources {
+		if r.Create != nil {
+			r.CreateContext = contextFunc(r.Create)
+			r.Create = nil
+		}
+		if r.Read != nil {
+			r.ReadContext = schema.ReadContextFunc(contextFunc(schema.CreateFunc(r.Read)))
+			r.Read = nil
+		}
+		if r.Update != nil {
+			r.UpdateContext = schema.UpdateContextFunc(contextFunc(schema.CreateFunc(r.Update)))
+			r.Update = nil
+		}
+		if r.Delete != nil {
+			r.DeleteContext = schema.DeleteContextFunc(contextFunc(schema.CreateFunc(r.Delete)))
+			r.Delete = nil
+		}
+		if r.Exists != nil && r.ReadContext != nil {
+			r.ReadContext = schema.ReadContextFunc(existsReadContextFunc(r.Exists, schema.CreateContextFunc(r.ReadContext)))
+			r.Exists = nil
+		}
+	}
+	return resources
+}
+
+// existsReadContextFunc checks that the object exists before reading it,
+// removing it from the state otherwise, like the SDK does with Exists.
+func existsReadContextFunc(exists schema.ExistsFunc, read schema.CreateContextFunc) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		ok, err := exists(d, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !ok {
+			d.SetId("")
+			return nil
+		}
+		return read(ctx, d, meta)
+	}
+}
+
+// contextFunc converts a CRUD function that returns an error into one that
+// returns diagnostics.
+func contextFunc(f schema.CreateFunc) schema.CreateContextFunc {
+	return func(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		return diag.FromErr(f(d, meta))
+	}
+}
+
+func apiErrorDiagnosticsFunc(r *schema.Resource, op apiOperation, f schema.CreateContextFunc) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		for i, diagnostic := range diags {
+			if diagnostic.Severity != diag.Error {
+				continue
+			}
+			diags[i].Detail = apiErrorDiagnosticDetail(r, op, d, meta, diagnostic)
+		}
+		return diags
+	}
+}
+
+// apiErrorDiagnosticDetail returns the detail of a diagnostic about a failed
+// Nomad API request. Diagnostics that are not about a Nomad API request, or
+// that already have a detail, are left as-is.
+func apiErrorDiagnosticDetail(r *schema.Resource, op apiOperation, d *schema.ResourceData, meta any, diagnostic diag.Diagnostic) string {
+	if diagnostic.Detail != "" {
+		return diagnostic.Detail
+	}
+
+	text := diagnostic.Summary
+	statusCode, body := 0, ""
+	if m := unexpectedResponseRe.FindStringSubmatch(text); m != nil {
+		statusCode, _ = strconv.Atoi(m[1])
+		body = strings.TrimSpace(m[2])
+	} else if apiConnectionHint(text) == "" {
+		return ""
+	}
+
+	namespace := ""
+	if _, ok := r.Schema["namespace"]; ok {
+		namespace, _ = d.Get("namespace").(string)
+	}
+	region := ""
+	config, _ := meta.(ProviderConfig)
+	if config.config != nil {
+		region = config.config.Region
+	}
+
+	var b strings.Builder
+	kind := "resource"
+	if op.dataSource {
+		kind = "data source"
+	}
+	fmt.Fprintf(&b, "The Nomad API request to %s the %s %s failed.\n", op.action, op.typeName, kind)
+
+	if statusCode != 0 {
+		fmt.Fprintf(&b, "\nStatus: %d %s", statusCode, http.StatusText(statusCode))
+		if body != "" {
+			fmt.Fprintf(&b, "\nError: %s", body)
+		}
+	}
+	if config.config != nil && config.config.Address != "" {
+		fmt.Fprintf(&b, "\nAddress: %s", config.config.Address)
+	}
+	if region != "" {
+		fmt.Fprintf(&b, "\nRegion: %s", region)
+	}
+	if namespace != "" {
+		fmt.Fprintf(&b, "\nNamespace: %s", namespace)
+	}
+
+	hint := apiConnectionHint(text)
+	if statusCode != 0 {
+		hint = apiResponseHint(op, statusCode, body, namespace)
+	}
+	if hint != "" {
+		fmt.Fprintf(&b, "\n\n%s", hint)
+	}
+	return b.String()
+}
+
+// apiConnectionHint returns a hint about errors that prevented the provider
+// from reaching the Nomad API.
+func apiConnectionHint(text string) string {
+	switch {
+	case strings.Contains(text, "connection refused"),
+		strings.Contains(text, "no such host"),
+		strings.Contains(text, "i/o timeout"):
+		return "The provider was unable to reach the Nomad API, check that the address of the provider, or the NOMAD_ADDR environment variable, points to a running Nomad agent."
+	case strings.Contains(text, "x509:"),
+		strings.Contains(text, "tls:"):
+		return "The TLS connection to the Nomad API failed, check the ca_file, cert_file and key_file arguments of the provider, or their NOMAD_CACERT, NOMAD_CLIENT_CERT and NOMAD_CLIENT_KEY environment variables."
+	}
+	return ""
+}
+
+// apiResponseHint returns a hint about how to fix the error returned by the
+// Nomad API.
+func apiResponseHint(op apiOperation, statusCode int, body, namespace string) string {
+	switch {
+	case strings.Contains(body, "ACL token not found"):
+		return "The ACL token of the provider doesn't exist or has expired, check the secret_id argument of the provider or the NOMAD_TOKEN environment variable."
+	case strings.Contains(body, "ACL support disabled"):
+		return "ACLs are not enabled in the Nomad cluster."
+	case strings.Contains(body, "Nomad Enterprise only"):
+		return fmt.Sprintf("%s requires Nomad Enterprise.", op.typeName)
+	case strings.Contains(body, "No cluster leader"):
+		return "The Nomad servers have no leader, which usually happens while they are being restarted or upgraded. Requests failing with this error are retried up to max_retries times."
+	case strings.Contains(body, "Permission denied"), statusCode == http.StatusForbidden:
+		if capability := requiredCapability(op); capability != "" {
+			if namespace == "" {
+				namespace = api.DefaultNamespace
+			}
+			if strings.HasPrefix(capability, "variables ") {
+				return fmt.Sprintf("The ACL token of the provider may be missing the %s capability for this path in the namespace %q.", strings.TrimPrefix(capability, "variables "), namespace)
+			}
+			return fmt.Sprintf("The ACL token of the provider may be missing the %s capability in the namespace %q.", capability, namespace)
+		}
+		if policy := requiredPolicy(op); policy != "" {
+			return fmt.Sprintf("The ACL token of the provider may be missing a policy with %s.", policy)
+		}
+		return "This operation requires a management ACL token."
+	case statusCode == http.StatusTooManyRequests:
+		return "The Nomad API is rate limiting the requests of the provider."
+	}
+	return ""
+}
+
+// requiredCapability returns the namespace capability required to run the
+// operation, if any.
+func requiredCapability(op apiOperation) string {
+	read := op.action == "read"
+	switch op.typeName {
+	case "nomad_job", "nomad_job_eval", "nomad_deployment_control", "nomad_deployment_promote":
+		if read {
+			return "read-job"
+		}
+		return "submit-job"
+	case "nomad_jobs", "nomad_deployments", "nomad_allocations", "nomad_scaling_policies", "nomad_scaling_policy":
+		return "read-job"
+	case "nomad_job_parser":
+		return "parse-job"
+	case "nomad_alloc_exec":
+		return "alloc-exec"
+	case "nomad_alloc_signal_restart":
+		return "alloc-lifecycle"
+	case "nomad_recommendation_action":
+		return "submit-recommendation"
+	case "nomad_csi_volume", "nomad_csi_volume_registration", "nomad_external_volume", "nomad_volume", "nomad_volumes":
+		if read {
+			return "csi-read-volume"
+		}
+		return "csi-write-volume"
+	case "nomad_dynamic_host_volume":
+		if read {
+			return "host-volume-read"
+		}
+		return "host-volume-create"
+	case "nomad_dynamic_host_volume_registration":
+		if read {
+			return "host-volume-read"
+		}
+		return "host-volume-register"
+	case "nomad_variable", "nomad_variable_tree":
+		if read {
+			return "variables read"
+		}
+		return "variables write"
+	}
+	return ""
+}
+
+// requiredPolicy returns the rules of the policy required to run operations
+// that are not scoped to a namespace.
+func requiredPolicy(op apiOperation) string {
+	access := "write"
+	if op.action == "read" {
+		access = "read"
+	}
+	switch op.typeName {
+	case "nomad_node_pool", "nomad_node_pools", "nomad_node_allocations", "nomad_node_purge", "nomad_datacenters", "nomad_topology", "nomad_cluster_readiness":
+		return fmt.Sprintf("node = %q", access)
+	case "nomad_scheduler_config", "nomad_regions":
+		return fmt.Sprintf("operator = %q", access)
+	case "nomad_plugin", "nomad_plugins":
+		return `plugin = "read"`
+	case "nomad_quota_specification", "nomad_quota_usage":
+		return fmt.Sprintf("quota = %q", access)
+	}
+	return ""
+}
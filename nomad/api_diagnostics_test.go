@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shoenig/test/must"
+)
+
+func TestWithAPIErrorDiagnostics(t *testing.T) {
+	resources := withAPIErrorDiagnostics(map[string]*schema.Resource{
+		"nomad_job": {
+			Schema: map[string]*schema.Schema{
+				"namespace": {
+					Type:     schema.TypeString,
+					Optional: true,
+					ForceNew: true,
+				},
+			},
+			Create: func(d *schema.ResourceData, meta any) error {
+				return errors.New("error applying jobspec: Unexpected response code: 403 (Permission denied)")
+			},
+			ReadContext: func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+				return diag.Errorf(`error reading job: Get "http://127.0.0.1:4646/v1/job/example": dial tcp 127.0.0.1:4646: connect: connection refused`)
+			},
+			Delete: func(d *schema.ResourceData, meta any) error {
+				return errors.New("job must be stopped first")
+			},
+		},
+	}, false)
+
+	r := resources["nomad_job"]
+	must.Nil(t, r.Create)
+	must.Nil(t, r.Delete)
+	must.NoError(t, r.InternalValidate(nil, true))
+
+	d := r.TestResourceData()
+	must.NoError(t, d.Set("namespace", "prod"))
+	meta := ProviderConfig{
+		config: &api.Config{
+			Address: "http://127.0.0.1:4646",
+			Region:  "global",
+		},
+	}
+
+	diags := r.CreateContext(context.Background(), d, meta)
+	must.Len(t, 1, diags)
+	must.Eq(t, "error applying jobspec: Unexpected response code: 403 (Permission denied)", diags[0].Summary)
+	must.Eq(t, `The Nomad API request to create the nomad_job resource failed.
+
+Status: 403 Forbidden
+Error: Permission denied
+Address: http://127.0.0.1:4646
+Region: global
+Namespace: prod
+
+The ACL token of the provider may be missing the submit-job capability in the namespace "prod".`, diags[0].Detail)
+
+	diags = r.ReadContext(context.Background(), d, meta)
+	must.Len(t, 1, diags)
+	must.StrContains(t, diags[0].Detail, "The Nomad API request to read the nomad_job resource failed.")
+	must.StrContains(t, diags[0].Detail, "check that the address of the provider")
+
+	// Errors that are not returned by the Nomad API are left as-is.
+	diags = r.DeleteContext(context.Background(), d, meta)
+	must.Len(t, 1, diags)
+	must.Eq(t, "job must be stopped first", diags[0].Summary)
+	must.Eq(t, "", diags[0].Detail)
+}
+
+func TestAPIResponseHint(t *testing.T) {
+	testCases := []struct {
+		name       string
+		op         apiOperation
+		statusCode int
+		body       string
+		namespace  string
+		expected   string
+	}{
+		{
+			name:       "job read",
+			op:         apiOperation{action: "read", typeName: "nomad_job"},
+			statusCode: 403,
+			body:       "Permission denied",
+			expected:   `The ACL token of the provider may be missing the read-job capability in the namespace "default".`,
+		},
+		{
+			name:       "variable write",
+			op:         apiOperation{action: "create", typeName: "nomad_variable"},
+			statusCode: 403,
+			body:       "Permission denied",
+			namespace:  "prod",
+			expected:   `The ACL token of the provider may be missing the write capability for this path in the namespace "prod".`,
+		},
+		{
+			name:       "node pool",
+			op:         apiOperation{action: "update", typeName: "nomad_node_pool"},
+			statusCode: 403,
+			body:       "Permission denied",
+			expected:   `The ACL token of the provider may be missing a policy with node = "write".`,
+		},
+		{
+			name:       "management",
+			op:         apiOperation{action: "create", typeName: "nomad_acl_policy"},
+			statusCode: 403,
+			body:       "Permission denied",
+			expected:   "This operation requires a management ACL token.",
+		},
+		{
+			name:       "token not found",
+			op:         apiOperation{action: "read", typeName: "nomad_job"},
+			statusCode: 403,
+			body:       "ACL token not found",
+			expected:   "The ACL token of the provider doesn't exist or has expired, check the secret_id argument of the provider or the NOMAD_TOKEN environment variable.",
+		},
+		{
+			name:       "enterprise",
+			op:         apiOperation{action: "create", typeName: "nomad_quota_specification"},
+			statusCode: 501,
+			body:       "Nomad Enterprise only endpoint",
+			expected:   "nomad_quota_specification requires Nomad Enterprise.",
+		},
+		{
+			name:       "not found",
+			op:         apiOperation{action: "read", typeName: "nomad_job"},
+			statusCode: 404,
+			body:       "job not found",
+			expected:   "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expected, apiResponseHint(tc.op, tc.statusCode, tc.body, tc.namespace))
+		})
+	}
+}
+
+func TestWithContextFuncs(t *testing.T) {
+	var reads int
+	exists := true
+	r := withContextFuncs(map[string]*schema.Resource{
+		"nomad_namespace": {
+			Schema: map[string]*schema.Schema{},
+			Create: func(d *schema.ResourceData, meta any) error {
+				return errors.New("error creating namespace")
+			},
+			Read: func(d *schema.ResourceData, meta any) error {
+				reads++
+				return nil
+			},
+			Exists: func(d *schema.ResourceData, meta any) (bool, error) {
+				return exists, nil
+			},
+		},
+	})["nomad_namespace"]
+	must.Nil(t, r.Create)
+	must.Nil(t, r.Read)
+	must.Nil(t, r.Exists)
+
+	diags := r.CreateContext(context.Background(), r.TestResourceData(), nil)
+	must.True(t, diags.HasError())
+	must.Eq(t, "error creating namespace", diags[0].Summary)
+
+	// Exists is checked before reading the object.
+	d := r.TestResourceData()
+	d.SetId("example")
+	must.False(t, r.ReadContext(context.Background(), d, nil).HasError())
+	must.Eq(t, 1, reads)
+
+	exists = false
+	must.False(t, r.ReadContext(context.Background(), d, nil).HasError())
+	must.Eq(t, "", d.Id())
+	must.Eq(t, 1, reads)
+}
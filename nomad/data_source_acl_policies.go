@@ -16,12 +16,18 @@ func dataSourceAclPolicies() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceAclPoliciesRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withListArguments(map[string]*schema.Schema{
 			"prefix": {
 				Description: "ACL Policy Name Prefix",
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
+			"include_rules": {
+				Description: "Whether to read the rules of the policies. The rules are read with one request per policy, so they are not read by default.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"policies": {
 				Description: "ACL Policies",
 				Type:        schema.TypeList,
@@ -38,10 +44,15 @@ func dataSourceAclPolicies() *schema.Resource {
 							Type:        schema.TypeString,
 							Computed:    true,
 						},
+						"rules": {
+							Description: "ACL Policy Rules, only set if include_rules is true",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
@@ -66,16 +77,46 @@ func dataSourceAclPoliciesRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error getting ACL policies: %#v", err)
 	}
 
+	list := getListArguments(d)
+	policies, err = filterList(list, policies)
+	if err != nil {
+		return err
+	}
+	result, err := list.sortAndLimit(flattenAclPolicies(policies))
+	if err != nil {
+		return err
+	}
+
+	// The list only returns the stubs of the policies, so their rules are
+	// read concurrently, only for the policies returned.
+	if d.Get("include_rules").(bool) {
+		log.Printf("[DEBUG] Reading the rules of %d ACL Policies", len(result))
+		rules, err := fetchAll(result, detailFetchConcurrency, func(p map[string]any) (string, error) {
+			name := p["name"].(string)
+			policy, _, err := client.ACLPolicies().Info(name, nil)
+			if err != nil {
+				return "", fmt.Errorf("error reading ACL policy %q: %w", name, err)
+			}
+			return policy.Rules, nil
+		})
+		if err != nil {
+			return err
+		}
+		for i := range result {
+			result[i]["rules"] = rules[i]
+		}
+	}
+
 	d.SetId(resource.UniqueId())
-	if err := d.Set("policies", flattenAclPolicies(policies)); err != nil {
+	if err := d.Set("policies", result); err != nil {
 		return fmt.Errorf("error setting policies: %#v", err)
 	}
 
 	return nil
 }
 
-func flattenAclPolicies(policies []*api.ACLPolicyListStub) []interface{} {
-	output := make([]interface{}, 0, len(policies))
+func flattenAclPolicies(policies []*api.ACLPolicyListStub) []map[string]interface{} {
+	output := make([]map[string]interface{}, 0, len(policies))
 	for _, policy := range policies {
 		p := map[string]interface{}{
 			"name":        policy.Name,
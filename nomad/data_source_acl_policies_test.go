@@ -33,6 +33,15 @@ func TestAccDataSourceNomadAclPolicies_Basic(t *testing.T) {
 					resource.TestMatchResourceAttr(dataSourceName, "policies.0.description", regexp.MustCompile("Terraform ACL Policy tf-acc-test")),
 					resource.TestMatchResourceAttr(dataSourceName, "policies.1.name", regexp.MustCompile("tf-acc-test")),
 					resource.TestMatchResourceAttr(dataSourceName, "policies.1.description", regexp.MustCompile("Terraform ACL Policy tf-acc-test")),
+					resource.TestCheckResourceAttr(dataSourceName, "policies.0.rules", ""),
+				),
+			},
+			{
+				Config: testAccNomadAclPoliciesConfigWithRules("tf-acc-test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "policies.#", "2"),
+					resource.TestMatchResourceAttr(dataSourceName, "policies.0.rules", regexp.MustCompile(`namespace "default"`)),
+					resource.TestMatchResourceAttr(dataSourceName, "policies.1.rules", regexp.MustCompile(`namespace "default"`)),
 				),
 			},
 		},
@@ -52,6 +61,15 @@ data "nomad_acl_policies" "test" {
 `, prefix)
 }
 
+func testAccNomadAclPoliciesConfigWithRules(prefix string) string {
+	return fmt.Sprintf(`
+data "nomad_acl_policies" "test" {
+	prefix        = "%s"
+	include_rules = true
+}
+`, prefix)
+}
+
 func testAccCreateNomadAclPolicies(t *testing.T, n int) func() {
 	return func() {
 		client := testProvider.Meta().(ProviderConfig).client
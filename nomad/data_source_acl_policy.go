@@ -6,7 +6,6 @@ package nomad
 import (
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,7 +45,7 @@ func dataSourceAclPolicyRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
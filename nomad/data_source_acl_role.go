@@ -6,7 +6,6 @@ package nomad
 import (
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -60,7 +59,7 @@ func dataSourceACLRoleRead(d *schema.ResourceData, meta interface{}) error {
 
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -14,7 +14,7 @@ func dataSourceACLRoles() *schema.Resource {
 	return &schema.Resource{
 		Read: aclRolesDataSourceRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withListArguments(map[string]*schema.Schema{
 			"prefix": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -57,7 +57,7 @@ func dataSourceACLRoles() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
@@ -71,6 +71,11 @@ func aclRolesDataSourceRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return fmt.Errorf("failed to list ACL Roles: %v", err)
 	}
+	list := getListArguments(d)
+	aclRoles, err = filterList(list, aclRoles)
+	if err != nil {
+		return err
+	}
 
 	result := make([]map[string]interface{}, len(aclRoles))
 	for i, aclRole := range aclRoles {
@@ -88,6 +93,11 @@ func aclRolesDataSourceRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	result, err = list.sortAndLimit(result)
+	if err != nil {
+		return err
+	}
+
 	d.SetId("nomad-roles")
 	return d.Set("acl_roles", result)
 }
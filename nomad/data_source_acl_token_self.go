@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceACLTokenSelf() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceACLTokenSelfRead,
+		Schema: map[string]*schema.Schema{
+			"accessor_id": {
+				Description: "Non-sensitive identifier for this token.",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"name": {
+				Description: "Human-friendly name of the ACL token.",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"type": {
+				Description: "The type of the token.",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"policies": {
+				Description: "List of policy names associated with this token.",
+				Computed:    true,
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"roles": {
+				Description: "The roles that are applied to the token.",
+				Computed:    true,
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the ACL role.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the ACL role.",
+						},
+					},
+				},
+			},
+			"global": {
+				Description: "Whether the token is replicated to all regions, or if it will only be used in the region it was created.",
+				Computed:    true,
+				Type:        schema.TypeBool,
+			},
+			"create_time": {
+				Description: "Date and time the token was created.",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"expiration_ttl": {
+				Description: "The expiration TTL for the token.",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"expiration_time": {
+				Description: "The point after which a token is considered revoked and eligible for destruction.",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+		},
+	}
+}
+
+func dataSourceACLTokenSelfRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(ProviderConfig)
+	client := providerConfig.client
+
+	log.Printf("[DEBUG] Reading ACL token used by the provider")
+	token, _, err := client.ACLTokens().Self(nil)
+	if err != nil {
+		return fmt.Errorf("error reading ACL token used by the provider: %s", err)
+	}
+	log.Printf("[DEBUG] Read ACL token %q", token.AccessorID)
+
+	var expirationTime string
+	if token.ExpirationTime != nil {
+		expirationTime = token.ExpirationTime.Format(time.RFC3339)
+	}
+
+	roles := make([]map[string]interface{}, len(token.Roles))
+	for i, roleLink := range token.Roles {
+		roles[i] = map[string]interface{}{"id": roleLink.ID, "name": roleLink.Name}
+	}
+
+	d.SetId(token.AccessorID)
+	d.Set("accessor_id", token.AccessorID)
+	d.Set("name", token.Name)
+	d.Set("type", token.Type)
+	d.Set("policies", token.Policies)
+	d.Set("roles", roles)
+	d.Set("global", token.Global)
+	d.Set("create_time", token.CreateTime.UTC().String())
+	d.Set("expiration_ttl", token.ExpirationTTL.String())
+	d.Set("expiration_time", expirationTime)
+
+	return nil
+}
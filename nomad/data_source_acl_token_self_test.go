@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceACLTokenSelf_Basic(t *testing.T) {
+	resourceName := "data.nomad_acl_token_self.test"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceACLTokenSelfConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "accessor_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "create_time"),
+					resource.TestCheckResourceAttr(resourceName, "type", "management"),
+					resource.TestCheckResourceAttr(resourceName, "global", "true"),
+					resource.TestCheckNoResourceAttr(resourceName, "secret_id"),
+				),
+			},
+		},
+	})
+}
+
+const testDataSourceACLTokenSelfConfig = `
+data "nomad_acl_token_self" "test" {}
+`
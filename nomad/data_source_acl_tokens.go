@@ -15,7 +15,7 @@ func dataSourceACLTokens() *schema.Resource {
 	return &schema.Resource{
 		Read: aclTokensDataSourceRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withListArguments(map[string]*schema.Schema{
 			"prefix": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -78,15 +78,18 @@ func dataSourceACLTokens() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
 func aclTokensDataSourceRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(ProviderConfig).client
 
+	list := getListArguments(d)
 	qOpts := &api.QueryOptions{
-		Prefix: d.Get("prefix").(string),
+		Prefix:  d.Get("prefix").(string),
+		Filter:  list.serverFilter(""),
+		PerPage: list.perPage(),
 	}
 	tokens, _, err := client.ACLTokens().List(qOpts)
 	if err != nil {
@@ -118,6 +121,11 @@ func aclTokensDataSourceRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	result, err = list.sortAndLimit(result)
+	if err != nil {
+		return err
+	}
+
 	d.SetId("nomad-tokens")
 	return d.Set("acl_tokens", result)
 }
@@ -0,0 +1,212 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// noLeaderError is the error returned by the Nomad servers when they haven't
+// elected a leader.
+const noLeaderError = "No cluster leader"
+
+func dataSourceClusterReadiness() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceClusterReadinessRead,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": {
+				Description: "Only count the clients in this datacenter.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"node_pool": {
+				Description: "Only count the clients in this node pool.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"min_ready_clients": {
+				Description:  "The minimum number of ready clients required for the cluster to be ready.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_blocked_evals": {
+				Description:  "The maximum number of blocked evaluations allowed for the cluster to be ready.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"ready": {
+				Description: "Whether the cluster meets all the readiness requirements.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"status": {
+				Description: "The readiness of the cluster, either ready or not_ready.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"reasons": {
+				Description: "Why the cluster is not ready.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"has_leader": {
+				Description: "Whether the servers have elected a leader.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"leader": {
+				Description: "The address of the leader.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ready_clients": {
+				Description: "The number of clients that are ready, eligible for scheduling and not draining.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"clients_ready": {
+				Description: "Whether there are at least min_ready_clients ready clients.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"blocked_evals": {
+				Description: "The number of blocked evaluations in all namespaces.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"blocked_evals_ok": {
+				Description: "Whether there are at most max_blocked_evals blocked evaluations.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceClusterReadinessRead(d *schema.ResourceData, meta any) error {
+	client := meta.(ProviderConfig).client
+
+	datacenter := d.Get("datacenter").(string)
+	nodePool := d.Get("node_pool").(string)
+	id := strconv.Itoa(schema.HashString(datacenter + "/" + nodePool))
+
+	log.Printf("[DEBUG] Reading cluster readiness")
+	leader, err := client.Status().Leader()
+	if err != nil && !strings.Contains(err.Error(), noLeaderError) {
+		return fmt.Errorf("error reading cluster leader: %w", err)
+	}
+
+	// The clients and evaluations are read from any server so they can be
+	// reported while the servers have no leader.
+	nodes, _, err := client.Nodes().List(&api.QueryOptions{AllowStale: true})
+	if err != nil {
+		return fmt.Errorf("error reading nodes: %w", err)
+	}
+	evals, _, err := client.Evaluations().List(&api.QueryOptions{
+		Namespace:  api.AllNamespacesNamespace,
+		Filter:     fmt.Sprintf("Status == %q", api.EvalStatusBlocked),
+		AllowStale: true,
+	})
+	if err != nil {
+		return fmt.Errorf("error reading evaluations: %w", err)
+	}
+	log.Printf("[DEBUG] Read cluster readiness")
+
+	r := checkClusterReadiness(
+		leader, nodes, evals, datacenter, nodePool,
+		d.Get("min_ready_clients").(int), d.Get("max_blocked_evals").(int),
+	)
+
+	d.SetId(id)
+	sets := map[string]any{
+		"ready":            len(r.reasons) == 0,
+		"status":           r.status(),
+		"reasons":          r.reasons,
+		"has_leader":       r.leader != "",
+		"leader":           r.leader,
+		"ready_clients":    r.readyClients,
+		"clients_ready":    r.clientsReady,
+		"blocked_evals":    r.blockedEvals,
+		"blocked_evals_ok": r.blockedEvalsOK,
+	}
+	for k, v := range sets {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("error setting %s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// clusterReadiness is the result of the readiness checks of a cluster.
+type clusterReadiness struct {
+	leader         string
+	readyClients   int
+	clientsReady   bool
+	blockedEvals   int
+	blockedEvalsOK bool
+
+	// reasons explains why the cluster is not ready, it is empty when the
+	// cluster is ready.
+	reasons []string
+}
+
+func (r clusterReadiness) status() string {
+	if len(r.reasons) == 0 {
+		return "ready"
+	}
+	return "not_ready"
+}
+
+// checkClusterReadiness checks that the cluster has a leader, at least
+// minReadyClients clients in datacenter and nodePool that can run
+// allocations, and at most maxBlockedEvals blocked evaluations.
+func checkClusterReadiness(leader string, nodes []*api.NodeListStub, evals []*api.Evaluation, datacenter, nodePool string, minReadyClients, maxBlockedEvals int) clusterReadiness {
+	r := clusterReadiness{leader: leader, reasons: []string{}}
+	if leader == "" {
+		r.reasons = append(r.reasons, "the servers have no leader")
+	}
+
+	for _, n := range nodes {
+		if datacenter != "" && n.Datacenter != datacenter {
+			continue
+		}
+		if nodePool != "" && n.NodePool != nodePool {
+			continue
+		}
+		if n.Status == api.NodeStatusReady && n.SchedulingEligibility == api.NodeSchedulingEligible && !n.Drain {
+			r.readyClients++
+		}
+	}
+	r.clientsReady = r.readyClients >= minReadyClients
+	if !r.clientsReady {
+		r.reasons = append(r.reasons, fmt.Sprintf("%d of the %d required clients are ready", r.readyClients, minReadyClients))
+	}
+
+	// The evaluations are filtered by the API, the status is checked again in
+	// case the agent ignored the filter.
+	for _, e := range evals {
+		if e.Status == api.EvalStatusBlocked {
+			r.blockedEvals++
+		}
+	}
+	r.blockedEvalsOK = r.blockedEvals <= maxBlockedEvals
+	if !r.blockedEvalsOK {
+		r.reasons = append(r.reasons, fmt.Sprintf("%d evaluations are blocked, at most %d allowed", r.blockedEvals, maxBlockedEvals))
+	}
+
+	return r
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/shoenig/test/must"
+)
+
+func TestDataSourceClusterReadiness_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceClusterReadiness_config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nomad_cluster_readiness.default", "ready", "true"),
+					resource.TestCheckResourceAttr("data.nomad_cluster_readiness.default", "status", "ready"),
+					resource.TestCheckResourceAttr("data.nomad_cluster_readiness.default", "has_leader", "true"),
+					resource.TestCheckResourceAttrSet("data.nomad_cluster_readiness.default", "leader"),
+					resource.TestCheckResourceAttr("data.nomad_cluster_readiness.default", "ready_clients", "1"),
+					resource.TestCheckResourceAttr("data.nomad_cluster_readiness.default", "reasons.#", "0"),
+					resource.TestCheckResourceAttr("data.nomad_cluster_readiness.missing", "ready", "false"),
+					resource.TestCheckResourceAttr("data.nomad_cluster_readiness.missing", "status", "not_ready"),
+					resource.TestCheckResourceAttr("data.nomad_cluster_readiness.missing", "clients_ready", "false"),
+					resource.TestCheckResourceAttr("data.nomad_cluster_readiness.missing", "reasons.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+var testDataSourceClusterReadiness_config = `
+data "nomad_cluster_readiness" "default" {}
+
+data "nomad_cluster_readiness" "missing" {
+  datacenter = "not-a-datacenter"
+}
+`
+
+func TestCheckClusterReadiness(t *testing.T) {
+	nodes := []*api.NodeListStub{
+		{Datacenter: "dc1", NodePool: "default", Status: api.NodeStatusReady, SchedulingEligibility: api.NodeSchedulingEligible},
+		{Datacenter: "dc1", NodePool: "default", Status: api.NodeStatusReady, SchedulingEligibility: api.NodeSchedulingEligible, Drain: true},
+		{Datacenter: "dc1", NodePool: "gpu", Status: api.NodeStatusReady, SchedulingEligibility: api.NodeSchedulingEligible},
+		{Datacenter: "dc1", NodePool: "gpu", Status: api.NodeStatusReady, SchedulingEligibility: api.NodeSchedulingIneligible},
+		{Datacenter: "dc2", NodePool: "default", Status: api.NodeStatusReady, SchedulingEligibility: api.NodeSchedulingEligible},
+		{Datacenter: "dc2", NodePool: "default", Status: api.NodeStatusDown, SchedulingEligibility: api.NodeSchedulingEligible},
+	}
+	evals := []*api.Evaluation{
+		{ID: "eval1", Status: api.EvalStatusBlocked},
+		{ID: "eval2", Status: api.EvalStatusBlocked},
+		{ID: "eval3", Status: api.EvalStatusComplete},
+	}
+
+	cases := []struct {
+		name            string
+		leader          string
+		datacenter      string
+		nodePool        string
+		minReadyClients int
+		maxBlockedEvals int
+		want            clusterReadiness
+	}{
+		{
+			name:            "ready",
+			leader:          "10.0.0.1:4647",
+			minReadyClients: 3,
+			maxBlockedEvals: 2,
+			want: clusterReadiness{
+				leader:         "10.0.0.1:4647",
+				readyClients:   3,
+				clientsReady:   true,
+				blockedEvals:   2,
+				blockedEvalsOK: true,
+				reasons:        []string{},
+			},
+		},
+		{
+			name:            "filtered",
+			leader:          "10.0.0.1:4647",
+			datacenter:      "dc1",
+			nodePool:        "gpu",
+			minReadyClients: 2,
+			maxBlockedEvals: 2,
+			want: clusterReadiness{
+				leader:         "10.0.0.1:4647",
+				readyClients:   1,
+				blockedEvals:   2,
+				blockedEvalsOK: true,
+				reasons:        []string{"1 of the 2 required clients are ready"},
+			},
+		},
+		{
+			name:            "not ready",
+			minReadyClients: 1,
+			want: clusterReadiness{
+				readyClients: 3,
+				clientsReady: true,
+				blockedEvals: 2,
+				reasons: []string{
+					"the servers have no leader",
+					"2 evaluations are blocked, at most 0 allowed",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := checkClusterReadiness(tc.leader, nodes, evals, tc.datacenter, tc.nodePool, tc.minReadyClients, tc.maxBlockedEvals)
+			must.Eq(t, tc.want, got)
+		})
+	}
+}
@@ -17,28 +17,37 @@ func dataSourceDatacenters() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceDatacentersRead,
 
-		Schema: map[string]*schema.Schema{
-			"prefix": {
-				Description: "Prefix value used for filtering results.",
-				Type:        schema.TypeString,
-				Optional:    true,
-			},
-			"ignore_down_nodes": {
-				Description: "If enabled, this flag will ignore nodes that are down when listing datacenters.",
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-			},
-			"datacenters": {
-				Description: "The list of datacenters.",
-				Computed:    true,
-				Type:        schema.TypeList,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+		Schema: dataSourceDatacentersSchema(),
+	}
+}
+
+// dataSourceDatacentersSchema returns the schema of nomad_datacenters. The
+// filter argument applies to the nodes and limit to the datacenters, which
+// are always sorted by name so sort_by is not supported.
+func dataSourceDatacentersSchema() map[string]*schema.Schema {
+	s := withListArguments(map[string]*schema.Schema{
+		"prefix": {
+			Description: "Prefix value used for filtering results.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"ignore_down_nodes": {
+			Description: "If enabled, this flag will ignore nodes that are down when listing datacenters.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"datacenters": {
+			Description: "The list of datacenters.",
+			Computed:    true,
+			Type:        schema.TypeList,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
 		},
-	}
+	})
+	delete(s, "sort_by")
+	return s
 }
 
 func dataSourceDatacentersRead(d *schema.ResourceData, meta interface{}) error {
@@ -48,11 +57,21 @@ func dataSourceDatacentersRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("failed to query list of nodes: %v", err)
 	}
 
+	list := getListArguments(d)
+	nodes, err = filterList(list, nodes)
+	if err != nil {
+		return err
+	}
+
 	prefix := d.Get("prefix").(string)
 	ignoreDown := d.Get("ignore_down_nodes").(bool)
+	datacenters := filterDatacenters(nodes, prefix, ignoreDown)
+	if list.limit > 0 && len(datacenters) > list.limit {
+		datacenters = datacenters[:list.limit]
+	}
 
 	d.SetId(resource.UniqueId())
-	if err := d.Set("datacenters", filterDatacenters(nodes, prefix, ignoreDown)); err != nil {
+	if err := d.Set("datacenters", datacenters); err != nil {
 		return fmt.Errorf("error setting datacenters: %v", err)
 	}
 
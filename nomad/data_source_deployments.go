@@ -4,43 +4,101 @@
 package nomad
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceDeployments() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceDeploymentsRead,
-		Schema: map[string]*schema.Schema{
-
+		ReadContext: dataSourceDeploymentsRead,
+		Schema: withListArguments(map[string]*schema.Schema{
+			"job_id": {
+				Description: "Only return deployments for this job.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"namespace": {
+				Description: "The namespace to search for deployments in. Use '*' for all namespaces.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": {
+				Description: "Only return deployments with this status.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					api.DeploymentStatusRunning,
+					api.DeploymentStatusPaused,
+					api.DeploymentStatusFailed,
+					api.DeploymentStatusSuccessful,
+					api.DeploymentStatusCancelled,
+					api.DeploymentStatusPending,
+					api.DeploymentStatusBlocked,
+					api.DeploymentStatusUnblocking,
+				}, false),
+			},
+			"latest_only": {
+				Description: "Only return the most recent deployment of each job.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"deployments": {
 				Description: "Deployments",
 				Computed:    true,
 				Type:        schema.TypeList,
 				Elem:        &schema.Schema{Type: schema.TypeMap},
 			},
-		},
+			"wait_for": dataSourceWaitForSchema(),
+		}),
 	}
 }
 
-func dataSourceDeploymentsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceDeploymentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConfig := meta.(ProviderConfig)
 	client := providerConfig.client
 
+	jobID := d.Get("job_id").(string)
+	namespace := d.Get("namespace").(string)
+	status := d.Get("status").(string)
+	latestOnly := d.Get("latest_only").(bool)
+	list := getListArguments(d)
+
+	queryOptions := &api.QueryOptions{
+		Namespace: namespace,
+		Filter:    list.serverFilter(deploymentsFilter(jobID, status)),
+	}
+	if !latestOnly {
+		queryOptions.PerPage = list.perPage()
+	}
+
 	log.Printf("[DEBUG] Getting deployments...")
-	deployment_list, _, err := client.Deployments().List(nil)
+	deployment_list, err := dataSourceWaitFor(ctx, d, "deployments", func(ctx context.Context) ([]*api.Deployment, error) {
+		deployment_list, _, err := client.Deployments().List(queryOptions.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if latestOnly {
+			deployment_list = latestDeployments(deployment_list)
+		}
+		return deployment_list, nil
+	})
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
-			return err
+		if isNotFoundError(err) {
+			return diag.FromErr(err)
 		}
 
-		return fmt.Errorf("error checking for deployments: %#v", err)
+		return diag.Errorf("error checking for deployments: %s", err)
 	}
 
 	var deployments []map[string]interface{}
@@ -50,12 +108,57 @@ func dataSourceDeploymentsRead(d *schema.ResourceData, meta interface{}) error {
 		entry["ID"] = deployment.ID
 		entry["JobID"] = deployment.JobID
 		entry["JobVersion"] = strconv.Itoa(int(deployment.JobVersion))
+		entry["Namespace"] = deployment.Namespace
 		entry["Status"] = deployment.Status
 		entry["StatusDescription"] = deployment.StatusDescription
 		deployments = append(deployments, entry)
 	}
+	deployments, err = list.sortAndLimit(deployments)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	d.SetId(client.Address() + "/deployments")
+	id := client.Address() + "/deployments"
+	if jobID != "" || namespace != "" || status != "" || latestOnly || list.filter != "" {
+		id += "/" + strconv.Itoa(schema.HashString(
+			fmt.Sprintf("%s/%s/%s/%t/%s", namespace, jobID, status, latestOnly, list.filter)))
+	}
+	d.SetId(id)
+
+	return diag.FromErr(d.Set("deployments", deployments))
+}
+
+// deploymentsFilter returns the filter expression used to select deployments
+// by job ID and status on the server.
+func deploymentsFilter(jobID, status string) string {
+	var exprs []string
+	if jobID != "" {
+		exprs = append(exprs, fmt.Sprintf("JobID == %q", jobID))
+	}
+	if status != "" {
+		exprs = append(exprs, fmt.Sprintf("Status == %q", status))
+	}
+	return strings.Join(exprs, " and ")
+}
+
+// latestDeployments returns the most recently created deployment of each job,
+// preserving the order in which the jobs were first seen.
+func latestDeployments(deployments []*api.Deployment) []*api.Deployment {
+	latest := make(map[string]int)
+	result := make([]*api.Deployment, 0, len(deployments))
+
+	for _, deployment := range deployments {
+		key := deployment.Namespace + "/" + deployment.JobID
+		i, ok := latest[key]
+		if !ok {
+			latest[key] = len(result)
+			result = append(result, deployment)
+			continue
+		}
+		if deployment.CreateIndex > result[i].CreateIndex {
+			result[i] = deployment
+		}
+	}
 
-	return d.Set("deployments", deployments)
+	return result
 }
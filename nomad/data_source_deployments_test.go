@@ -9,6 +9,8 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -29,21 +31,13 @@ func TestAccDataSourceDeployments(t *testing.T) {
 			},
 			{
 				Config: testAccCheckDataSourceNomadDeploymentsCfgWithJob,
-				Check: func(s *terraform.State) error {
-					rs, _ := s.RootModule().Resources["data.nomad_deployments.foobar"]
-					is := rs.Primary
-					v, ok := is.Attributes["deployments.#"]
-					if !ok {
-						return fmt.Errorf("Attribute '%s' not found", "deployments.#")
-					}
-					numDeployments, err := strconv.Atoi(v)
-					if err != nil {
-						return fmt.Errorf("received error parsing 'deployments.#': %v", err)
-					} else if numDeployments < 1 {
-						return fmt.Errorf("Attribute 'deployments.#' should be >= 1, got %v", v)
-					}
-					return nil
-				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nomad_deployments.filtered", "deployments.#", "1"),
+					resource.TestCheckResourceAttr("data.nomad_deployments.filtered", "deployments.0.JobID", "foo_deploy"),
+					resource.TestCheckResourceAttr("data.nomad_deployments.filtered", "deployments.0.Namespace", "default"),
+					resource.TestCheckResourceAttr("data.nomad_deployments.missing", "deployments.#", "0"),
+					testAccCheckDataSourceNomadDeploymentsCount,
+				),
 				Destroy: true,
 			},
 			{
@@ -108,10 +102,81 @@ resource "nomad_job" "foobar" {
 	EOT
 }
 `
+
+func testAccCheckDataSourceNomadDeploymentsCount(s *terraform.State) error {
+	rs, _ := s.RootModule().Resources["data.nomad_deployments.foobar"]
+	is := rs.Primary
+	v, ok := is.Attributes["deployments.#"]
+	if !ok {
+		return fmt.Errorf("Attribute '%s' not found", "deployments.#")
+	}
+	numDeployments, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("received error parsing 'deployments.#': %v", err)
+	} else if numDeployments < 1 {
+		return fmt.Errorf("Attribute 'deployments.#' should be >= 1, got %v", v)
+	}
+	return nil
+}
+
+var testAccCheckDataSourceNomadDeploymentsFilteredCfg = `
+data "nomad_deployments" "filtered" {
+  job_id      = nomad_job.foobar.id
+  namespace   = "default"
+  latest_only = true
+}
+
+data "nomad_deployments" "missing" {
+  job_id = nomad_job.foobar.id
+  status = "paused"
+}
+`
+
 var testAccCheckDataSourceNomadDeploymentsCfg = `
 
 data "nomad_deployments" "foobar" {}
 
 `
 
-var testAccCheckDataSourceNomadDeploymentsCfgWithJob = testAccCheckDataSourceNomadDeploymentsJobCfg + testAccCheckDataSourceNomadDeploymentsCfg
+var testAccCheckDataSourceNomadDeploymentsCfgWithJob = testAccCheckDataSourceNomadDeploymentsJobCfg +
+	testAccCheckDataSourceNomadDeploymentsCfg +
+	testAccCheckDataSourceNomadDeploymentsFilteredCfg
+
+func TestDeploymentsFilter(t *testing.T) {
+	cases := []struct {
+		jobID  string
+		status string
+		want   string
+	}{
+		{want: ""},
+		{jobID: "example", want: `JobID == "example"`},
+		{status: "running", want: `Status == "running"`},
+		{jobID: "example", status: "running", want: `JobID == "example" and Status == "running"`},
+	}
+
+	for _, tc := range cases {
+		if got := deploymentsFilter(tc.jobID, tc.status); got != tc.want {
+			t.Errorf("deploymentsFilter(%q, %q) = %q, want %q", tc.jobID, tc.status, got, tc.want)
+		}
+	}
+}
+
+func TestLatestDeployments(t *testing.T) {
+	deployments := []*api.Deployment{
+		{ID: "a1", Namespace: "default", JobID: "a", CreateIndex: 10},
+		{ID: "b1", Namespace: "default", JobID: "b", CreateIndex: 11},
+		{ID: "a2", Namespace: "default", JobID: "a", CreateIndex: 12},
+		{ID: "a3", Namespace: "prod", JobID: "a", CreateIndex: 9},
+		{ID: "b0", Namespace: "default", JobID: "b", CreateIndex: 5},
+	}
+
+	var got []string
+	for _, d := range latestDeployments(deployments) {
+		got = append(got, d.ID)
+	}
+
+	want := []string{"a2", "b1", "a3"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("latestDeployments() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -139,6 +139,18 @@ func dataSourceDynamicHostVolume() *schema.Resource {
 				Computed: true,
 				Type:     schema.TypeInt,
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the volume was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the volume was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -161,8 +173,10 @@ func dynamicHostVolumeRead(d *schema.ResourceData, meta any) error {
 		"capacity_max_bytes": vol.RequestedCapacityMaxBytes,
 		"capacity_min_bytes": vol.RequestedCapacityMinBytes,
 		"constraint":         flattenConstraints(vol.Constraints),
+		"create_index":       int(vol.CreateIndex),
 		"host_path":          vol.HostPath,
 		"id":                 vol.ID,
+		"modify_index":       int(vol.ModifyIndex),
 		"name":               vol.Name,
 		"namespace":          vol.Namespace,
 		"node_id":            vol.NodeID,
@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEventsRead,
+
+		Schema: map[string]*schema.Schema{
+			"topics": {
+				Description: "Topics to read events from, in the form Topic or Topic:Key.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateEventTopic,
+				},
+			},
+			"namespace": {
+				Description: "The namespace to read events from. Use '*' for all namespaces.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+			},
+			"start_index": {
+				Description: "Only return events with a raft index greater than this value.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+			},
+			"end_index": {
+				Description: "Stop reading once an event with a raft index greater than this value is received.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"max_events": {
+				Description:  "The maximum number of events to return.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"wait": {
+				Description: "How long to wait for new events before returning.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "2s",
+			},
+			"last_index": {
+				Description: "The raft index of the last event returned.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"events": {
+				Description: "The list of events read.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"topic": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"index": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"filter_keys": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"payload": {
+							Description: "The JSON encoded event payload.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+var eventTopics = []string{
+	string(api.TopicAll),
+	string(api.TopicAllocation),
+	string(api.TopicDeployment),
+	string(api.TopicEvaluation),
+	string(api.TopicJob),
+	string(api.TopicNode),
+	string(api.TopicNodePool),
+	string(api.TopicService),
+}
+
+func validateEventTopic(i interface{}, path cty.Path) diag.Diagnostics {
+	topic, _, _ := strings.Cut(i.(string), ":")
+	for _, t := range eventTopics {
+		if topic == t {
+			return nil
+		}
+	}
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       "Invalid event topic",
+		Detail:        fmt.Sprintf("topic %q must be one of %s", topic, strings.Join(eventTopics, ", ")),
+		AttributePath: path,
+	}}
+}
+
+func dataSourceEventsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(ProviderConfig).client
+
+	topics := map[api.Topic][]string{}
+	for _, raw := range d.Get("topics").([]any) {
+		topic, key, found := strings.Cut(raw.(string), ":")
+		if !found {
+			key = "*"
+		}
+		topics[api.Topic(topic)] = append(topics[api.Topic(topic)], key)
+	}
+	if len(topics) == 0 {
+		topics[api.TopicAll] = []string{"*"}
+	}
+
+	startIndex := uint64(d.Get("start_index").(int))
+	endIndex := uint64(d.Get("end_index").(int))
+	maxEvents := d.Get("max_events").(int)
+	wait, err := time.ParseDuration(d.Get("wait").(string))
+	if err != nil {
+		return diag.Errorf("failed to parse wait: %v", err)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	log.Printf("[DEBUG] Reading events from index %d", startIndex)
+	eventsCh, err := client.EventStream().Stream(streamCtx, topics, startIndex, &api.QueryOptions{
+		Namespace: d.Get("namespace").(string),
+	})
+	if err != nil {
+		return diag.Errorf("error reading events: %v", err)
+	}
+
+	events, err := collectEvents(streamCtx, eventsCh, startIndex, endIndex, maxEvents, wait)
+	if err != nil {
+		return diag.Errorf("error reading events: %v", err)
+	}
+	log.Printf("[DEBUG] Read %d events", len(events))
+
+	lastIndex := startIndex
+	result := make([]map[string]any, 0, len(events))
+	for _, e := range events {
+		payload, err := json.Marshal(e.Payload)
+		if err != nil {
+			return diag.Errorf("error encoding payload for event %d: %v", e.Index, err)
+		}
+		result = append(result, map[string]any{
+			"topic":       string(e.Topic),
+			"type":        e.Type,
+			"key":         e.Key,
+			"index":       int(e.Index),
+			"filter_keys": e.FilterKeys,
+			"payload":     string(payload),
+		})
+		lastIndex = e.Index
+	}
+
+	d.SetId(strconv.FormatUint(startIndex, 10) + "-" + strconv.FormatUint(lastIndex, 10))
+	if err := d.Set("last_index", int(lastIndex)); err != nil {
+		return diag.Errorf("error setting last_index: %v", err)
+	}
+	if err := d.Set("events", result); err != nil {
+		return diag.Errorf("error setting events: %v", err)
+	}
+	return nil
+}
+
+// collectEvents reads events from eventsCh until maxEvents are collected, an
+// event past endIndex is received, or no new events arrive within wait. An
+// endIndex of 0 means there is no upper bound.
+func collectEvents(ctx context.Context, eventsCh <-chan *api.Events, startIndex, endIndex uint64, maxEvents int, wait time.Duration) ([]api.Event, error) {
+	var events []api.Event
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+
+		case <-timer.C:
+			return events, nil
+
+		case batch, ok := <-eventsCh:
+			if !ok {
+				return events, nil
+			}
+			if batch.Err != nil {
+				return nil, batch.Err
+			}
+
+			for _, e := range batch.Events {
+				if e.Index <= startIndex {
+					continue
+				}
+				if endIndex > 0 && e.Index > endIndex {
+					return events, nil
+				}
+				events = append(events, e)
+				if len(events) >= maxEvents {
+					return events, nil
+				}
+			}
+
+			timer.Reset(wait)
+		}
+	}
+}
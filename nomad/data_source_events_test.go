@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/shoenig/test/must"
+)
+
+func TestDataSourceEvents_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-nomad-test")
+	dataSourceName := "data.nomad_events.job"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceEvents_config(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "events.0.topic", "Job"),
+					resource.TestCheckResourceAttr(dataSourceName, "events.0.key", name),
+					resource.TestCheckResourceAttrSet(dataSourceName, "events.0.index"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "events.0.payload"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "last_index"),
+				),
+			},
+		},
+		CheckDestroy: testResourceJob_checkDestroy(name),
+	})
+}
+
+func testDataSourceEvents_config(name string) string {
+	return fmt.Sprintf(`
+resource "nomad_job" "test" {
+  jobspec = <<EOT
+job "%[1]s" {
+  datacenters = ["dc1"]
+  type        = "batch"
+
+  group "sleep" {
+    task "sleep" {
+      driver = "raw_exec"
+
+      config {
+        command = "/bin/sleep"
+        args    = ["1"]
+      }
+    }
+  }
+}
+EOT
+  detach = true
+}
+
+data "nomad_events" "job" {
+  topics     = ["Job:${nomad_job.test.id}"]
+  max_events = 1
+}
+`, name)
+}
+
+func TestCollectEvents(t *testing.T) {
+	batches := func(bs ...*api.Events) <-chan *api.Events {
+		ch := make(chan *api.Events, len(bs))
+		for _, b := range bs {
+			ch <- b
+		}
+		return ch
+	}
+	batch := func(indexes ...uint64) *api.Events {
+		events := &api.Events{}
+		for _, i := range indexes {
+			events.Index = i
+			events.Events = append(events.Events, api.Event{Index: i})
+		}
+		return events
+	}
+	indexes := func(events []api.Event) []uint64 {
+		out := []uint64{}
+		for _, e := range events {
+			out = append(out, e.Index)
+		}
+		return out
+	}
+
+	testCases := []struct {
+		name       string
+		ch         <-chan *api.Events
+		startIndex uint64
+		endIndex   uint64
+		maxEvents  int
+		want       []uint64
+		wantErr    bool
+	}{
+		{
+			name:      "wait elapses",
+			ch:        batches(batch(1, 2), batch(3)),
+			maxEvents: 10,
+			want:      []uint64{1, 2, 3},
+		},
+		{
+			name:      "max events",
+			ch:        batches(batch(1, 2), batch(3)),
+			maxEvents: 2,
+			want:      []uint64{1, 2},
+		},
+		{
+			name:       "index range",
+			ch:         batches(batch(1, 2), batch(3), batch(4)),
+			startIndex: 1,
+			endIndex:   3,
+			maxEvents:  10,
+			want:       []uint64{2, 3},
+		},
+		{
+			name:      "stream error",
+			ch:        batches(batch(1), &api.Events{Err: errors.New("boom")}),
+			maxEvents: 10,
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := collectEvents(context.Background(), tc.ch, tc.startIndex, tc.endIndex, tc.maxEvents, 50*time.Millisecond)
+			if tc.wantErr {
+				must.Error(t, err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.want, indexes(got))
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -44,7 +45,17 @@ func dataSourceJobParserRead(d *schema.ResourceData, meta interface{}) error {
 	canonicalize := d.Get("canonicalize").(bool)
 
 	log.Printf("[DEBUG] Parsing Job with Canonicalize set to %t", canonicalize)
-	job, err := client.Jobs().ParseHCL(hcl, canonicalize)
+	var job *api.Job
+	var err error
+	if providerConfig.offline {
+		// The jobspec is parsed locally, the same way the Nomad API does.
+		job, err = parseHCL2Jobspec(hcl, HCL2JobParserConfig{})
+		if err == nil && canonicalize {
+			job.Canonicalize()
+		}
+	} else {
+		job, err = client.Jobs().ParseHCL(hcl, canonicalize)
+	}
 	if err != nil {
 		return fmt.Errorf("error parsing job: %#v", err)
 	}
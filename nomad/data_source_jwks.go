@@ -52,6 +52,11 @@ func dataSourceJWKS() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"pem": {
+							Description: "The key rendered as a PEM-encoded X.509 public key",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -94,11 +99,6 @@ func dataSourceJWKSRead(d *schema.ResourceData, meta any) error {
 		return fmt.Errorf("no keys found")
 	}
 
-	d.SetId(id.UniqueId())
-	if err := d.Set("keys", fromKeys(jwks.Keys)); err != nil {
-		return fmt.Errorf("error setting JWKS: %#v", err)
-	}
-
 	pemKeys := make([]string, 0, len(jwks.Keys))
 
 	for _, key := range jwks.Keys {
@@ -109,6 +109,11 @@ func dataSourceJWKSRead(d *schema.ResourceData, meta any) error {
 		pemKeys = append(pemKeys, pemKey)
 	}
 
+	d.SetId(id.UniqueId())
+	if err := d.Set("keys", fromKeys(jwks.Keys, pemKeys)); err != nil {
+		return fmt.Errorf("error setting JWKS: %#v", err)
+	}
+
 	if err := d.Set("pem_keys", pemKeys); err != nil {
 		return fmt.Errorf("error setting JWKS pemKeys: %s", err)
 	}
@@ -158,9 +163,9 @@ func keyToPem(key Key) (string, error) {
 	return string(x509CertEncoded), nil
 }
 
-func fromKeys(keys []Key) []interface{} {
+func fromKeys(keys []Key, pemKeys []string) []interface{} {
 	output := make([]interface{}, 0, len(keys))
-	for _, key := range keys {
+	for i, key := range keys {
 		p := map[string]interface{}{
 			"key_use":   key.KeyUse,
 			"key_type":  key.KeyType,
@@ -168,6 +173,7 @@ func fromKeys(keys []Key) []interface{} {
 			"algorithm": key.Algorithm,
 			"modulus":   key.Modulus,
 			"exponent":  key.Exponent,
+			"pem":       pemKeys[i],
 		}
 		output = append(output, p)
 	}
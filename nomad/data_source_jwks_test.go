@@ -33,6 +33,8 @@ func TestAccDataSourceNomadJWKS_Basic(t *testing.T) {
 					resource.TestMatchResourceAttr(dataSourceName, "keys.0.key_type", regexp.MustCompile("RSA")),
 					resource.TestCheckResourceAttr(dataSourceName, "pem_keys.#", expectedKeyCount),
 					resource.TestCheckResourceAttrWith(dataSourceName, "pem_keys.0", validateKeyPEM),
+					resource.TestCheckResourceAttrWith(dataSourceName, "keys.0.pem", validateKeyPEM),
+					resource.TestCheckResourceAttrPair(dataSourceName, "keys.0.pem", dataSourceName, "pem_keys.0"),
 				),
 			},
 		},
@@ -14,16 +14,33 @@ func dataSourceNamespaces() *schema.Resource {
 	return &schema.Resource{
 		Read: namespacesDataSourceRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withListArguments(map[string]*schema.Schema{
 			"namespaces": {
 				Type:     schema.TypeList,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Computed: true,
 			},
-		},
+			"namespace_details": {
+				Description: "The full details of each namespace.",
+				Type:        schema.TypeList,
+				Elem:        namespaceDetailsResource(),
+				Computed:    true,
+			},
+		}),
 	}
 }
 
+// namespaceDetailsResource returns the schema of a namespace as read by the
+// nomad_namespace data source, with the name marked as computed.
+func namespaceDetailsResource() *schema.Resource {
+	s := dataSourceNamespace().Schema
+	s["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	return &schema.Resource{Schema: s}
+}
+
 func namespacesDataSourceRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(ProviderConfig).client
 
@@ -32,12 +49,35 @@ func namespacesDataSourceRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return fmt.Errorf("error reading namespaces from Nomad: %s", err)
 	}
-	namespaces := make([]string, 0, len(resp))
+	list := getListArguments(d)
+	resp, err = filterList(list, resp)
+	if err != nil {
+		return err
+	}
+	details := make([]map[string]any, 0, len(resp))
 	for _, v := range resp {
-		namespaces = append(namespaces, v.Name)
+		details = append(details, map[string]any{
+			"name":             v.Name,
+			"description":      v.Description,
+			"quota":            v.Quota,
+			"meta":             v.Meta,
+			"capabilities":     flattenNamespaceCapabilities(v.Capabilities),
+			"node_pool_config": flattenNamespaceNodePoolConfig(v.NodePoolConfiguration),
+		})
+	}
+	details, err = list.sortAndLimit(details)
+	if err != nil {
+		return err
+	}
+	namespaces := make([]string, 0, len(details))
+	for _, v := range details {
+		namespaces = append(namespaces, v["name"].(string))
 	}
 	log.Printf("[DEBUG] Read namespaces from Nomad")
 	d.SetId(client.Address() + "/namespaces")
 
-	return d.Set("namespaces", namespaces)
+	if err := d.Set("namespaces", namespaces); err != nil {
+		return fmt.Errorf("error setting namespaces: %v", err)
+	}
+	return d.Set("namespace_details", details)
 }
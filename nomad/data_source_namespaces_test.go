@@ -19,7 +19,13 @@ func TestDataSourceNamespaces(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: testDataSourceNamespaces_config,
-				Check:  testDataSourceNamespaces_check,
+				Check: resource.ComposeTestCheckFunc(
+					testDataSourceNamespaces_check,
+					resource.TestCheckTypeSetElemNestedAttrs("data.nomad_namespaces.test", "namespace_details.*", map[string]string{
+						"name":        "default",
+						"description": "Default shared namespace",
+					}),
+				),
 			},
 		},
 	})
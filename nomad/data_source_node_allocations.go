@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNodeAllocations() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNodeAllocationsRead,
+
+		Schema: withListArguments(map[string]*schema.Schema{
+			"node_id": {
+				Description: "The ID of the node to list allocations for.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"include_terminal": {
+				Description: "If true, allocations in a terminal state are also returned.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"allocations": {
+				Description: "List of allocations placed on the node.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        allocationStubResource(),
+			},
+		}),
+	}
+}
+
+func dataSourceNodeAllocationsRead(d *schema.ResourceData, meta any) error {
+	client := meta.(ProviderConfig).client
+
+	nodeID := d.Get("node_id").(string)
+	includeTerminal := d.Get("include_terminal").(bool)
+
+	log.Printf("[DEBUG] Reading allocations for node %q", nodeID)
+	resp, _, err := client.Nodes().Allocations(nodeID, nil)
+	if err != nil {
+		return fmt.Errorf("error reading allocations for node %q: %w", nodeID, err)
+	}
+	list := getListArguments(d)
+	resp, err = filterList(list, resp)
+	if err != nil {
+		return err
+	}
+
+	allocs := make([]map[string]any, 0, len(resp))
+	for _, alloc := range resp {
+		if !includeTerminal && (alloc.ServerTerminalStatus() || alloc.ClientTerminalStatus()) {
+			continue
+		}
+		allocs = append(allocs, flattenAllocationStub(alloc.Stub()))
+	}
+	allocs, err = list.sortAndLimit(allocs)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Read allocations for node %q", nodeID)
+
+	d.SetId(nodeID)
+	return d.Set("allocations", allocs)
+}
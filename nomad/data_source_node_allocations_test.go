@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceNodeAllocations_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-nomad-test")
+	resourceName := "data.nomad_node_allocations.test"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceAllocations_basicConfig_jobOnly(name),
+			},
+			{
+				Config: testDataSourceNodeAllocations_config(name),
+				Check: resource.ComposeTestCheckFunc(
+					testDataSourceAllocations_waitForAllocs(t, 3),
+
+					resource.TestCheckResourceAttrPair(resourceName, "node_id", "data.nomad_allocations.by_job", "allocations.0.node_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "allocations.#"),
+					resource.TestCheckResourceAttrPair(resourceName, "allocations.0.node_id", "data.nomad_allocations.by_job", "allocations.0.node_id"),
+					resource.TestCheckResourceAttr("data.nomad_node_allocations.missing", "allocations.#", "0"),
+				),
+			},
+		},
+		CheckDestroy: testResourceJob_checkDestroy(name),
+	})
+}
+
+func testDataSourceNodeAllocations_config(prefix string) string {
+	return fmt.Sprintf(`
+%s
+
+data "nomad_allocations" "by_job" {
+  filter = "JobID == \"${nomad_job.test.id}\""
+}
+
+data "nomad_node_allocations" "test" {
+  node_id = data.nomad_allocations.by_job.allocations[0].node_id
+}
+
+data "nomad_node_allocations" "missing" {
+  node_id = "00000000-0000-0000-0000-000000000000"
+}
+`, testDataSourceAllocations_basicConfig_jobOnly(prefix))
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"log"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,17 +16,12 @@ func dataSourceNodePools() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceNodePoolsRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withListArguments(map[string]*schema.Schema{
 			"prefix": {
 				Description: "Specifies a string to filter node pools based on a name prefix.",
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
-			"filter": {
-				Description: "Specifies the expression used to filter the results.",
-				Type:        schema.TypeString,
-				Optional:    true,
-			},
 			"node_pools": {
 				Description: "List of node pools returned",
 				Type:        schema.TypeList,
@@ -80,7 +74,7 @@ func dataSourceNodePools() *schema.Resource {
 				},
 				Computed: true,
 			},
-		},
+		}),
 	}
 }
 
@@ -88,16 +82,17 @@ func dataSourceNodePoolsRead(d *schema.ResourceData, meta any) error {
 	client := meta.(ProviderConfig).client
 
 	prefix := d.Get("prefix").(string)
-	filter := d.Get("filter").(string)
-	id := strconv.Itoa(schema.HashString(prefix + filter))
+	list := getListArguments(d)
+	id := strconv.Itoa(schema.HashString(prefix + list.filter))
 
 	log.Printf("[DEBUG] Reading node pool list")
 	resp, _, err := client.NodePools().List(&api.QueryOptions{
-		Prefix: prefix,
-		Filter: filter,
+		Prefix:  prefix,
+		Filter:  list.serverFilter(""),
+		PerPage: list.perPage(),
 	})
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -113,6 +108,10 @@ func dataSourceNodePoolsRead(d *schema.ResourceData, meta any) error {
 			"scheduler_config": flattenNodePoolSchedulerConfiguration(p.SchedulerConfiguration),
 		}
 	}
+	pools, err = list.sortAndLimit(pools)
+	if err != nil {
+		return err
+	}
 	log.Printf("[DEBUG] Read node pool list")
 
 	d.SetId(id)
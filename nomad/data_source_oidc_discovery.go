@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOIDCDiscovery() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOIDCDiscoveryRead,
+		Schema: map[string]*schema.Schema{
+			"ca_pem": {
+				Description: "PEM-encoded certificate authorities used to verify the certificate of the JWKS URI host. Defaults to the certificate authority of the provider.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"issuer": {
+				Description: "The issuer of workload identity JWTs.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"jwks_uri": {
+				Description: "The URL of the JSON Web Key Set (JWKS) used to validate workload identity JWTs.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"id_token_signing_alg_values_supported": {
+				Description: "The signing algorithms used for workload identity JWTs.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"response_types_supported": {
+				Description: "The OIDC response types supported.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"subject_types_supported": {
+				Description: "The OIDC subject types supported.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"thumbprints": {
+				Description: "The SHA-1 thumbprints of the certificates presented by the JWKS URI host, starting with the leaf certificate.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ca_thumbprint": {
+				Description: "The SHA-1 thumbprint of the top certificate authority presented by the JWKS URI host.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type oidcDiscoveryDocument struct {
+	Issuer        string   `json:"issuer"`
+	JWKSURI       string   `json:"jwks_uri"`
+	SigningAlgs   []string `json:"id_token_signing_alg_values_supported"`
+	ResponseTypes []string `json:"response_types_supported"`
+	SubjectTypes  []string `json:"subject_types_supported"`
+}
+
+func dataSourceOIDCDiscoveryRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	providerConfig := meta.(ProviderConfig)
+	client := providerConfig.client
+
+	var doc oidcDiscoveryDocument
+
+	log.Printf("[DEBUG] Reading OIDC discovery document from Nomad")
+	_, err := client.Raw().Query("/.well-known/openid-configuration", &doc, &api.QueryOptions{})
+	if err != nil {
+		return diag.Errorf("error reading OIDC discovery document from Nomad: %s", err)
+	}
+	if doc.Issuer == "" {
+		return diag.Errorf("OIDC discovery document does not have an issuer, the oidc_issuer option may not be set in the Nomad server configuration")
+	}
+
+	tlsConfig, err := oidcDiscoveryTLSConfig(d.Get("ca_pem").(string), providerConfig.config)
+	if err != nil {
+		return diag.Errorf("error configuring TLS for %q: %s", doc.JWKSURI, err)
+	}
+	thumbprints, err := fetchCertificateThumbprints(ctx, doc.JWKSURI, tlsConfig)
+	if err != nil {
+		return diag.Errorf("error reading certificates for %q: %s", doc.JWKSURI, err)
+	}
+
+	var caThumbprint string
+	if len(thumbprints) > 0 {
+		caThumbprint = thumbprints[len(thumbprints)-1]
+	}
+
+	d.SetId(doc.Issuer)
+	d.Set("issuer", doc.Issuer)
+	d.Set("jwks_uri", doc.JWKSURI)
+	d.Set("id_token_signing_alg_values_supported", doc.SigningAlgs)
+	d.Set("response_types_supported", doc.ResponseTypes)
+	d.Set("subject_types_supported", doc.SubjectTypes)
+	d.Set("thumbprints", thumbprints)
+	d.Set("ca_thumbprint", caThumbprint)
+
+	return nil
+}
+
+// oidcDiscoveryTLSConfig returns the TLS configuration used to connect to the
+// JWKS URI host. The JWKS are usually served by the Nomad servers themselves,
+// so the TLS settings of the provider are used unless caPEM is set.
+func oidcDiscoveryTLSConfig(caPEM string, conf *api.Config) (*tls.Config, error) {
+	if caPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+			return nil, errors.New("failed to parse CA certificate")
+		}
+		return &tls.Config{RootCAs: pool}, nil
+	}
+
+	if conf == nil || conf.TLSConfig == nil {
+		return nil, nil
+	}
+	httpClient := nonPooledHttpClient()
+	if err := api.ConfigureTLS(httpClient, conf.TLSConfig); err != nil {
+		return nil, err
+	}
+	return httpClient.Transport.(*http.Transport).TLSClientConfig, nil
+}
+
+// fetchCertificateThumbprints connects to the host of rawURL and returns the
+// SHA-1 thumbprints of the certificate chain it presents. URLs that don't use
+// HTTPS don't have certificates, so no thumbprints are returned for them.
+func fetchCertificateThumbprints(ctx context.Context, rawURL string, tlsConfig *tls.Config) ([]string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %v", err)
+	}
+	if u.Scheme != "https" {
+		return []string{}, nil
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.ServerName = u.Hostname()
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: 10 * time.Second},
+		Config:    tlsConfig,
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	thumbprints := make([]string, 0, len(certs))
+	for _, cert := range certs {
+		sum := sha1.Sum(cert.Raw)
+		thumbprints = append(thumbprints, hex.EncodeToString(sum[:]))
+	}
+
+	return thumbprints, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/shoenig/test/must"
+)
+
+const testAccNomadOIDCDiscoveryConfig = `data "nomad_oidc_discovery" "test" {}`
+
+func TestAccDataSourceNomadOIDCDiscovery_Basic(t *testing.T) {
+	dataSourceName := "data.nomad_oidc_discovery.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckMinVersion(t, "1.7.0")
+			testCheckOIDCIssuerConfigured(t)
+		},
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNomadOIDCDiscoveryConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "issuer"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "jwks_uri"),
+					resource.TestCheckResourceAttr(dataSourceName, "id_token_signing_alg_values_supported.0", "RS256"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckOIDCIssuerConfigured(t *testing.T) {
+	t.Helper()
+	client := testProvider.Meta().(ProviderConfig).client
+
+	var doc oidcDiscoveryDocument
+	if _, err := client.Raw().Query("/.well-known/openid-configuration", &doc, nil); err != nil || doc.Issuer == "" {
+		t.Skipf("OIDC issuer is not configured: %v", err)
+	}
+}
+
+func TestFetchCertificateThumbprints(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	tlsConfig := srv.Client().Transport.(*http.Transport).TLSClientConfig
+	sum := sha1.Sum(srv.Certificate().Raw)
+
+	got, err := fetchCertificateThumbprints(context.Background(), srv.URL+"/.well-known/jwks.json", tlsConfig)
+	must.NoError(t, err)
+	must.Eq(t, []string{hex.EncodeToString(sum[:])}, got)
+
+	got, err = fetchCertificateThumbprints(context.Background(), "http://127.0.0.1:4646/.well-known/jwks.json", nil)
+	must.NoError(t, err)
+	must.SliceEmpty(t, got)
+}
+
+func TestOIDCDiscoveryTLSConfig(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+	jwksURL := srv.URL + "/.well-known/jwks.json"
+
+	// The certificate of the host is not trusted without a CA.
+	tlsConfig, err := oidcDiscoveryTLSConfig("", &api.Config{})
+	must.NoError(t, err)
+	_, err = fetchCertificateThumbprints(context.Background(), jwksURL, tlsConfig)
+	must.Error(t, err)
+
+	// The CA of the provider is used by default.
+	tlsConfig, err = oidcDiscoveryTLSConfig("", &api.Config{TLSConfig: &api.TLSConfig{CACertPEM: []byte(caPEM)}})
+	must.NoError(t, err)
+	_, err = fetchCertificateThumbprints(context.Background(), jwksURL, tlsConfig)
+	must.NoError(t, err)
+
+	// The CA of the data source takes precedence over the one of the provider.
+	tlsConfig, err = oidcDiscoveryTLSConfig(caPEM, &api.Config{TLSConfig: &api.TLSConfig{}})
+	must.NoError(t, err)
+	_, err = fetchCertificateThumbprints(context.Background(), jwksURL, tlsConfig)
+	must.NoError(t, err)
+
+	_, err = oidcDiscoveryTLSConfig("not a certificate", nil)
+	must.ErrorContains(t, err, "failed to parse CA certificate")
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -16,7 +17,7 @@ func dataSourcePlugins() *schema.Resource {
 	return &schema.Resource{
 		Read: pluginsDataSourceRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withListArguments(map[string]*schema.Schema{
 			"type": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -28,13 +29,27 @@ func dataSourcePlugins() *schema.Resource {
 				},
 			},
 
+			"capability": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only return plugins that provide the given capability ('controller' or 'node').",
+				ValidateFunc: validation.StringInSlice([]string{"controller", "node"}, false),
+			},
+
+			"healthy_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only return plugins where all expected controllers and nodes are healthy.",
+			},
+
 			"plugins": {
 				Description: "Registered plugins",
 				Computed:    true,
 				Type:        schema.TypeList,
 				Elem:        &schema.Schema{Type: schema.TypeMap},
 			},
-		},
+		}),
 	}
 }
 
@@ -46,8 +61,16 @@ func pluginsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return fmt.Errorf("error reading plugins from Nomad: %s", err)
 	}
+	list := getListArguments(d)
+	resp, err = filterList(list, resp)
+	if err != nil {
+		return err
+	}
+	resp = filterPlugins(resp, d.Get("capability").(string), d.Get("healthy_only").(bool))
+
 	plugins := make([]map[string]interface{}, 0, len(resp))
 	for _, p := range resp {
+		healthy := pluginHealthy(p.ControllersHealthy, p.ControllersExpected, p.NodesHealthy, p.NodesExpected)
 		plugin := map[string]interface{}{
 			"id":                   p.ID,
 			"provider":             p.Provider,
@@ -56,11 +79,46 @@ func pluginsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
 			"controllers_expected": strconv.Itoa(p.ControllersExpected),
 			"nodes_healthy":        strconv.Itoa(p.NodesHealthy),
 			"nodes_expected":       strconv.Itoa(p.NodesExpected),
+			"healthy":              strconv.FormatBool(healthy),
 		}
 		plugins = append(plugins, plugin)
 	}
+	plugins, err = list.sortAndLimit(plugins)
+	if err != nil {
+		return err
+	}
 	log.Printf("[DEBUG] Finished reading plugins from Nomad")
 	d.SetId(client.Address() + "/v1/plugins")
 
 	return d.Set("plugins", plugins)
 }
+
+// filterPlugins returns the plugins providing capability, if set, and only
+// the healthy ones when healthyOnly is set.
+func filterPlugins(plugins []*api.CSIPluginListStub, capability string, healthyOnly bool) []*api.CSIPluginListStub {
+	result := make([]*api.CSIPluginListStub, 0, len(plugins))
+	for _, p := range plugins {
+		switch capability {
+		case "controller":
+			if p.ControllersExpected == 0 {
+				continue
+			}
+		case "node":
+			if p.NodesExpected == 0 {
+				continue
+			}
+		}
+
+		if healthyOnly && !pluginHealthy(p.ControllersHealthy, p.ControllersExpected, p.NodesHealthy, p.NodesExpected) {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// pluginHealthy returns true when all of the expected controllers and nodes of
+// a plugin are reporting as healthy.
+func pluginHealthy(controllersHealthy, controllersExpected, nodesHealthy, nodesExpected int) bool {
+	return controllersExpected == controllersHealthy && nodesExpected == nodesHealthy
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+)
+
+func TestFilterPlugins(t *testing.T) {
+	plugins := []*api.CSIPluginListStub{
+		{ID: "ebs", ControllersExpected: 1, ControllersHealthy: 1, NodesExpected: 3, NodesHealthy: 3},
+		{ID: "nfs", NodesExpected: 3, NodesHealthy: 2},
+		{ID: "ceph", ControllersExpected: 2, ControllersHealthy: 1},
+	}
+	ids := func(plugins []*api.CSIPluginListStub) []string {
+		result := []string{}
+		for _, p := range plugins {
+			result = append(result, p.ID)
+		}
+		return result
+	}
+
+	cases := []struct {
+		name        string
+		capability  string
+		healthyOnly bool
+		want        []string
+	}{
+		{
+			name: "unfiltered",
+			want: []string{"ebs", "nfs", "ceph"},
+		},
+		{
+			name:       "controller",
+			capability: "controller",
+			want:       []string{"ebs", "ceph"},
+		},
+		{
+			name:       "node",
+			capability: "node",
+			want:       []string{"ebs", "nfs"},
+		},
+		{
+			name:        "healthy only",
+			healthyOnly: true,
+			want:        []string{"ebs"},
+		},
+		{
+			name:        "healthy controllers",
+			capability:  "controller",
+			healthyOnly: true,
+			want:        []string{"ebs"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.want, ids(filterPlugins(plugins, tc.capability, tc.healthyOnly)))
+		})
+	}
+}
+
+func TestPluginHealthy(t *testing.T) {
+	must.True(t, pluginHealthy(0, 0, 0, 0))
+	must.True(t, pluginHealthy(1, 1, 3, 3))
+	must.False(t, pluginHealthy(0, 1, 3, 3))
+	must.False(t, pluginHealthy(1, 1, 2, 3))
+}
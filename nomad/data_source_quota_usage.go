@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
+)
+
+func dataSourceQuotaUsage() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceQuotaUsageRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the quota specification.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"used": {
+				Description: "The resources used in each region of the quota specification.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Description: "The region of the usage.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"cpu": {
+							Description: "The CPU used, in MHz.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"cores": {
+							Description: "The number of CPU cores reserved.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"memory_mb": {
+							Description: "The memory used, in megabytes.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"memory_max_mb": {
+							Description: "The maximum memory used with memory oversubscription, in megabytes.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"secrets_mb": {
+							Description: "The memory used by task secrets directories, in megabytes.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"variables_mb": {
+							Description: "The total size of all variables, in megabytes.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"host_volumes_mb": {
+							Description: "The provisioned size of all dynamic host volumes, in megabytes.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"device": {
+							Description: "The number of devices of each type used.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Description: "The device name.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"count": {
+										Description: "The number of devices.",
+										Type:        schema.TypeInt,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceQuotaUsageRead(d *schema.ResourceData, meta any) error {
+	client := meta.(ProviderConfig).client
+
+	name := d.Get("name").(string)
+	log.Printf("[DEBUG] Reading quota usage %q", name)
+	usage, _, err := client.Quotas().Usage(name, nil)
+	if err != nil {
+		return fmt.Errorf("error reading quota usage %q: %w", name, err)
+	}
+	log.Printf("[DEBUG] Read quota usage %q", name)
+
+	sw := helper.NewStateWriter(d)
+	sw.Set("name", usage.Name)
+	sw.Set("used", flattenQuotaUsage(usage.Used))
+	if err := sw.Error(); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return nil
+}
+
+// flattenQuotaUsage returns the usage of each region, sorted by region since
+// Nomad indexes them by the hash of their limit.
+func flattenQuotaUsage(used map[string]*api.QuotaLimit) []any {
+	limits := make([]*api.QuotaLimit, 0, len(used))
+	for _, limit := range used {
+		if limit != nil {
+			limits = append(limits, limit)
+		}
+	}
+	sort.Slice(limits, func(i, j int) bool { return limits[i].Region < limits[j].Region })
+
+	result := make([]any, 0, len(limits))
+	for _, limit := range limits {
+		res := map[string]any{
+			"region": limit.Region,
+			"device": []any{},
+		}
+
+		if r := limit.RegionLimit; r != nil {
+			res["cpu"] = intValue(r.CPU)
+			res["cores"] = intValue(r.Cores)
+			res["memory_mb"] = intValue(r.MemoryMB)
+			res["memory_max_mb"] = intValue(r.MemoryMaxMB)
+			res["secrets_mb"] = intValue(r.SecretsMB)
+			if r.Storage != nil {
+				res["variables_mb"] = r.Storage.VariablesMB
+				res["host_volumes_mb"] = r.Storage.HostVolumesMB
+			}
+
+			devices := make([]any, 0, len(r.Devices))
+			for _, dev := range r.Devices {
+				if dev == nil {
+					continue
+				}
+				device := map[string]any{
+					"name":  dev.Name,
+					"count": 0,
+				}
+				if dev.Count != nil {
+					device["count"] = int(*dev.Count)
+				}
+				devices = append(devices, device)
+			}
+			res["device"] = devices
+		}
+
+		// Older versions of Nomad only report the variables usage here.
+		if _, ok := res["variables_mb"]; !ok {
+			res["variables_mb"] = intValue(limit.VariablesLimit)
+		}
+
+		result = append(result, res)
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper/pointer"
+)
+
+func TestDataSourceQuotaUsage(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-nomad-test")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckEnt(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceQuotaUsageConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nomad_quota_usage.test", "name", name),
+					resource.TestCheckResourceAttr("data.nomad_quota_usage.test", "used.#", "1"),
+					resource.TestCheckResourceAttr("data.nomad_quota_usage.test", "used.0.region", "global"),
+					resource.TestCheckResourceAttr("data.nomad_quota_usage.test", "used.0.cpu", "0"),
+					resource.TestCheckResourceAttr("data.nomad_quota_usage.test", "used.0.memory_mb", "0"),
+				),
+			},
+		},
+		CheckDestroy: testResourceQuotaSpecification_checkDestroy(name),
+	})
+}
+
+func testDataSourceQuotaUsageConfig(name string) string {
+	return fmt.Sprintf(`
+resource "nomad_quota_specification" "test" {
+  name = "%s"
+
+  limits {
+    region = "global"
+
+    region_limit {
+      cpu       = 2500
+      memory_mb = 1000
+    }
+  }
+}
+
+data "nomad_quota_usage" "test" {
+  name = nomad_quota_specification.test.name
+}
+`, name)
+}
+
+func TestFlattenQuotaUsage(t *testing.T) {
+	used := map[string]*api.QuotaLimit{
+		"hash-b": {
+			Region: "west",
+			RegionLimit: &api.QuotaResources{
+				CPU:      pointer.Of(500),
+				MemoryMB: pointer.Of(256),
+				Devices: []*api.RequestedDevice{
+					{Name: "nvidia/gpu", Count: pointer.Of(uint64(1))},
+				},
+				Storage: &api.QuotaStorageResources{VariablesMB: 2, HostVolumesMB: 100},
+			},
+		},
+		"hash-a": {
+			Region:         "east",
+			RegionLimit:    &api.QuotaResources{CPU: pointer.Of(100)},
+			VariablesLimit: pointer.Of(1),
+		},
+	}
+
+	expected := []any{
+		map[string]any{
+			"region":        "east",
+			"cpu":           100,
+			"cores":         0,
+			"memory_mb":     0,
+			"memory_max_mb": 0,
+			"secrets_mb":    0,
+			"variables_mb":  1,
+			"device":        []any{},
+		},
+		map[string]any{
+			"region":          "west",
+			"cpu":             500,
+			"cores":           0,
+			"memory_mb":       256,
+			"memory_max_mb":   0,
+			"secrets_mb":      0,
+			"variables_mb":    2,
+			"host_volumes_mb": 100,
+			"device": []any{
+				map[string]any{"name": "nvidia/gpu", "count": 1},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, flattenQuotaUsage(used)); diff != "" {
+		t.Fatalf("usage mismatch (-want +got):\n%s", diff)
+	}
+}
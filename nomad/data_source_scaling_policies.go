@@ -15,7 +15,7 @@ func dataSourceScalingPolicies() *schema.Resource {
 	return &schema.Resource{
 		Read: scalingPoliciesDataSourceRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withListArguments(map[string]*schema.Schema{
 			"job_id": {
 				Description: "Job ID to use to filter scaling policies.",
 				Type:        schema.TypeString,
@@ -55,7 +55,7 @@ func dataSourceScalingPolicies() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
@@ -75,18 +75,27 @@ func scalingPoliciesDataSourceRead(d *schema.ResourceData, meta interface{}) err
 	if err != nil {
 		return fmt.Errorf("failed to query scaling policies: %v", err)
 	}
+	list := getListArguments(d)
+	policies, err = filterList(list, policies)
+	if err != nil {
+		return err
+	}
+	result, err := list.sortAndLimit(flattenScalingPolicies(policies))
+	if err != nil {
+		return err
+	}
 
 	d.SetId(resource.UniqueId())
 
-	if err := d.Set("policies", flattenScalingPolicies(policies)); err != nil {
+	if err := d.Set("policies", result); err != nil {
 		return fmt.Errorf("failed to set policies: %v", err)
 	}
 
 	return nil
 }
 
-func flattenScalingPolicies(policies []*api.ScalingPolicyListStub) []interface{} {
-	out := make([]interface{}, 0, len(policies))
+func flattenScalingPolicies(policies []*api.ScalingPolicyListStub) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(policies))
 
 	for _, policy := range policies {
 		p := map[string]interface{}{
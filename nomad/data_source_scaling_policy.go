@@ -6,7 +6,6 @@ package nomad
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
@@ -62,7 +61,7 @@ func scalingPolicyDataSourceRead(d *schema.ResourceData, meta interface{}) error
 	id := d.Get("id").(string)
 	p, _, err := client.Scaling().GetPolicy(id, nil)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -32,6 +32,16 @@ func dataSourceSchedulerConfig() *schema.Resource {
 				Type:        schema.TypeMap,
 				Elem:        &schema.Schema{Type: schema.TypeBool},
 			},
+			"reject_job_registration": {
+				Description: "When true, new job registrations are rejected unless they use a management token.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"pause_eval_broker": {
+				Description: "When true, the evaluation broker is paused and no evaluations are processed.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -59,5 +69,7 @@ func dataSourceSchedulerConfigRead(d *schema.ResourceData, meta interface{}) err
 	sw.Set("memory_oversubscription_enabled", schedCfg.SchedulerConfig.MemoryOversubscriptionEnabled)
 	sw.Set("scheduler_algorithm", schedCfg.SchedulerConfig.SchedulerAlgorithm)
 	sw.Set("preemption_config", premptMap)
+	sw.Set("reject_job_registration", schedCfg.SchedulerConfig.RejectJobRegistration)
+	sw.Set("pause_eval_broker", schedCfg.SchedulerConfig.PauseEvalBroker)
 	return sw.Error()
 }
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSentinelPolicy() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSentinelPolicyRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the Sentinel policy.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "The description of the Sentinel policy.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"scope": {
+				Description: "The scope of the Sentinel policy.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"enforcement_level": {
+				Description: "The enforcement level of the Sentinel policy.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"policy": {
+				Description: "The Sentinel policy.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceSentinelPolicyRead(d *schema.ResourceData, meta any) error {
+	client := meta.(ProviderConfig).client
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Reading Sentinel policy %q", name)
+	policy, _, err := client.SentinelPolicies().Info(name, nil)
+	if err != nil {
+		if isNotFoundError(err) {
+			return fmt.Errorf("Sentinel policy %q not found", name)
+		}
+		return fmt.Errorf("error reading Sentinel policy %q: %s", name, err)
+	}
+	log.Printf("[DEBUG] Read Sentinel policy %q", name)
+
+	d.SetId(policy.Name)
+	d.Set("description", policy.Description)
+	d.Set("scope", policy.Scope)
+	d.Set("enforcement_level", policy.EnforcementLevel)
+	d.Set("policy", policy.Policy)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceSentinelPolicy_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-nomad-test")
+	dataSourceName := "data.nomad_sentinel_policy.test"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckEnt(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceSentinelPolicy_config(name, "A terraform acctest policy", `main = rule { true }`, "submit-job", "advisory") + `
+data "nomad_sentinel_policy" "test" {
+  name = nomad_sentinel_policy.test.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "name", name),
+					resource.TestCheckResourceAttr(dataSourceName, "description", "A terraform acctest policy"),
+					resource.TestCheckResourceAttr(dataSourceName, "scope", "submit-job"),
+					resource.TestCheckResourceAttr(dataSourceName, "enforcement_level", "advisory"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "policy", "nomad_sentinel_policy.test", "policy"),
+				),
+			},
+			{
+				Config: `
+data "nomad_sentinel_policy" "test" {
+  name = "tf-nomad-test-does-not-exist"
+}
+`,
+				ExpectError: regexp.MustCompile(`Sentinel policy "tf-nomad-test-does-not-exist" not found`),
+			},
+		},
+		CheckDestroy: testResourceSentinelPolicy_checkDestroy(name),
+	})
+}
@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTopology() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTopologyRead,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": {
+				Description: "Only summarize nodes in this datacenter.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"node_pool": {
+				Description: "Only summarize nodes in this node pool.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"groups": {
+				Description: "Capacity summary for each datacenter and node pool pair.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter": {
+							Description: "The datacenter of the nodes.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"node_pool": {
+							Description: "The node pool of the nodes.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"node_count": {
+							Description: "The total number of nodes.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"nodes_by_status": {
+							Description: "The number of nodes in each status.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+						"ineligible_nodes": {
+							Description: "The number of nodes that are ineligible for scheduling.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"draining_nodes": {
+							Description: "The number of nodes that are draining.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"cpu_total": {
+							Description: "The total schedulable CPU in MHz.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"cpu_allocated": {
+							Description: "The CPU in MHz allocated to running allocations.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"memory_total": {
+							Description: "The total schedulable memory in MB.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"memory_allocated": {
+							Description: "The memory in MB allocated to running allocations.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTopologyRead(d *schema.ResourceData, meta any) error {
+	client := meta.(ProviderConfig).client
+
+	datacenter := d.Get("datacenter").(string)
+	nodePool := d.Get("node_pool").(string)
+	id := strconv.Itoa(schema.HashString(datacenter + "/" + nodePool))
+
+	log.Printf("[DEBUG] Reading cluster topology")
+	nodes, _, err := client.Nodes().List(&api.QueryOptions{
+		Params: map[string]string{"resources": "true"},
+	})
+	if err != nil {
+		return fmt.Errorf("error reading nodes: %w", err)
+	}
+
+	allocs, _, err := client.Allocations().List(&api.QueryOptions{
+		Namespace: api.AllNamespacesNamespace,
+		Params:    map[string]string{"resources": "true"},
+	})
+	if err != nil {
+		return fmt.Errorf("error reading allocations: %w", err)
+	}
+	log.Printf("[DEBUG] Read cluster topology")
+
+	d.SetId(id)
+	return d.Set("groups", summarizeTopology(nodes, allocs, datacenter, nodePool))
+}
+
+// summarizeTopology aggregates node capacity and the resources used by
+// non-terminal allocations for each datacenter and node pool pair. Results are
+// sorted by datacenter and then by node pool to keep the output stable.
+func summarizeTopology(nodes []*api.NodeListStub, allocs []*api.AllocationListStub, datacenter, nodePool string) []map[string]any {
+	type group struct {
+		datacenter      string
+		nodePool        string
+		nodeCount       int
+		nodesByStatus   map[string]any
+		ineligible      int
+		draining        int
+		cpuTotal        int64
+		cpuAllocated    int64
+		memoryTotal     int64
+		memoryAllocated int64
+	}
+
+	groups := make(map[string]*group)
+	nodeGroup := make(map[string]*group)
+
+	for _, n := range nodes {
+		if datacenter != "" && n.Datacenter != datacenter {
+			continue
+		}
+		if nodePool != "" && n.NodePool != nodePool {
+			continue
+		}
+
+		key := n.Datacenter + "/" + n.NodePool
+		g, ok := groups[key]
+		if !ok {
+			g = &group{
+				datacenter:    n.Datacenter,
+				nodePool:      n.NodePool,
+				nodesByStatus: make(map[string]any),
+			}
+			groups[key] = g
+		}
+		nodeGroup[n.ID] = g
+
+		g.nodeCount++
+		count, _ := g.nodesByStatus[n.Status].(int)
+		g.nodesByStatus[n.Status] = count + 1
+		if n.SchedulingEligibility == api.NodeSchedulingIneligible {
+			g.ineligible++
+		}
+		if n.Drain {
+			g.draining++
+		}
+
+		// Only ready nodes contribute to the schedulable capacity.
+		if n.Status != api.NodeStatusReady || n.NodeResources == nil {
+			continue
+		}
+		g.cpuTotal += n.NodeResources.Cpu.CpuShares
+		g.memoryTotal += n.NodeResources.Memory.MemoryMB
+		if n.ReservedResources != nil {
+			g.cpuTotal -= int64(n.ReservedResources.Cpu.CpuShares)
+			g.memoryTotal -= int64(n.ReservedResources.Memory.MemoryMB)
+		}
+	}
+
+	for _, a := range allocs {
+		g, ok := nodeGroup[a.NodeID]
+		if !ok || a.AllocatedResources == nil {
+			continue
+		}
+		if a.DesiredStatus != api.AllocDesiredStatusRun {
+			continue
+		}
+		switch a.ClientStatus {
+		case api.AllocClientStatusComplete, api.AllocClientStatusFailed, api.AllocClientStatusLost:
+			continue
+		}
+
+		for _, t := range a.AllocatedResources.Tasks {
+			if t == nil {
+				continue
+			}
+			g.cpuAllocated += t.Cpu.CpuShares
+			g.memoryAllocated += t.Memory.MemoryMB
+		}
+	}
+
+	sorted := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].datacenter != sorted[j].datacenter {
+			return sorted[i].datacenter < sorted[j].datacenter
+		}
+		return sorted[i].nodePool < sorted[j].nodePool
+	})
+
+	result := make([]map[string]any, 0, len(sorted))
+	for _, g := range sorted {
+		result = append(result, map[string]any{
+			"datacenter":       g.datacenter,
+			"node_pool":        g.nodePool,
+			"node_count":       g.nodeCount,
+			"nodes_by_status":  g.nodesByStatus,
+			"ineligible_nodes": g.ineligible,
+			"draining_nodes":   g.draining,
+			"cpu_total":        int(g.cpuTotal),
+			"cpu_allocated":    int(g.cpuAllocated),
+			"memory_total":     int(g.memoryTotal),
+			"memory_allocated": int(g.memoryAllocated),
+		})
+	}
+
+	return result
+}
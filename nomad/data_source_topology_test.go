@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceTopology_basic(t *testing.T) {
+	dataSourceName := "data.nomad_topology.all"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceTopology_config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "groups.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "groups.0.datacenter", "dc1"),
+					resource.TestCheckResourceAttr(dataSourceName, "groups.0.node_pool", "default"),
+					resource.TestCheckResourceAttr(dataSourceName, "groups.0.node_count", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "groups.0.nodes_by_status.ready", "1"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "groups.0.cpu_total"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "groups.0.memory_total"),
+					resource.TestCheckResourceAttr("data.nomad_topology.missing", "groups.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+var testDataSourceTopology_config = `
+data "nomad_topology" "all" {}
+
+data "nomad_topology" "missing" {
+  datacenter = "not-a-datacenter"
+}
+`
+
+func TestSummarizeTopology(t *testing.T) {
+	nodeResources := &api.NodeResources{
+		Cpu:    api.NodeCpuResources{CpuShares: 4000},
+		Memory: api.NodeMemoryResources{MemoryMB: 8192},
+	}
+	reservedResources := &api.NodeReservedResources{
+		Cpu:    api.NodeReservedCpuResources{CpuShares: 500},
+		Memory: api.NodeReservedMemoryResources{MemoryMB: 1024},
+	}
+	allocResources := &api.AllocatedResources{
+		Tasks: map[string]*api.AllocatedTaskResources{
+			"web": {
+				Cpu:    api.AllocatedCpuResources{CpuShares: 100},
+				Memory: api.AllocatedMemoryResources{MemoryMB: 256},
+			},
+		},
+	}
+
+	nodes := []*api.NodeListStub{
+		{
+			ID:                    "node-1",
+			Datacenter:            "dc1",
+			NodePool:              "default",
+			Status:                api.NodeStatusReady,
+			SchedulingEligibility: api.NodeSchedulingEligible,
+			NodeResources:         nodeResources,
+			ReservedResources:     reservedResources,
+		},
+		{
+			ID:                    "node-2",
+			Datacenter:            "dc1",
+			NodePool:              "default",
+			Status:                api.NodeStatusDown,
+			SchedulingEligibility: api.NodeSchedulingIneligible,
+			NodeResources:         nodeResources,
+		},
+		{
+			ID:                    "node-3",
+			Datacenter:            "dc2",
+			NodePool:              "gpu",
+			Status:                api.NodeStatusReady,
+			SchedulingEligibility: api.NodeSchedulingIneligible,
+			Drain:                 true,
+			NodeResources:         nodeResources,
+		},
+	}
+
+	allocs := []*api.AllocationListStub{
+		{
+			NodeID:             "node-1",
+			DesiredStatus:      api.AllocDesiredStatusRun,
+			ClientStatus:       api.AllocClientStatusRunning,
+			AllocatedResources: allocResources,
+		},
+		{
+			NodeID:             "node-1",
+			DesiredStatus:      api.AllocDesiredStatusStop,
+			ClientStatus:       api.AllocClientStatusComplete,
+			AllocatedResources: allocResources,
+		},
+		{
+			NodeID:             "node-3",
+			DesiredStatus:      api.AllocDesiredStatusRun,
+			ClientStatus:       api.AllocClientStatusPending,
+			AllocatedResources: allocResources,
+		},
+	}
+
+	dc1 := map[string]any{
+		"datacenter":       "dc1",
+		"node_pool":        "default",
+		"node_count":       2,
+		"nodes_by_status":  map[string]any{"ready": 1, "down": 1},
+		"ineligible_nodes": 1,
+		"draining_nodes":   0,
+		"cpu_total":        3500,
+		"cpu_allocated":    100,
+		"memory_total":     7168,
+		"memory_allocated": 256,
+	}
+	dc2 := map[string]any{
+		"datacenter":       "dc2",
+		"node_pool":        "gpu",
+		"node_count":       1,
+		"nodes_by_status":  map[string]any{"ready": 1},
+		"ineligible_nodes": 1,
+		"draining_nodes":   1,
+		"cpu_total":        4000,
+		"cpu_allocated":    100,
+		"memory_total":     8192,
+		"memory_allocated": 256,
+	}
+
+	cases := []struct {
+		name       string
+		datacenter string
+		nodePool   string
+		want       []map[string]any
+	}{
+		{
+			name: "all nodes",
+			want: []map[string]any{dc1, dc2},
+		},
+		{
+			name:       "filter by datacenter",
+			datacenter: "dc2",
+			want:       []map[string]any{dc2},
+		},
+		{
+			name:     "filter by node pool",
+			nodePool: "default",
+			want:     []map[string]any{dc1},
+		},
+		{
+			name:       "no match",
+			datacenter: "dc1",
+			nodePool:   "gpu",
+			want:       []map[string]any{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := summarizeTopology(nodes, allocs, tc.datacenter, tc.nodePool)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("summarizeTopology() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
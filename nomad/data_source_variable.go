@@ -4,13 +4,16 @@
 package nomad
 
 import (
+	"fmt"
+	"log"
+
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceVariable() *schema.Resource {
 	return &schema.Resource{
-		Read: resourceVariableRead,
+		Read: dataSourceVariableRead,
 
 		Schema: map[string]*schema.Schema{
 			"path": {
@@ -24,12 +27,65 @@ func dataSourceVariable() *schema.Resource {
 				Optional:    true,
 				Default:     api.DefaultNamespace,
 			},
+			"allow_missing": {
+				Description: "If true, a variable that doesn't exist results in empty items instead of an error",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"nonsensitive": {
+				Description: "Whether the items are not secrets. When true, the items are also exported in nonsensitive_items",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"exists": {
+				Description: "Whether the variable exists",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
 			"items": {
 				Description: "A map of values from the stored variable",
 				Type:        schema.TypeMap,
 				Computed:    true,
 				Sensitive:   true,
 			},
+			"nonsensitive_items": {
+				Description: "A map of values from the stored variable when nonsensitive is true",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
+
+func dataSourceVariableRead(d *schema.ResourceData, meta any) error {
+	client := meta.(ProviderConfig).client
+
+	path := d.Get("path").(string)
+	ns := d.Get("namespace").(string)
+	variableID := path + "@" + ns
+
+	log.Printf("[DEBUG] Reading variable %s", variableID)
+	variable, _, err := client.Variables().Read(path, &api.QueryOptions{Namespace: ns})
+	if err != nil {
+		notFound := isNotFoundError(err)
+		if !notFound || !d.Get("allow_missing").(bool) {
+			return fmt.Errorf("error getting information about %s: %v", variableID, err)
+		}
+
+		log.Printf("[DEBUG] Variable %s not found", variableID)
+		d.SetId(variableID)
+		d.Set("exists", false)
+		d.Set("nonsensitive_items", map[string]string{})
+		return d.Set("items", map[string]string{})
+	}
+
+	d.SetId(variableID)
+	d.Set("exists", true)
+	if err := d.Set("nonsensitive_items", nonsensitiveVariableItems(d, variable.Items)); err != nil {
+		return err
+	}
+	return d.Set("items", variable.Items)
+}
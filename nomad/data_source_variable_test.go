@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceVariable_basic(t *testing.T) {
+	path := acctest.RandomWithPrefix("tf-nomad-test")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.4.0") },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceVariableConfig(path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nomad_variable.test", "exists", "true"),
+					resource.TestCheckResourceAttr("data.nomad_variable.test", "items.k1", "v1"),
+					resource.TestCheckResourceAttr("data.nomad_variable.test", "nonsensitive_items.%", "0"),
+					resource.TestCheckResourceAttr("data.nomad_variable.nonsensitive", "nonsensitive_items.k1", "v1"),
+					resource.TestCheckResourceAttr("data.nomad_variable.missing", "exists", "false"),
+					resource.TestCheckResourceAttr("data.nomad_variable.missing", "items.%", "0"),
+				),
+			},
+			{
+				Config:      testDataSourceVariableConfig_missing(path),
+				ExpectError: regexp.MustCompile("error getting information about"),
+			},
+		},
+		CheckDestroy: testResourceVariable_checkDestroy(api.DefaultNamespace, path),
+	})
+}
+
+func testDataSourceVariableConfig(path string) string {
+	return fmt.Sprintf(`
+resource "nomad_variable" "test" {
+  path  = "%[1]s"
+  items = {
+    k1 = "v1"
+  }
+}
+
+data "nomad_variable" "test" {
+  path = nomad_variable.test.path
+}
+
+data "nomad_variable" "nonsensitive" {
+  path         = nomad_variable.test.path
+  nonsensitive = true
+}
+
+data "nomad_variable" "missing" {
+  path          = "%[1]s-missing"
+  allow_missing = true
+}
+`, path)
+}
+
+func testDataSourceVariableConfig_missing(path string) string {
+	return fmt.Sprintf(`
+data "nomad_variable" "missing" {
+  path = "%s-missing"
+}
+`, path)
+}
@@ -17,16 +17,13 @@ func dataSourceVolumes() *schema.Resource {
 	return &schema.Resource{
 		Read: volumesDataSourceRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withListArguments(map[string]*schema.Schema{
 			"type": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Volume Type (currently only 'csi')",
-				Default:     "csi",
-				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: validation.StringInSlice([]string{"csi"}, false),
-				},
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Volume Type (one of 'csi' or 'host')",
+				Default:      "csi",
+				ValidateFunc: validation.StringInSlice([]string{"csi", "host"}, false),
 			},
 			"node_id": {
 				Type:        schema.TypeString,
@@ -40,6 +37,18 @@ func dataSourceVolumes() *schema.Resource {
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"access_mode": {
+				Type:        schema.TypeString,
+				Description: "Access mode filter (CSI volumes only)",
+				Optional:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(api.CSIVolumeAccessModeSingleNodeReader),
+					string(api.CSIVolumeAccessModeSingleNodeWriter),
+					string(api.CSIVolumeAccessModeMultiNodeReader),
+					string(api.CSIVolumeAccessModeMultiNodeSingleWriter),
+					string(api.CSIVolumeAccessModeMultiNodeMultiWriter),
+				}, false),
+			},
 			"namespace": {
 				Description: "Volume namespace filter",
 				Type:        schema.TypeString,
@@ -53,7 +62,7 @@ func dataSourceVolumes() *schema.Resource {
 				Type:        schema.TypeList,
 				Elem:        &schema.Schema{Type: schema.TypeMap},
 			},
-		},
+		}),
 	}
 }
 
@@ -64,28 +73,78 @@ func volumesDataSourceRead(d *schema.ResourceData, meta interface{}) error {
 	if ns == "" {
 		ns = "default"
 	}
+	list := getListArguments(d)
 	q := &api.QueryOptions{
 		Namespace: ns,
 		Params:    make(map[string]string, 0),
+		Filter:    list.serverFilter(""),
 	}
-	if v, ok := d.GetOk("node_id"); ok && v != "" {
-		q.Params["node_id"] = v.(string)
-	}
-	if v, ok := d.GetOk("plugin_id"); ok && v != "" {
-		q.Params["plugin_id"] = v.(string)
-	}
+	nodeID := d.Get("node_id").(string)
+	pluginID := d.Get("plugin_id").(string)
+	accessMode := d.Get("access_mode").(string)
+
+	var volumes []map[string]interface{}
 
 	log.Printf("[DEBUG] Reading list of volumes from Nomad")
-	resp, _, err := client.CSIVolumes().List(q)
+	switch volType := d.Get("type").(string); volType {
+	case "host":
+		if _, ok := d.GetOk("access_mode"); ok {
+			return fmt.Errorf("access_mode can only be used with csi volumes")
+		}
+
+		// The plugin ID is filtered by the provider, so the API can only
+		// return the first results when it's not set.
+		if pluginID == "" {
+			q.PerPage = list.perPage()
+		}
+		resp, _, err := client.HostVolumes().List(&api.HostVolumeListRequest{NodeID: nodeID}, q)
+		if err != nil {
+			return fmt.Errorf("error reading volumes from Nomad: %s", err)
+		}
+		volumes = flattenHostVolumeStubs(resp, pluginID)
+
+	default:
+		if nodeID != "" {
+			q.Params["node_id"] = nodeID
+		}
+		if pluginID != "" {
+			q.Params["plugin_id"] = pluginID
+		}
+		if accessMode == "" {
+			q.PerPage = list.perPage()
+		}
+
+		resp, _, err := client.CSIVolumes().List(q)
+		if err != nil {
+			return fmt.Errorf("error reading volumes from Nomad: %s", err)
+		}
+		volumes = flattenCSIVolumeStubs(resp, accessMode)
+	}
+	volumes, err := list.sortAndLimit(volumes)
 	if err != nil {
-		return fmt.Errorf("error reading volumes from Nomad: %s", err)
+		return err
 	}
-	volumes := make([]map[string]interface{}, 0, len(resp))
-	for _, v := range resp {
+	log.Printf("[DEBUG] Finished reading volumes from Nomad")
+	d.SetId(client.Address() + "/v1/volumes")
+
+	return d.Set("volumes", volumes)
+}
+
+// flattenCSIVolumeStubs converts CSI volume list stubs into volume maps,
+// skipping volumes that don't match accessMode when it is set.
+func flattenCSIVolumeStubs(stubs []*api.CSIVolumeListStub, accessMode string) []map[string]interface{} {
+	volumes := make([]map[string]interface{}, 0, len(stubs))
+	for _, v := range stubs {
+		if accessMode != "" && string(v.AccessMode) != accessMode {
+			continue
+		}
+
+		healthy := pluginHealthy(v.ControllersHealthy, v.ControllersExpected, v.NodesHealthy, v.NodesExpected)
 		volume := map[string]interface{}{
 			"id":                   v.ID,
 			"namespace":            v.Namespace,
 			"name":                 v.Name,
+			"type":                 "csi",
 			"external_id":          v.ExternalID,
 			"access_mode":          v.AccessMode,
 			"attachement_mode":     v.AttachmentMode,
@@ -97,11 +156,39 @@ func volumesDataSourceRead(d *schema.ResourceData, meta interface{}) error {
 			"controllers_expected": strconv.Itoa(v.ControllersExpected),
 			"nodes_healthy":        strconv.Itoa(v.NodesHealthy),
 			"nodes_expected":       strconv.Itoa(v.NodesExpected),
+			"healthy":              strconv.FormatBool(healthy),
+			"current_readers":      strconv.Itoa(v.CurrentReaders),
+			"current_writers":      strconv.Itoa(v.CurrentWriters),
 		}
 		volumes = append(volumes, volume)
 	}
-	log.Printf("[DEBUG] Finished reading volumes from Nomad")
-	d.SetId(client.Address() + "/v1/volumes")
+	return volumes
+}
 
-	return d.Set("volumes", volumes)
+// flattenHostVolumeStubs converts dynamic host volume list stubs into volume
+// maps, skipping volumes that don't match pluginID when it is set. The host
+// volumes API doesn't support filtering by plugin, so it's done here instead.
+func flattenHostVolumeStubs(stubs []*api.HostVolumeStub, pluginID string) []map[string]interface{} {
+	volumes := make([]map[string]interface{}, 0, len(stubs))
+	for _, v := range stubs {
+		if pluginID != "" && v.PluginID != pluginID {
+			continue
+		}
+
+		volume := map[string]interface{}{
+			"id":             v.ID,
+			"namespace":      v.Namespace,
+			"name":           v.Name,
+			"type":           "host",
+			"plugin_id":      v.PluginID,
+			"node_id":        v.NodeID,
+			"node_pool":      v.NodePool,
+			"capacity_bytes": strconv.FormatInt(v.CapacityBytes, 10),
+			"state":          string(v.State),
+			"schedulable":    strconv.FormatBool(v.State == api.HostVolumeStateReady),
+			"healthy":        strconv.FormatBool(v.State == api.HostVolumeStateReady),
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes
 }
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/nomad/api"
+)
+
+func TestFlattenCSIVolumeStubs(t *testing.T) {
+	stubs := []*api.CSIVolumeListStub{
+		{
+			ID:                  "data",
+			Namespace:           "default",
+			AccessMode:          api.CSIVolumeAccessModeSingleNodeWriter,
+			PluginID:            "ebs",
+			ControllersHealthy:  1,
+			ControllersExpected: 1,
+			NodesHealthy:        2,
+			NodesExpected:       3,
+			CurrentWriters:      1,
+		},
+		{
+			ID:         "shared",
+			Namespace:  "default",
+			AccessMode: api.CSIVolumeAccessModeMultiNodeMultiWriter,
+			PluginID:   "efs",
+		},
+	}
+
+	var got []string
+	for _, v := range flattenCSIVolumeStubs(stubs, "") {
+		got = append(got, v["id"].(string)+"/"+v["healthy"].(string)+"/"+v["current_writers"].(string))
+	}
+	if diff := cmp.Diff([]string{"data/false/1", "shared/true/0"}, got); diff != "" {
+		t.Errorf("flattenCSIVolumeStubs() mismatch (-want +got):\n%s", diff)
+	}
+
+	filtered := flattenCSIVolumeStubs(stubs, string(api.CSIVolumeAccessModeMultiNodeMultiWriter))
+	if len(filtered) != 1 || filtered[0]["id"] != "shared" {
+		t.Errorf("expected only the shared volume, got %v", filtered)
+	}
+}
+
+func TestFlattenHostVolumeStubs(t *testing.T) {
+	stubs := []*api.HostVolumeStub{
+		{ID: "a", PluginID: "mkdir", NodeID: "node-1", State: api.HostVolumeStateReady},
+		{ID: "b", PluginID: "lvm", NodeID: "node-1", State: api.HostVolumeStatePending},
+	}
+
+	all := flattenHostVolumeStubs(stubs, "")
+	if len(all) != 2 {
+		t.Fatalf("expected 2 volumes, got %d", len(all))
+	}
+	if all[1]["healthy"] != "false" || all[1]["state"] != "pending" {
+		t.Errorf("unexpected volume %v", all[1])
+	}
+
+	filtered := flattenHostVolumeStubs(stubs, "mkdir")
+	if len(filtered) != 1 || filtered[0]["id"] != "a" || filtered[0]["healthy"] != "true" {
+		t.Errorf("expected only the mkdir volume, got %v", filtered)
+	}
+}
@@ -6,7 +6,6 @@ package nomad
 import (
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -47,6 +46,7 @@ func dataSourceJob() *schema.Resource {
 			"region": {
 				Description: "Job Region",
 				Type:        schema.TypeString,
+				Optional:    true,
 				Computed:    true,
 			},
 			"datacenters": {
@@ -100,7 +100,7 @@ func dataSourceJob() *schema.Resource {
 				Computed:    true,
 				Type:        schema.TypeString,
 			},
-			"task_groups": taskGroupSchema(),
+			"task_groups": dataSourceJobTaskGroupSchema(),
 			"stable": {
 				Description: "Job Stable",
 				Type:        schema.TypeBool,
@@ -181,7 +181,7 @@ func dataSourceJobRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			return err
 		}
 
@@ -204,7 +204,7 @@ func dataSourceJobRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("stop", job.Stop)
 	d.Set("priority", job.Priority)
 	d.Set("parent_id", job.ParentID)
-	d.Set("task_groups", jobTaskGroupsRaw(job.TaskGroups))
+	d.Set("task_groups", dataSourceJobTaskGroupsRaw(job.TaskGroups))
 	d.Set("stable", job.Stable)
 	d.Set("all_at_once", job.AllAtOnce)
 	d.Set("constraints", job.Constraints)
@@ -230,3 +230,222 @@ func dataSourceJobRead(d *schema.ResourceData, meta interface{}) error {
 
 	return nil
 }
+
+// dataSourceJobTaskGroupSchema extends the task group schema shared with the
+// nomad_job resource with attributes that are only exposed by the data source.
+func dataSourceJobTaskGroupSchema() *schema.Schema {
+	s := taskGroupSchema()
+
+	tg := s.Elem.(*schema.Resource).Schema
+	tg["network"] = &schema.Schema{
+		Computed: true,
+		Type:     schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"mode": {
+					Computed: true,
+					Type:     schema.TypeString,
+				},
+				"port": {
+					Computed: true,
+					Type:     schema.TypeList,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"label": {
+								Computed: true,
+								Type:     schema.TypeString,
+							},
+							"static": {
+								Computed: true,
+								Type:     schema.TypeInt,
+							},
+							"to": {
+								Computed: true,
+								Type:     schema.TypeInt,
+							},
+							"host_network": {
+								Computed: true,
+								Type:     schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	tg["service"] = jobServiceSchema()
+
+	task := tg["task"].Elem.(*schema.Resource).Schema
+	task["user"] = &schema.Schema{
+		Computed: true,
+		Type:     schema.TypeString,
+	}
+	task["env"] = &schema.Schema{
+		Computed: true,
+		Type:     schema.TypeMap,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	task["resources"] = &schema.Schema{
+		Computed: true,
+		Type:     schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cpu": {
+					Computed: true,
+					Type:     schema.TypeInt,
+				},
+				"cores": {
+					Computed: true,
+					Type:     schema.TypeInt,
+				},
+				"memory": {
+					Computed: true,
+					Type:     schema.TypeInt,
+				},
+				"memory_max": {
+					Computed: true,
+					Type:     schema.TypeInt,
+				},
+			},
+		},
+	}
+	task["lifecycle"] = &schema.Schema{
+		Computed: true,
+		Type:     schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"hook": {
+					Computed: true,
+					Type:     schema.TypeString,
+				},
+				"sidecar": {
+					Computed: true,
+					Type:     schema.TypeBool,
+				},
+			},
+		},
+	}
+	task["service"] = jobServiceSchema()
+
+	return s
+}
+
+func jobServiceSchema() *schema.Schema {
+	return &schema.Schema{
+		Computed: true,
+		Type:     schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Computed: true,
+					Type:     schema.TypeString,
+				},
+				"provider": {
+					Computed: true,
+					Type:     schema.TypeString,
+				},
+				"port": {
+					Computed: true,
+					Type:     schema.TypeString,
+				},
+				"address_mode": {
+					Computed: true,
+					Type:     schema.TypeString,
+				},
+				"task": {
+					Computed: true,
+					Type:     schema.TypeString,
+				},
+				"tags": {
+					Computed: true,
+					Type:     schema.TypeList,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"meta": {
+					Computed: true,
+					Type:     schema.TypeMap,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceJobTaskGroupsRaw flattens the task groups of a job into the format
+// defined by dataSourceJobTaskGroupSchema.
+func dataSourceJobTaskGroupsRaw(tgs []*api.TaskGroup) []interface{} {
+	ret := jobTaskGroupsRaw(tgs)
+
+	for i, tg := range tgs {
+		tgM := ret[i].(map[string]interface{})
+
+		networksI := make([]interface{}, 0, len(tg.Networks))
+		for _, n := range tg.Networks {
+			portsI := make([]interface{}, 0, len(n.ReservedPorts)+len(n.DynamicPorts))
+			for _, p := range append(n.ReservedPorts, n.DynamicPorts...) {
+				portsI = append(portsI, map[string]interface{}{
+					"label":        p.Label,
+					"static":       p.Value,
+					"to":           p.To,
+					"host_network": p.HostNetwork,
+				})
+			}
+			networksI = append(networksI, map[string]interface{}{
+				"mode": n.Mode,
+				"port": portsI,
+			})
+		}
+		tgM["network"] = networksI
+		tgM["service"] = jobServicesRaw(tg.Services)
+
+		tasksI := tgM["task"].([]interface{})
+		for j, task := range tg.Tasks {
+			taskM := tasksI[j].(map[string]interface{})
+
+			taskM["user"] = task.User
+			taskM["env"] = task.Env
+			taskM["service"] = jobServicesRaw(task.Services)
+
+			if r := task.Resources; r != nil {
+				resources := map[string]interface{}{}
+				if r.CPU != nil {
+					resources["cpu"] = *r.CPU
+				}
+				if r.Cores != nil {
+					resources["cores"] = *r.Cores
+				}
+				if r.MemoryMB != nil {
+					resources["memory"] = *r.MemoryMB
+				}
+				if r.MemoryMaxMB != nil {
+					resources["memory_max"] = *r.MemoryMaxMB
+				}
+				taskM["resources"] = []interface{}{resources}
+			}
+			if l := task.Lifecycle; l != nil {
+				taskM["lifecycle"] = []interface{}{map[string]interface{}{
+					"hook":    l.Hook,
+					"sidecar": l.Sidecar,
+				}}
+			}
+		}
+	}
+
+	return ret
+}
+
+func jobServicesRaw(services []*api.Service) []interface{} {
+	ret := make([]interface{}, 0, len(services))
+	for _, s := range services {
+		ret = append(ret, map[string]interface{}{
+			"name":         s.Name,
+			"provider":     s.Provider,
+			"port":         s.PortLabel,
+			"address_mode": s.AddressMode,
+			"task":         s.TaskName,
+			"tags":         s.Tags,
+			"meta":         s.Meta,
+		})
+	}
+	return ret
+}
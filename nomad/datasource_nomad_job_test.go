@@ -91,6 +91,47 @@ func TestAccDataSourceNomadJob_Namespaced(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceNomadJob_TaskGroups(t *testing.T) {
+	job := "testjobds_taskgroups"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testProviders,
+		CheckDestroy: testResourceJob_forceDestroyWithPurge(job, "default"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccJobDataSourceConfigTaskGroups(job),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceNomadJobExists("data.nomad_job.test-job", "default"),
+					resource.TestCheckResourceAttr(
+						"data.nomad_job.test-job", "task_groups.0.name", "foo"),
+					resource.TestCheckResourceAttr(
+						"data.nomad_job.test-job", "task_groups.0.meta.team", "storage"),
+					resource.TestCheckResourceAttr(
+						"data.nomad_job.test-job", "task_groups.0.network.0.port.0.label", "http"),
+					resource.TestCheckResourceAttr(
+						"data.nomad_job.test-job", "task_groups.0.network.0.port.0.to", "8080"),
+					resource.TestCheckResourceAttr(
+						"data.nomad_job.test-job", "task_groups.0.service.0.name", "foo-http"),
+					resource.TestCheckResourceAttr(
+						"data.nomad_job.test-job", "task_groups.0.service.0.provider", "nomad"),
+					resource.TestCheckResourceAttr(
+						"data.nomad_job.test-job", "task_groups.0.service.0.port", "http"),
+					resource.TestCheckResourceAttr(
+						"data.nomad_job.test-job", "task_groups.0.service.0.tags.0", "web"),
+					resource.TestCheckResourceAttr(
+						"data.nomad_job.test-job", "task_groups.0.task.0.name", "foo"),
+					resource.TestCheckResourceAttr(
+						"data.nomad_job.test-job", "task_groups.0.task.0.env.GREETING", "hello"),
+					resource.TestCheckResourceAttr(
+						"data.nomad_job.test-job", "task_groups.0.task.0.resources.0.cpu", "100"),
+					resource.TestCheckResourceAttr(
+						"data.nomad_job.test-job", "task_groups.0.task.0.resources.0.memory", "10"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDataSourceNomadJobExists(n, namespace string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -250,3 +291,61 @@ data "nomad_job" "test-job-periodic" {
 }
 `
 }
+
+func testAccJobDataSourceConfigTaskGroups(job string) string {
+	return `
+resource "nomad_job" "job-instance" {
+	jobspec = <<EOT
+		job "` + job + `" {
+			datacenters = ["dc1"]
+			type = "service"
+			group "foo" {
+				meta {
+					team = "storage"
+				}
+
+				network {
+					port "http" {
+						to = 8080
+					}
+				}
+
+				service {
+					name     = "foo-http"
+					provider = "nomad"
+					port     = "http"
+					tags     = ["web"]
+				}
+
+				task "foo" {
+					driver = "raw_exec"
+					config {
+						command = "/bin/sleep"
+						args = ["3600"]
+					}
+
+					env {
+						GREETING = "hello"
+					}
+
+					resources {
+						cpu = 100
+						memory = 10
+					}
+
+					logs {
+						max_files = 3
+						max_file_size = 10
+					}
+				}
+			}
+		}
+	EOT
+	detach = true
+}
+
+data "nomad_job" "test-job" {
+  job_id    = "${nomad_job.job-instance.id}"
+}
+`
+}
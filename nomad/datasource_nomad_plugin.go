@@ -4,18 +4,19 @@
 package nomad
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourcePlugin() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourcePluginRead,
+		ReadContext: dataSourcePluginRead,
 		Schema: map[string]*schema.Schema{
 			"plugin_id": {
 				Description: "Plugin ID",
@@ -34,6 +35,7 @@ func dataSourcePlugin() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
+			"wait_for": dataSourceWaitForSchema(),
 
 			// computed attributes
 			"plugin_provider": {
@@ -64,6 +66,32 @@ func dataSourcePlugin() *schema.Resource {
 				Computed: true,
 				Type:     schema.TypeInt,
 			},
+			"healthy": {
+				Description: "Whether all expected controllers and nodes are healthy",
+				Computed:    true,
+				Type:        schema.TypeBool,
+			},
+			"controllers": {
+				Description: "Available controllers for this plugin",
+				Computed:    true,
+				Type:        schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"healthy": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"healthy_description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 			"nodes": {
 				Description: "Available nodes for this plugin",
 				Computed:    true,
@@ -89,10 +117,29 @@ func dataSourcePlugin() *schema.Resource {
 	}
 }
 
-func dataSourcePluginRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourcePluginRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConfig := meta.(ProviderConfig)
 	client := providerConfig.client
 
+	// The plugin is read again below once it matches the condition, like
+	// when waiting for it to be registered or healthy.
+	if _, ok := d.GetOk("wait_for"); ok {
+		id := d.Get("plugin_id").(string)
+		_, err := dataSourceWaitFor(ctx, d, "CSI plugins", func(ctx context.Context) ([]*api.CSIPlugin, error) {
+			plugin, _, err := client.CSIPlugins().Info(id, (&api.QueryOptions{}).WithContext(ctx))
+			switch {
+			case isNotFoundError(err):
+				return nil, nil
+			case err != nil:
+				return nil, err
+			}
+			return []*api.CSIPlugin{plugin}, nil
+		})
+		if err != nil {
+			return diag.Errorf("error waiting for plugin %q: %s", id, err)
+		}
+	}
+
 	wait := d.Get("wait_for_registration").(bool)
 	waitForHealthy := d.Get("wait_for_healthy").(bool)
 	if wait || waitForHealthy {
@@ -102,7 +149,7 @@ func dataSourcePluginRead(d *schema.ResourceData, meta interface{}) error {
 	} else {
 		err := getPluginInfo(client, d)
 		if err != nil {
-			return err.Err
+			return diag.FromErr(err.Err)
 		}
 	}
 
@@ -119,14 +166,14 @@ func getPluginInfo(client *api.Client, d *schema.ResourceData) *resource.RetryEr
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
 		log.Printf("[ERROR] error checking for plugin: %#v", err)
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			return resource.RetryableError(fmt.Errorf("plugin %q not found", id))
 		}
 		return resource.NonRetryableError(fmt.Errorf("error checking for plugin: %#v", err))
 	}
 
-	healthy := plugin.ControllersExpected == plugin.ControllersHealthy &&
-		plugin.NodesExpected == plugin.NodesHealthy
+	healthy := pluginHealthy(plugin.ControllersHealthy, plugin.ControllersExpected,
+		plugin.NodesHealthy, plugin.NodesExpected)
 
 	if waitForHealthy && !healthy {
 		log.Printf("[DEBUG] plugin %s not yet healthy: %d/%d controllers healthy  %d/%d nodes healthy",
@@ -146,6 +193,16 @@ func getPluginInfo(client *api.Client, d *schema.ResourceData) *resource.RetryEr
 	d.Set("controllers_healthy", plugin.ControllersHealthy)
 	d.Set("nodes_expected", plugin.NodesExpected)
 	d.Set("nodes_healthy", plugin.NodesHealthy)
+	d.Set("healthy", healthy)
+	controllers := make([]map[string]interface{}, 0, len(plugin.Controllers))
+	for name, info := range plugin.Controllers {
+		controllers = append(controllers, map[string]interface{}{
+			"name":                name,
+			"healthy":             info.Healthy,
+			"healthy_description": info.HealthDescription,
+		})
+	}
+	d.Set("controllers", controllers)
 	nodes := make([]map[string]interface{}, 0, len(plugin.Nodes))
 	for name, info := range plugin.Nodes {
 		nodes = append(nodes, map[string]interface{}{
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The arguments below control what happens when a resource managing an object
+// of the cluster is destroyed, so all the resources that support them behave
+// the same way:
+//
+//   - deregister_on_destroy: if false, the object is only removed from the
+//     Terraform state and left in the cluster.
+//   - purge_on_destroy: if true, the object is removed from the cluster
+//     completely instead of being left for Nomad to garbage collect, or, for
+//     the volumes created by Terraform, deleted from the storage provider
+//     instead of only being deregistered.
+//   - force: if true, the object is deregistered even if it is still in use.
+//   - retain_on_destroy: if true, the object is only removed from the
+//     Terraform state, whatever the other arguments are set to, so it can be
+//     handed over to another workspace or left unmanaged.
+//   - force_destroy and force_destroy_confirmation: for nomad_namespace, if
+//     force_destroy is true and the confirmation matches the name of the
+//     namespace, the objects of the namespace are deleted with it.
+//
+// They are only used on destroy, so changing them doesn't update the object.
+var destroyArguments = []string{"deregister_on_destroy", "purge_on_destroy", "force", "retain_on_destroy", "force_destroy", "force_destroy_confirmation"}
+
+func deregisterOnDestroySchema(what string) *schema.Schema {
+	return &schema.Schema{
+		Description: "If true, the " + what + " will be deregistered on destroy. If false, it is only removed from the Terraform state.",
+		Optional:    true,
+		Default:     true,
+		Type:        schema.TypeBool,
+	}
+}
+
+func purgeOnDestroySchema(description string, def bool) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Optional:    true,
+		Default:     def,
+		Type:        schema.TypeBool,
+	}
+}
+
+func forceDestroySchema(what string) *schema.Schema {
+	return &schema.Schema{
+		Description: "If true, the " + what + " will be deregistered on destroy even if it is still in use.",
+		Optional:    true,
+		Default:     true,
+		Type:        schema.TypeBool,
+	}
+}
+
+func retainOnDestroySchema(what string) *schema.Schema {
+	return &schema.Schema{
+		Description: "If true, the " + what + " is left in the cluster on destroy and only removed from the Terraform state.",
+		Optional:    true,
+		Default:     false,
+		Type:        schema.TypeBool,
+	}
+}
+
+// destroyFlag returns the value of one of the arguments above, or def when it
+// is not in the state, as for resources created before the argument was
+// added, so they are destroyed as they were before.
+func destroyFlag(d *schema.ResourceData, key string, def bool) bool {
+	state := d.GetRawState()
+	if state.IsNull() || !state.IsKnown() || !state.Type().HasAttribute(key) || state.GetAttr(key).IsNull() {
+		return def
+	}
+	return d.Get(key).(bool)
+}
+
+// skipDeregister returns true, and logs why, when the object managed by d must
+// be left in the cluster on destroy because retain_on_destroy is true or
+// deregister_on_destroy is false.
+func skipDeregister(d *schema.ResourceData, what string) bool {
+	if destroyFlag(d, "retain_on_destroy", false) {
+		log.Printf("[WARN] %s %q will not be deleted since 'retain_on_destroy' is true", what, d.Id())
+		return true
+	}
+	if destroyFlag(d, "deregister_on_destroy", true) {
+		return false
+	}
+	log.Printf("[WARN] %s %q will not deregister since 'deregister_on_destroy' is false", what, d.Id())
+	return true
+}
+
+// withDestroyArguments wraps the Update functions of the resources so the
+// object is not written again when only the destroy arguments change, the new
+// values are only stored in the state. nomad_job is left unchanged since its
+// CustomizeDiff may plan a new modify_index that only its Update can set.
+func withDestroyArguments(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for typeName, r := range resources {
+		if typeName == "nomad_job" || !hasDestroyArguments(r) {
+			continue
+		}
+		if r.Update != nil {
+			update := r.Update
+			r.Update = func(d *schema.ResourceData, meta any) error {
+				if !d.HasChangesExcept(destroyArguments...) {
+					return nil
+				}
+				return update(d, meta)
+			}
+		}
+		if r.UpdateContext != nil {
+			update := r.UpdateContext
+			r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+				if !d.HasChangesExcept(destroyArguments...) {
+					return nil
+				}
+				return update(ctx, d, meta)
+			}
+		}
+	}
+	return resources
+}
+
+func hasDestroyArguments(r *schema.Resource) bool {
+	for _, key := range destroyArguments {
+		if _, ok := r.Schema[key]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/shoenig/test/must"
+)
+
+func TestDestroyFlag(t *testing.T) {
+	r := resourceCSIVolume()
+
+	// Resources created before the arguments were added use the defaults.
+	d := r.Data(&terraform.InstanceState{ID: "vol"})
+	must.True(t, destroyFlag(d, "deregister_on_destroy", true))
+	must.True(t, destroyFlag(d, "purge_on_destroy", true))
+	must.True(t, destroyFlag(d, "force", true))
+
+	d = r.Data(&terraform.InstanceState{
+		ID: "vol",
+		Attributes: map[string]string{
+			"deregister_on_destroy": "true",
+			"purge_on_destroy":      "false",
+		},
+		RawState: cty.ObjectVal(map[string]cty.Value{
+			"deregister_on_destroy": cty.True,
+			"purge_on_destroy":      cty.False,
+			"force":                 cty.NullVal(cty.Bool),
+		}),
+	})
+	must.True(t, destroyFlag(d, "deregister_on_destroy", true))
+	must.False(t, destroyFlag(d, "purge_on_destroy", true))
+	must.True(t, destroyFlag(d, "force", true))
+}
+
+func TestSkipDeregister(t *testing.T) {
+	// The resources must not send any request when deregister_on_destroy is
+	// false, the provider has no client to fail otherwise.
+	for typeName, r := range map[string]func() *schema.Resource{
+		"nomad_namespace":           resourceNamespace,
+		"nomad_csi_volume":          resourceCSIVolume,
+		"nomad_dynamic_host_volume": resourceDynamicHostVolume,
+	} {
+		t.Run(typeName, func(t *testing.T) {
+			res := r()
+			d := res.Data(&terraform.InstanceState{
+				ID:         "example",
+				Attributes: map[string]string{"deregister_on_destroy": "false"},
+				RawState: cty.ObjectVal(map[string]cty.Value{
+					"deregister_on_destroy": cty.False,
+				}),
+			})
+			must.True(t, skipDeregister(d, typeName))
+
+			diags := res.DeleteContext(context.Background(), d, ProviderConfig{})
+			must.SliceEmpty(t, diags)
+		})
+	}
+
+	// retain_on_destroy takes precedence over deregister_on_destroy.
+	for typeName, r := range map[string]func() *schema.Resource{
+		"nomad_namespace":           resourceNamespace,
+		"nomad_variable":            resourceVariable,
+		"nomad_csi_volume":          resourceCSIVolume,
+		"nomad_dynamic_host_volume": resourceDynamicHostVolume,
+	} {
+		t.Run(typeName+"/retain", func(t *testing.T) {
+			res := r()
+			attrs := map[string]string{"retain_on_destroy": "true"}
+			raw := map[string]cty.Value{"retain_on_destroy": cty.True}
+			if _, ok := res.Schema["deregister_on_destroy"]; ok {
+				attrs["deregister_on_destroy"] = "true"
+				raw["deregister_on_destroy"] = cty.True
+			}
+			d := res.Data(&terraform.InstanceState{
+				ID:         "example",
+				Attributes: attrs,
+				RawState:   cty.ObjectVal(raw),
+			})
+			must.True(t, skipDeregister(d, typeName))
+
+			var diags diag.Diagnostics
+			if res.DeleteContext != nil {
+				diags = res.DeleteContext(context.Background(), d, ProviderConfig{})
+			} else {
+				diags = diag.FromErr(res.Delete(d, ProviderConfig{}))
+			}
+			must.SliceEmpty(t, diags)
+		})
+	}
+}
+
+func TestWithDestroyArguments(t *testing.T) {
+	updated := false
+	resources := withDestroyArguments(map[string]*schema.Resource{
+		"nomad_namespace": {
+			Schema: map[string]*schema.Schema{
+				"description":           {Type: schema.TypeString, Optional: true},
+				"deregister_on_destroy": deregisterOnDestroySchema("namespace"),
+			},
+			Update: func(*schema.ResourceData, any) error {
+				updated = true
+				return nil
+			},
+		},
+	})
+	r := resources["nomad_namespace"]
+	state := &terraform.InstanceState{
+		ID: "example",
+		Attributes: map[string]string{
+			"id":                    "example",
+			"description":           "before",
+			"deregister_on_destroy": "true",
+		},
+	}
+
+	// The namespace is not written again when only deregister_on_destroy
+	// changes.
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]any{
+		"description":           "before",
+		"deregister_on_destroy": false,
+	}), nil)
+	must.NoError(t, err)
+	_, diags := r.Apply(context.Background(), state, diff, nil)
+	must.SliceEmpty(t, diags)
+	must.False(t, updated)
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]any{
+		"description":           "after",
+		"deregister_on_destroy": false,
+	}), nil)
+	must.NoError(t, err)
+	_, diags = r.Apply(context.Background(), state, diff, nil)
+	must.SliceEmpty(t, diags)
+	must.True(t, updated)
+}
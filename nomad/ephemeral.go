@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ephemeralResource is a resource that is opened for the duration of a
+// Terraform operation and is never persisted to state.
+//
+// The Terraform Plugin SDK doesn't support ephemeral resources, so they are
+// described with the same schema.Resource used for data sources. The Read
+// function of the resource is called to open it, and Close is called with the
+// ID set by Read once Terraform no longer needs its values.
+type ephemeralResource struct {
+	*schema.Resource
+
+	Close func(ctx context.Context, id string, meta any) diag.Diagnostics
+}
+
+// providerServer serves the SDK provider along with its ephemeral resources
+// and functions.
+type providerServer struct {
+	tfprotov5.ProviderServer
+
+	provider  *schema.Provider
+	resources map[string]*ephemeralResource
+	functions map[string]*providerFunction
+
+	// ephemeralProvider holds the ephemeral resources as data sources so the
+	// SDK can handle their schema and configuration.
+	ephemeralProvider *schema.Provider
+	ephemeralServer   *schema.GRPCProviderServer
+
+	// ephemeralMeta shares the meta of the configured provider with
+	// ephemeralProvider the first time an ephemeral resource is opened, since
+	// the meta of a schema.Provider must not be set while it is in use.
+	ephemeralMeta sync.Once
+}
+
+// NewProviderServer returns the protocol server for the provider p, adding
+// support for the ephemeral resources and functions of the Nomad provider.
+func NewProviderServer(p *schema.Provider) tfprotov5.ProviderServer {
+	resources := ephemeralResources()
+
+	dataSources := make(map[string]*schema.Resource, len(resources))
+	for name, r := range resources {
+		dataSources[name] = r.Resource
+	}
+	ephemeralProvider := &schema.Provider{DataSourcesMap: dataSources}
+
+	return &providerServer{
+		ProviderServer:    schema.NewGRPCProviderServer(p),
+		provider:          p,
+		resources:         resources,
+		functions:         providerFunctions(),
+		ephemeralProvider: ephemeralProvider,
+		ephemeralServer:   schema.NewGRPCProviderServer(ephemeralProvider),
+	}
+}
+
+func (s *providerServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	resp, err := s.ProviderServer.GetMetadata(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	for name := range s.resources {
+		resp.EphemeralResources = append(resp.EphemeralResources, tfprotov5.EphemeralResourceMetadata{
+			TypeName: name,
+		})
+	}
+	for name := range s.functions {
+		resp.Functions = append(resp.Functions, tfprotov5.FunctionMetadata{
+			Name: name,
+		})
+	}
+	resp.ServerCapabilities = withMoveResourceState(resp.ServerCapabilities)
+	return resp, nil
+}
+
+func (s *providerServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	ephemeralResp, err := s.ephemeralServer.GetProviderSchema(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	resp.Diagnostics = append(resp.Diagnostics, ephemeralResp.Diagnostics...)
+	resp.EphemeralResourceSchemas = ephemeralResp.DataSourceSchemas
+	resp.Functions = s.functionDefinitions()
+	resp.ServerCapabilities = withMoveResourceState(resp.ServerCapabilities)
+
+	return resp, nil
+}
+
+func (s *providerServer) ValidateEphemeralResourceConfig(ctx context.Context, req *tfprotov5.ValidateEphemeralResourceConfigRequest) (*tfprotov5.ValidateEphemeralResourceConfigResponse, error) {
+	validateResp, err := s.ephemeralServer.ValidateDataSourceConfig(ctx, &tfprotov5.ValidateDataSourceConfigRequest{
+		TypeName: req.TypeName,
+		Config:   req.Config,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.ValidateEphemeralResourceConfigResponse{
+		Diagnostics: validateResp.Diagnostics,
+	}, nil
+}
+
+func (s *providerServer) OpenEphemeralResource(ctx context.Context, req *tfprotov5.OpenEphemeralResourceRequest) (*tfprotov5.OpenEphemeralResourceResponse, error) {
+	resp := &tfprotov5.OpenEphemeralResourceResponse{}
+
+	meta := s.provider.Meta()
+	if meta == nil && req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed {
+		// The provider has deferred its configuration since it is unknown.
+		resp.Deferred = &tfprotov5.Deferred{
+			Reason: tfprotov5.DeferredReasonProviderConfigUnknown,
+		}
+		return resp, nil
+	}
+	if meta == nil {
+		resp.Diagnostics = append(resp.Diagnostics, errorDiagnostic(
+			fmt.Sprintf("Unable to open %s", req.TypeName),
+			"The provider has not been configured.",
+		))
+		return resp, nil
+	}
+	s.ephemeralMeta.Do(func() {
+		s.ephemeralProvider.SetMeta(meta)
+	})
+
+	readResp, err := s.ephemeralServer.ReadDataSource(ctx, &tfprotov5.ReadDataSourceRequest{
+		TypeName: req.TypeName,
+		Config:   req.Config,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Diagnostics = readResp.Diagnostics
+	if readResp.State == nil {
+		return resp, nil
+	}
+	resp.Result = readResp.State
+
+	id, err := s.ephemeralResourceID(ctx, req.TypeName, readResp.State)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, errorDiagnostic(
+			fmt.Sprintf("Unable to open %s", req.TypeName),
+			err.Error(),
+		))
+		return resp, nil
+	}
+	resp.Private = []byte(id)
+
+	return resp, nil
+}
+
+func (s *providerServer) RenewEphemeralResource(ctx context.Context, req *tfprotov5.RenewEphemeralResourceRequest) (*tfprotov5.RenewEphemeralResourceResponse, error) {
+	// None of the ephemeral resources request to be renewed.
+	return &tfprotov5.RenewEphemeralResourceResponse{Private: req.Private}, nil
+}
+
+func (s *providerServer) CloseEphemeralResource(ctx context.Context, req *tfprotov5.CloseEphemeralResourceRequest) (*tfprotov5.CloseEphemeralResourceResponse, error) {
+	resp := &tfprotov5.CloseEphemeralResourceResponse{}
+
+	r, ok := s.resources[req.TypeName]
+	if !ok {
+		resp.Diagnostics = append(resp.Diagnostics, errorDiagnostic(
+			"Unknown ephemeral resource",
+			fmt.Sprintf("The provider doesn't support the ephemeral resource %q.", req.TypeName),
+		))
+		return resp, nil
+	}
+	if r.Close == nil || len(req.Private) == 0 {
+		return resp, nil
+	}
+
+	for _, d := range r.Close(ctx, string(req.Private), s.provider.Meta()) {
+		resp.Diagnostics = append(resp.Diagnostics, toProtoDiagnostic(d))
+	}
+	return resp, nil
+}
+
+// ephemeralResourceID returns the ID set by the Read function of an ephemeral
+// resource from its encoded result.
+func (s *providerServer) ephemeralResourceID(ctx context.Context, typeName string, result *tfprotov5.DynamicValue) (string, error) {
+	schemaResp, err := s.ephemeralServer.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return "", err
+	}
+	resourceSchema, ok := schemaResp.DataSourceSchemas[typeName]
+	if !ok {
+		return "", fmt.Errorf("unknown ephemeral resource %q", typeName)
+	}
+
+	val, err := result.Unmarshal(resourceSchema.ValueType())
+	if err != nil {
+		return "", fmt.Errorf("failed to decode result: %v", err)
+	}
+
+	var attrs map[string]tftypes.Value
+	if err := val.As(&attrs); err != nil {
+		return "", fmt.Errorf("failed to decode result: %v", err)
+	}
+
+	var id string
+	if v, ok := attrs["id"]; ok && v.IsKnown() && !v.IsNull() {
+		if err := v.As(&id); err != nil {
+			return "", fmt.Errorf("failed to decode id: %v", err)
+		}
+	}
+	return id, nil
+}
+
+// withMoveResourceState returns the capabilities of the SDK server with
+// support for moving resources, which is handled by MoveResourceState.
+func withMoveResourceState(capabilities *tfprotov5.ServerCapabilities) *tfprotov5.ServerCapabilities {
+	if capabilities == nil {
+		capabilities = &tfprotov5.ServerCapabilities{}
+	}
+	capabilities.MoveResourceState = true
+	return capabilities
+}
+
+func errorDiagnostic(summary, detail string) *tfprotov5.Diagnostic {
+	return &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  summary,
+		Detail:   detail,
+	}
+}
+
+func toProtoDiagnostic(d diag.Diagnostic) *tfprotov5.Diagnostic {
+	severity := tfprotov5.DiagnosticSeverityError
+	if d.Severity == diag.Warning {
+		severity = tfprotov5.DiagnosticSeverityWarning
+	}
+	return &tfprotov5.Diagnostic{
+		Severity: severity,
+		Summary:  d.Summary,
+		Detail:   d.Detail,
+	}
+}
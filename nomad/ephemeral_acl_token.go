@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ephemeralACLToken() *ephemeralResource {
+	return &ephemeralResource{
+		Resource: &schema.Resource{
+			ReadContext: ephemeralACLTokenOpen,
+
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "Human-readable name for this token.",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+				"type": {
+					Description:  "The type of token to create, 'client' or 'management'.",
+					Required:     true,
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"client", "management"}, false),
+				},
+				"policies": {
+					Description: "The ACL policies to associate with the token, if it's a 'client' type.",
+					Optional:    true,
+					Type:        schema.TypeSet,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"role": {
+					Description: "The roles that should be applied to the token. It may be used multiple times.",
+					Optional:    true,
+					Type:        schema.TypeSet,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "The ID of the ACL role to link.",
+							},
+							"name": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "The name of the ACL role linked.",
+							},
+						},
+					},
+				},
+				"global": {
+					Description: "Whether the token should be replicated to all regions or not.",
+					Optional:    true,
+					Type:        schema.TypeBool,
+					Default:     false,
+				},
+				"expiration_ttl": {
+					Description: `Provides a TTL for the token in the form of a time duration such as "5m" or "1h".`,
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+				"accessor_id": {
+					Description: "Nomad-generated ID for this token.",
+					Computed:    true,
+					Type:        schema.TypeString,
+				},
+				"secret_id": {
+					Description: "The value that grants access to Nomad.",
+					Computed:    true,
+					Sensitive:   true,
+					Type:        schema.TypeString,
+				},
+				"create_time": {
+					Description: "The timestamp the token was created.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"expiration_time": {
+					Description: "The point after which a token is considered expired and eligible for destruction.",
+					Computed:    true,
+					Type:        schema.TypeString,
+				},
+			},
+		},
+		Close: ephemeralACLTokenClose,
+	}
+}
+
+func ephemeralACLTokenOpen(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(ProviderConfig).client
+
+	token, err := resourceACLTokenGenerate(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Creating ephemeral ACL token")
+	resp, _, err := client.ACLTokens().Create(token, nil)
+	if err != nil {
+		return diag.Errorf("error creating ACL token: %s", err)
+	}
+	log.Printf("[DEBUG] Created ephemeral ACL token %q", resp.AccessorID)
+
+	var expirationTime string
+	if resp.ExpirationTime != nil {
+		expirationTime = resp.ExpirationTime.Format(time.RFC3339)
+	}
+
+	d.SetId(resp.AccessorID)
+	d.Set("accessor_id", resp.AccessorID)
+	d.Set("secret_id", resp.SecretID)
+	if len(resp.Roles) > 0 {
+		roles := make([]map[string]any, len(resp.Roles))
+		for i, roleLink := range resp.Roles {
+			roles[i] = map[string]any{"id": roleLink.ID, "name": roleLink.Name}
+		}
+		d.Set("role", roles)
+	}
+	d.Set("create_time", resp.CreateTime.UTC().String())
+	d.Set("expiration_time", expirationTime)
+
+	return nil
+}
+
+func ephemeralACLTokenClose(_ context.Context, accessor string, meta any) diag.Diagnostics {
+	client := meta.(ProviderConfig).client
+
+	log.Printf("[DEBUG] Deleting ephemeral ACL token %q", accessor)
+	_, err := client.ACLTokens().Delete(accessor, nil)
+	if err != nil {
+		// The token may have already expired and been garbage collected.
+		if isNotFoundError(err) {
+			return nil
+		}
+		return diag.Errorf("error deleting ACL token %q: %s", accessor, err)
+	}
+	log.Printf("[DEBUG] Deleted ephemeral ACL token %q", accessor)
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEphemeralACLToken_openClose(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests skipped unless env 'TF_ACC' set")
+	}
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	s := NewProviderServer(testProvider)
+
+	openResp, err := s.OpenEphemeralResource(ctx, &tfprotov5.OpenEphemeralResourceRequest{
+		TypeName: "nomad_acl_token",
+		Config: testEphemeralResourceConfig(t, s, "nomad_acl_token", map[string]tftypes.Value{
+			"name":           tftypes.NewValue(tftypes.String, "terraform-ephemeral-token-test"),
+			"type":           tftypes.NewValue(tftypes.String, "client"),
+			"policies":       tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "dev")}),
+			"expiration_ttl": tftypes.NewValue(tftypes.String, "10m"),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(openResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", openResp.Diagnostics)
+	}
+
+	result := testEphemeralResourceResult(t, s, "nomad_acl_token", openResp.Result)
+	var accessor, secret, expiration string
+	result["accessor_id"].As(&accessor)
+	result["secret_id"].As(&secret)
+	result["expiration_time"].As(&expiration)
+	if accessor == "" || secret == "" || expiration == "" {
+		t.Fatalf("expected accessor_id, secret_id and expiration_time to be set, got %v", result)
+	}
+	if string(openResp.Private) != accessor {
+		t.Fatalf("expected private data to be %q, got %q", accessor, openResp.Private)
+	}
+
+	client := testProvider.Meta().(ProviderConfig).client
+	token, _, err := client.ACLTokens().Info(accessor, nil)
+	if err != nil {
+		t.Fatalf("error reading back token %q: %v", accessor, err)
+	}
+	if token.SecretID != secret {
+		t.Fatalf("expected secret_id to match token %q", accessor)
+	}
+
+	closeResp, err := s.CloseEphemeralResource(ctx, &tfprotov5.CloseEphemeralResourceRequest{
+		TypeName: "nomad_acl_token",
+		Private:  openResp.Private,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(closeResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", closeResp.Diagnostics)
+	}
+
+	_, _, err = client.ACLTokens().Info(accessor, nil)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("expected token %q to be deleted, got %v", accessor, err)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProviderServer_schema(t *testing.T) {
+	s := NewProviderServer(Provider())
+	ctx := context.Background()
+
+	metaResp, err := s.GetMetadata(ctx, &tfprotov5.GetMetadataRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name := range ephemeralResources() {
+		found := false
+		for _, r := range metaResp.EphemeralResources {
+			if r.TypeName == name {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected ephemeral resource %q in metadata", name)
+		}
+	}
+	if len(metaResp.Resources) == 0 || len(metaResp.DataSources) == 0 {
+		t.Errorf("expected resources and data sources in metadata")
+	}
+
+	schemaResp, err := s.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name := range ephemeralResources() {
+		if _, ok := schemaResp.EphemeralResourceSchemas[name]; !ok {
+			t.Errorf("expected schema for ephemeral resource %q", name)
+		}
+	}
+	if _, ok := schemaResp.ResourceSchemas["nomad_job"]; !ok {
+		t.Errorf("expected schema for resource nomad_job")
+	}
+}
+
+func TestProviderServer_validateEphemeralResourceConfig(t *testing.T) {
+	s := NewProviderServer(Provider())
+
+	resp, err := s.ValidateEphemeralResourceConfig(context.Background(), &tfprotov5.ValidateEphemeralResourceConfigRequest{
+		TypeName: "nomad_acl_token",
+		Config: testEphemeralResourceConfig(t, s, "nomad_acl_token", map[string]tftypes.Value{
+			"type": tftypes.NewValue(tftypes.String, "invalid"),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) == 0 {
+		t.Fatal("expected invalid token type to fail validation")
+	}
+
+	resp, err = s.ValidateEphemeralResourceConfig(context.Background(), &tfprotov5.ValidateEphemeralResourceConfigRequest{
+		TypeName: "nomad_acl_token",
+		Config: testEphemeralResourceConfig(t, s, "nomad_acl_token", map[string]tftypes.Value{
+			"type": tftypes.NewValue(tftypes.String, "management"),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderServer_openEphemeralResourceConcurrently(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Nomad-Index", "1")
+		fmt.Fprint(w, `{"Namespace":"default","Path":"foo","Items":{"bar":"baz"}}`)
+	}))
+	defer ts.Close()
+
+	s := NewProviderServer(Provider())
+	ctx := context.Background()
+
+	schemaResp, err := s.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configResp, err := s.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{
+		Config: testObjectValue(t, schemaResp.Provider.ValueType(), map[string]tftypes.Value{
+			"address": tftypes.NewValue(tftypes.String, ts.URL),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", configResp.Diagnostics[0])
+	}
+
+	config := testEphemeralResourceConfig(t, s, "nomad_variable", map[string]tftypes.Value{
+		"path": tftypes.NewValue(tftypes.String, "foo"),
+	})
+
+	// Terraform opens the ephemeral resources of a configuration in parallel.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			openResp, err := s.OpenEphemeralResource(ctx, &tfprotov5.OpenEphemeralResourceRequest{
+				TypeName: "nomad_variable",
+				Config:   config,
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if len(openResp.Diagnostics) != 0 {
+				t.Errorf("unexpected diagnostics: %v", openResp.Diagnostics[0])
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// testEphemeralResourceConfig encodes the configuration of an ephemeral
+// resource, setting any attribute that isn't in values to null.
+func testEphemeralResourceConfig(t *testing.T, s tfprotov5.ProviderServer, typeName string, values map[string]tftypes.Value) *tfprotov5.DynamicValue {
+	t.Helper()
+
+	schemaResp, err := s.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := schemaResp.EphemeralResourceSchemas[typeName].ValueType().(tftypes.Object)
+
+	attrs := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+			continue
+		}
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	config, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, attrs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &config
+}
+
+// testEphemeralResourceResult decodes the result of opening an ephemeral
+// resource.
+func testEphemeralResourceResult(t *testing.T, s tfprotov5.ProviderServer, typeName string, result *tfprotov5.DynamicValue) map[string]tftypes.Value {
+	t.Helper()
+
+	schemaResp, err := s.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	val, err := result.Unmarshal(schemaResp.EphemeralResourceSchemas[typeName].ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var attrs map[string]tftypes.Value
+	if err := val.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	return attrs
+}
@@ -0,0 +1,12 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+// ephemeralVariable reads a variable the same way as the nomad_variable data
+// source. Nothing needs to be cleaned up when it's closed.
+func ephemeralVariable() *ephemeralResource {
+	return &ephemeralResource{
+		Resource: dataSourceVariable(),
+	}
+}
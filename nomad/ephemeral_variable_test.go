@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEphemeralVariable_open(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests skipped unless env 'TF_ACC' set")
+	}
+	testAccPreCheck(t)
+	testCheckMinVersion(t, "1.4.0")
+
+	path := "terraform/ephemeral-test"
+	client := testProvider.Meta().(ProviderConfig).client
+	_, _, err := client.Variables().Create(&api.Variable{
+		Path:  path,
+		Items: api.VariableItems{"password": "hunter2"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("error creating variable: %v", err)
+	}
+	defer client.Variables().Delete(path, nil)
+
+	ctx := context.Background()
+	s := NewProviderServer(testProvider)
+
+	openResp, err := s.OpenEphemeralResource(ctx, &tfprotov5.OpenEphemeralResourceRequest{
+		TypeName: "nomad_variable",
+		Config: testEphemeralResourceConfig(t, s, "nomad_variable", map[string]tftypes.Value{
+			"path": tftypes.NewValue(tftypes.String, path),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(openResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", openResp.Diagnostics)
+	}
+
+	result := testEphemeralResourceResult(t, s, "nomad_variable", openResp.Result)
+	var items map[string]tftypes.Value
+	if err := result["items"].As(&items); err != nil {
+		t.Fatal(err)
+	}
+	var password string
+	items["password"].As(&password)
+	if password != "hunter2" {
+		t.Fatalf("expected password to be %q, got %q", "hunter2", password)
+	}
+
+	closeResp, err := s.CloseEphemeralResource(ctx, &tfprotov5.CloseEphemeralResourceRequest{
+		TypeName: "nomad_variable",
+		Private:  openResp.Private,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(closeResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", closeResp.Diagnostics)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"sync"
+)
+
+// detailFetchConcurrency is the number of requests the data sources send at
+// the same time to read the details of the objects they list.
+const detailFetchConcurrency = 8
+
+// fetchAll calls fetch for each item, with at most workers calls running at
+// the same time, and returns their results in the order of items. It returns
+// the error of the first item that failed, if any, once all the calls
+// started are done.
+func fetchAll[T, R any](items []T, workers int, fetch func(T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+	if workers < 1 {
+		workers = 1
+	}
+
+	indexes := make(chan int)
+	var failed sync.Once
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(items)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = fetch(items[i])
+				if errs[i] != nil {
+					failed.Do(func() { close(done) })
+				}
+			}
+		}()
+	}
+
+	// No more items are fetched once one of them failed.
+send:
+	for i := range items {
+		select {
+		case indexes <- i:
+		case <-done:
+			break send
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
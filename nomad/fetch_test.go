@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestFetchAll(t *testing.T) {
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+
+	var running, maxRunning atomic.Int32
+	results, err := fetchAll(items, 4, func(i int) (string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return strconv.Itoa(i), nil
+	})
+	must.NoError(t, err)
+	must.Len(t, 50, results)
+	for i, result := range results {
+		must.Eq(t, strconv.Itoa(i), result)
+	}
+	must.LessEq(t, 4, maxRunning.Load())
+
+	results, err = fetchAll([]int{}, 4, func(i int) (string, error) { return "", nil })
+	must.NoError(t, err)
+	must.SliceEmpty(t, results)
+
+	var calls atomic.Int32
+	_, err = fetchAll(items, 1, func(i int) (string, error) {
+		calls.Add(1)
+		if i == 2 {
+			return "", errors.New("failed")
+		}
+		return "", nil
+	})
+	must.EqError(t, err, "failed")
+	must.Less(t, 50, calls.Load())
+}
@@ -0,0 +1,248 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NewMuxServer returns the protocol server of the provider. It serves the
+// resources, data sources, ephemeral resources and functions of the SDK
+// provider p along with the resources and data sources of the Terraform Plugin
+// Framework provider, so they can be ported to the framework one at a time.
+func NewMuxServer(ctx context.Context, p *schema.Provider) (tfprotov5.ProviderServer, error) {
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		func() tfprotov5.ProviderServer {
+			return NewProviderServer(p)
+		},
+		providerserver.NewProtocol5(&frameworkProvider{sdkProvider: p}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer(), nil
+}
+
+// frameworkProvider holds the resources and data sources implemented with the
+// Terraform Plugin Framework.
+//
+// The servers muxed together must have the same provider schema, so its
+// schema is the schema served for the SDK provider. The SDK provider is
+// configured first, and its meta is shared with the resources and data
+// sources of the framework provider instead of configuring another client.
+type frameworkProvider struct {
+	sdkProvider *schema.Provider
+}
+
+var _ provider.Provider = &frameworkProvider{}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "nomad"
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	schemaResp, err := schema.NewGRPCProviderServer(p.sdkProvider).GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read the provider schema", err.Error())
+		return
+	}
+	if schemaResp.Provider == nil || schemaResp.Provider.Block == nil {
+		return
+	}
+
+	attributes, blocks, err := frameworkSchemaBlock(schemaResp.Provider.Block)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to convert the provider schema", err.Error())
+		return
+	}
+	resp.Schema = fwschema.Schema{
+		Attributes:  attributes,
+		Blocks:      blocks,
+		Description: schemaResp.Provider.Block.Description,
+	}
+}
+
+func (p *frameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	meta := p.sdkProvider.Meta()
+	resp.DataSourceData = meta
+	resp.ResourceData = meta
+}
+
+func (p *frameworkProvider) Resources(context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// frameworkSchemaBlock returns the attributes and nested blocks of the
+// framework provider schema equivalent to the protocol schema block.
+func frameworkSchemaBlock(block *tfprotov5.SchemaBlock) (map[string]fwschema.Attribute, map[string]fwschema.Block, error) {
+	attributes := make(map[string]fwschema.Attribute, len(block.Attributes))
+	for _, a := range block.Attributes {
+		attribute, err := frameworkSchemaAttribute(a)
+		if err != nil {
+			return nil, nil, fmt.Errorf("attribute %q: %w", a.Name, err)
+		}
+		attributes[a.Name] = attribute
+	}
+
+	blocks := make(map[string]fwschema.Block, len(block.BlockTypes))
+	for _, b := range block.BlockTypes {
+		nestedAttributes, nestedBlocks, err := frameworkSchemaBlock(b.Block)
+		if err != nil {
+			return nil, nil, fmt.Errorf("block %q: %w", b.TypeName, err)
+		}
+		nested := fwschema.NestedBlockObject{
+			Attributes: nestedAttributes,
+			Blocks:     nestedBlocks,
+		}
+
+		switch b.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			blocks[b.TypeName] = fwschema.ListNestedBlock{
+				NestedObject: nested,
+				Description:  b.Block.Description,
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			blocks[b.TypeName] = fwschema.SetNestedBlock{
+				NestedObject: nested,
+				Description:  b.Block.Description,
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSingle:
+			blocks[b.TypeName] = fwschema.SingleNestedBlock{
+				Attributes:  nestedAttributes,
+				Blocks:      nestedBlocks,
+				Description: b.Block.Description,
+			}
+		default:
+			return nil, nil, fmt.Errorf("block %q: unsupported nesting mode %s", b.TypeName, b.Nesting)
+		}
+	}
+
+	return attributes, blocks, nil
+}
+
+// frameworkSchemaAttribute returns the attribute of the framework provider
+// schema equivalent to the protocol schema attribute.
+func frameworkSchemaAttribute(a *tfprotov5.SchemaAttribute) (fwschema.Attribute, error) {
+	var deprecationMessage string
+	if a.Deprecated {
+		deprecationMessage = fmt.Sprintf("%s is deprecated.", a.Name)
+	}
+
+	switch {
+	case a.Type.Is(tftypes.String):
+		return fwschema.StringAttribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: deprecationMessage,
+		}, nil
+	case a.Type.Is(tftypes.Bool):
+		return fwschema.BoolAttribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: deprecationMessage,
+		}, nil
+	case a.Type.Is(tftypes.Number):
+		return fwschema.NumberAttribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: deprecationMessage,
+		}, nil
+	}
+
+	typ, err := frameworkAttrType(a.Type)
+	if err != nil {
+		return nil, err
+	}
+	switch typ := typ.(type) {
+	case types.ListType:
+		return fwschema.ListAttribute{
+			ElementType:        typ.ElemType,
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: deprecationMessage,
+		}, nil
+	case types.SetType:
+		return fwschema.SetAttribute{
+			ElementType:        typ.ElemType,
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: deprecationMessage,
+		}, nil
+	case types.MapType:
+		return fwschema.MapAttribute{
+			ElementType:        typ.ElemType,
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: deprecationMessage,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", a.Type)
+	}
+}
+
+// frameworkAttrType returns the framework type of the values of the protocol
+// type typ.
+func frameworkAttrType(typ tftypes.Type) (attr.Type, error) {
+	switch typ := typ.(type) {
+	case tftypes.List:
+		elem, err := frameworkAttrType(typ.ElementType)
+		return types.ListType{ElemType: elem}, err
+	case tftypes.Set:
+		elem, err := frameworkAttrType(typ.ElementType)
+		return types.SetType{ElemType: elem}, err
+	case tftypes.Map:
+		elem, err := frameworkAttrType(typ.ElementType)
+		return types.MapType{ElemType: elem}, err
+	case tftypes.Object:
+		attrTypes := make(map[string]attr.Type, len(typ.AttributeTypes))
+		for name, attrType := range typ.AttributeTypes {
+			t, err := frameworkAttrType(attrType)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[name] = t
+		}
+		return types.ObjectType{AttrTypes: attrTypes}, nil
+	}
+
+	switch {
+	case typ.Is(tftypes.String):
+		return types.StringType, nil
+	case typ.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case typ.Is(tftypes.Number):
+		return types.NumberType, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", typ)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMuxServer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Nomad-Index", "1")
+		w.Write([]byte(`[{"Name":"default"}]`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	p := Provider()
+	s, err := NewMuxServer(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The provider schema of the framework provider must be the schema of
+	// the SDK provider for the servers to be muxed.
+	schemaResp, err := s.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schemaResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", schemaResp.Diagnostics[0])
+	}
+	if _, ok := schemaResp.ResourceSchemas["nomad_job"]; !ok {
+		t.Errorf("expected schema for resource nomad_job")
+	}
+	if _, ok := schemaResp.DataSourceSchemas["nomad_namespaces"]; !ok {
+		t.Errorf("expected schema for data source nomad_namespaces")
+	}
+	for name := range ephemeralResources() {
+		if _, ok := schemaResp.EphemeralResourceSchemas[name]; !ok {
+			t.Errorf("expected schema for ephemeral resource %q", name)
+		}
+	}
+	for name := range providerFunctions() {
+		if _, ok := schemaResp.Functions[name]; !ok {
+			t.Errorf("expected function %q", name)
+		}
+	}
+
+	// Both providers are configured with the same configuration.
+	configResp, err := s.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{
+		Config: testObjectValue(t, schemaResp.Provider.ValueType(), map[string]tftypes.Value{
+			"address": tftypes.NewValue(tftypes.String, ts.URL),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", configResp.Diagnostics[0])
+	}
+	if p.Meta() == nil {
+		t.Fatal("expected the SDK provider to be configured")
+	}
+
+	readResp, err := s.ReadDataSource(ctx, &tfprotov5.ReadDataSourceRequest{
+		TypeName: "nomad_namespaces",
+		Config:   testObjectValue(t, schemaResp.DataSourceSchemas["nomad_namespaces"].ValueType(), nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(readResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics[0])
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func functionFormatJobspec() *providerFunction {
+	return &providerFunction{
+		Function: &tfprotov5.Function{
+			Summary:     "Format an HCL jobspec",
+			Description: "Rewrites an HCL2 jobspec to the canonical format and style, like `nomad fmt`.",
+			Parameters: []*tfprotov5.FunctionParameter{
+				{
+					Name:        "jobspec",
+					Description: "The HCL2 jobspec to format.",
+					Type:        tftypes.String,
+				},
+			},
+			Return: &tfprotov5.FunctionReturn{
+				Type: tftypes.String,
+			},
+		},
+		Call: callFormatJobspec,
+	}
+}
+
+func callFormatJobspec(_ context.Context, args []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	var jobspec string
+	if err := args[0].As(&jobspec); err != nil {
+		return tftypes.Value{}, functionArgumentError(0, "Failed to decode jobspec: %v", err)
+	}
+
+	out, err := formatJobspec(jobspec)
+	if err != nil {
+		return tftypes.Value{}, functionArgumentError(0, "%v", err)
+	}
+	return tftypes.NewValue(tftypes.String, out), nil
+}
+
+// formatJobspec returns the jobspec in the canonical HCL format. Like `nomad
+// fmt`, the jobspec only needs to be syntactically valid, it isn't parsed as
+// a job.
+func formatJobspec(jobspec string) (string, error) {
+	src := []byte(jobspec)
+
+	// hclwrite doesn't report syntax errors, so the jobspec is parsed first
+	// to avoid formatting invalid input into something else.
+	_, diags := hclsyntax.ParseConfig(src, "jobspec.nomad.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", diags
+	}
+
+	return string(hclwrite.Format(src)), nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/shoenig/test/must"
+)
+
+func TestFunctionFormatJobspec(t *testing.T) {
+	s := NewProviderServer(Provider())
+
+	result, funcErr := testCallFunction(t, s, "format_jobspec", tftypes.NewValue(tftypes.String, `job "example" {
+datacenters = ["dc1"]
+  group "cache" {
+      count=2
+    task "redis" {
+  driver    = "docker"
+    }
+  }
+}
+`))
+	must.Nil(t, funcErr)
+
+	var out string
+	must.NoError(t, result.As(&out))
+	must.Eq(t, `job "example" {
+  datacenters = ["dc1"]
+  group "cache" {
+    count = 2
+    task "redis" {
+      driver = "docker"
+    }
+  }
+}
+`, out)
+
+	// Formatting is idempotent.
+	result, funcErr = testCallFunction(t, s, "format_jobspec", tftypes.NewValue(tftypes.String, out))
+	must.Nil(t, funcErr)
+	var again string
+	must.NoError(t, result.As(&again))
+	must.Eq(t, out, again)
+
+	_, funcErr = testCallFunction(t, s, "format_jobspec", tftypes.NewValue(tftypes.String, `job "example" {`))
+	must.NotNil(t, funcErr)
+	must.NotNil(t, funcErr.FunctionArgument)
+	must.Eq(t, 0, *funcErr.FunctionArgument)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func functionJobspecToJSON() *providerFunction {
+	return &providerFunction{
+		Function: &tfprotov5.Function{
+			Summary:     "Convert an HCL jobspec to JSON",
+			Description: "Parses an HCL2 jobspec and returns the JSON representation of the job, in the format expected by the Nomad API.",
+			Parameters: []*tfprotov5.FunctionParameter{
+				{
+					Name:        "jobspec",
+					Description: "The HCL2 jobspec to convert.",
+					Type:        tftypes.String,
+				},
+			},
+			Return: &tfprotov5.FunctionReturn{
+				Type: tftypes.String,
+			},
+		},
+		Call: callJobspecToJSON,
+	}
+}
+
+func callJobspecToJSON(_ context.Context, args []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	var jobspec string
+	if err := args[0].As(&jobspec); err != nil {
+		return tftypes.Value{}, functionArgumentError(0, "Failed to decode jobspec: %v", err)
+	}
+
+	out, err := jobspecToJSON(jobspec)
+	if err != nil {
+		return tftypes.Value{}, functionArgumentError(0, "%v", err)
+	}
+	return tftypes.NewValue(tftypes.String, out), nil
+}
+
+// jobspecToJSON returns the JSON representation of the HCL2 jobspec with a
+// "Job" root, like the output of `nomad job run -output`, so it can be
+// submitted to the Nomad API or used as the jobspec of a nomad_job with the
+// json argument set.
+func jobspecToJSON(jobspec string) (string, error) {
+	job, err := parseJobspec(jobspec, JobParserConfig{})
+	if err != nil {
+		return "", err
+	}
+
+	out, err := json.Marshal(struct{ Job *api.Job }{Job: job})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/shoenig/test/must"
+)
+
+func TestFunctionJobspecToJSON(t *testing.T) {
+	s := NewProviderServer(Provider())
+
+	result, funcErr := testCallFunction(t, s, "jobspec_to_json", tftypes.NewValue(tftypes.String, `
+job "example" {
+  datacenters = ["dc1"]
+
+  group "cache" {
+    count = 2
+
+    task "redis" {
+      driver = "docker"
+
+      config {
+        image = "redis:7"
+      }
+    }
+  }
+}
+`))
+	must.Nil(t, funcErr)
+
+	var out string
+	must.NoError(t, result.As(&out))
+
+	var root struct {
+		Job struct {
+			ID          string
+			Datacenters []string
+			TaskGroups  []struct {
+				Name  string
+				Count int
+				Tasks []struct {
+					Name   string
+					Driver string
+					Config map[string]any
+				}
+			}
+		}
+	}
+	must.NoError(t, json.Unmarshal([]byte(out), &root))
+	must.Eq(t, "example", root.Job.ID)
+	must.Eq(t, []string{"dc1"}, root.Job.Datacenters)
+	must.Len(t, 1, root.Job.TaskGroups)
+	must.Eq(t, "cache", root.Job.TaskGroups[0].Name)
+	must.Eq(t, 2, root.Job.TaskGroups[0].Count)
+	must.Len(t, 1, root.Job.TaskGroups[0].Tasks)
+	must.Eq(t, "docker", root.Job.TaskGroups[0].Tasks[0].Driver)
+	must.Eq(t, "redis:7", root.Job.TaskGroups[0].Tasks[0].Config["image"])
+
+	// The output can be used as a JSON jobspec.
+	job, err := parseJSONJobspec(out)
+	must.NoError(t, err)
+	must.Eq(t, "example", *job.ID)
+
+	_, funcErr = testCallFunction(t, s, "jobspec_to_json", tftypes.NewValue(tftypes.String, `job "example" {`))
+	must.NotNil(t, funcErr)
+	must.NotNil(t, funcErr.FunctionArgument)
+	must.Eq(t, 0, *funcErr.FunctionArgument)
+	must.StrContains(t, funcErr.Text, "error parsing jobspec")
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func functionRenderJobspec() *providerFunction {
+	return &providerFunction{
+		Function: &tfprotov5.Function{
+			Summary:     "Set the variables of an HCL jobspec",
+			Description: "Returns the HCL2 jobspec with the values given as the defaults of its variables, so the rendered jobspec doesn't need any variable to be parsed.",
+			Parameters: []*tfprotov5.FunctionParameter{
+				{
+					Name:        "jobspec",
+					Description: "The HCL2 jobspec to render.",
+					Type:        tftypes.String,
+				},
+				{
+					Name:        "variables",
+					Description: "The values of the variables of the jobspec, in the same format as the `-var` flag of `nomad job run`.",
+					Type:        tftypes.Map{ElementType: tftypes.String},
+				},
+			},
+			Return: &tfprotov5.FunctionReturn{
+				Type: tftypes.String,
+			},
+		},
+		Call: callRenderJobspec,
+	}
+}
+
+func callRenderJobspec(_ context.Context, args []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	var jobspec string
+	if err := args[0].As(&jobspec); err != nil {
+		return tftypes.Value{}, functionArgumentError(0, "Failed to decode jobspec: %v", err)
+	}
+
+	var values map[string]tftypes.Value
+	if err := args[1].As(&values); err != nil {
+		return tftypes.Value{}, functionArgumentError(1, "Failed to decode variables: %v", err)
+	}
+	vars := make(map[string]string, len(values))
+	for name, v := range values {
+		var s string
+		if err := v.As(&s); err != nil {
+			return tftypes.Value{}, functionArgumentError(1, "Failed to decode variable %q: %v", name, err)
+		}
+		vars[name] = s
+	}
+
+	// Parse the jobspec with the variables first so undeclared variables and
+	// invalid values are reported the same way as by nomad_job.
+	if _, err := parseJobspec(jobspec, JobParserConfig{HCL2: HCL2JobParserConfig{Vars: vars}}); err != nil {
+		return tftypes.Value{}, &tfprotov5.FunctionError{Text: err.Error()}
+	}
+
+	out, err := renderJobspec(jobspec, vars)
+	if err != nil {
+		return tftypes.Value{}, functionArgumentError(0, "%v", err)
+	}
+	return tftypes.NewValue(tftypes.String, out), nil
+}
+
+// renderJobspec sets the values given as the defaults of the variable blocks
+// of the jobspec. Values of variables of type string are used as-is, while
+// the others are parsed as HCL expressions like the -var flag of `nomad job
+// run` does.
+func renderJobspec(jobspec string, vars map[string]string) (string, error) {
+	f, diags := hclwrite.ParseConfig([]byte(jobspec), "jobspec.nomad.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", diags
+	}
+
+	for _, block := range f.Body().Blocks() {
+		if block.Type() != "variable" || len(block.Labels()) != 1 {
+			continue
+		}
+		name := block.Labels()[0]
+		value, ok := vars[name]
+		if !ok {
+			continue
+		}
+
+		body := block.Body()
+		typ := body.GetAttribute("type")
+		if typ == nil || strings.TrimSpace(string(typ.Expr().BuildTokens(nil).Bytes())) == "string" {
+			body.SetAttributeValue("default", cty.StringVal(value))
+			continue
+		}
+
+		expr, err := variableValueTokens(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for variable %q: %v", name, err)
+		}
+		body.SetAttributeRaw("default", expr)
+	}
+
+	return string(hclwrite.Format(f.Bytes())), nil
+}
+
+// variableValueTokens returns the tokens of the HCL expression of a variable
+// value.
+func variableValueTokens(value string) (hclwrite.Tokens, error) {
+	f, diags := hclwrite.ParseConfig([]byte("value = "+value+"\n"), "value", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	attr := f.Body().GetAttribute("value")
+	if attr == nil {
+		return nil, fmt.Errorf("%q is not a valid value", value)
+	}
+	return attr.Expr().BuildTokens(nil), nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/shoenig/test/must"
+)
+
+func TestFunctionRenderJobspec(t *testing.T) {
+	s := NewProviderServer(Provider())
+
+	jobspec := `variable "image" {
+  type = string
+}
+
+variable "count" {
+  type    = number
+  default = 1
+}
+
+variable "datacenters" {
+  type    = list(string)
+  default = ["dc1"]
+}
+
+job "example" {
+  datacenters = var.datacenters
+
+  group "cache" {
+    count = var.count
+
+    task "redis" {
+      driver = "docker"
+
+      config {
+        image = var.image
+      }
+    }
+  }
+}
+`
+
+	vars := func(values map[string]string) tftypes.Value {
+		m := make(map[string]tftypes.Value, len(values))
+		for k, v := range values {
+			m[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, m)
+	}
+
+	result, funcErr := testCallFunction(t, s, "render_jobspec",
+		tftypes.NewValue(tftypes.String, jobspec),
+		vars(map[string]string{
+			"image":       "redis:${version}",
+			"count":       "3",
+			"datacenters": `["dc1", "dc2"]`,
+		}),
+	)
+	must.Nil(t, funcErr)
+
+	var out string
+	must.NoError(t, result.As(&out))
+	must.StrContains(t, out, `default = "redis:$${version}"`)
+
+	// The rendered jobspec can be parsed without variables.
+	job, err := parseJobspec(out, JobParserConfig{})
+	must.NoError(t, err)
+	must.Eq(t, []string{"dc1", "dc2"}, job.Datacenters)
+	must.Eq(t, 3, *job.TaskGroups[0].Count)
+	must.Eq(t, "redis:${version}", job.TaskGroups[0].Tasks[0].Config["image"])
+
+	// Variables without a default must be set.
+	_, funcErr = testCallFunction(t, s, "render_jobspec",
+		tftypes.NewValue(tftypes.String, jobspec),
+		vars(nil),
+	)
+	must.NotNil(t, funcErr)
+
+	// Variables must be declared in the jobspec.
+	_, funcErr = testCallFunction(t, s, "render_jobspec",
+		tftypes.NewValue(tftypes.String, jobspec),
+		vars(map[string]string{"image": "redis:7", "region": "global"}),
+	)
+	must.NotNil(t, funcErr)
+}
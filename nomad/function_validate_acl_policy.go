@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var aclPolicyValidationType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"valid":  tftypes.Bool,
+		"errors": tftypes.List{ElementType: tftypes.String},
+	},
+}
+
+func functionValidateACLPolicy() *providerFunction {
+	return &providerFunction{
+		Function: &tfprotov5.Function{
+			Summary:     "Validate ACL policy rules",
+			Description: "Parses ACL policy rules with the same parser used by the Nomad servers and returns an object with a `valid` boolean and the list of `errors` found.",
+			Parameters: []*tfprotov5.FunctionParameter{
+				{
+					Name:        "rules",
+					Description: "The ACL policy rules to validate, in HCL.",
+					Type:        tftypes.String,
+				},
+			},
+			Return: &tfprotov5.FunctionReturn{
+				Type: aclPolicyValidationType,
+			},
+		},
+		Call: callValidateACLPolicy,
+	}
+}
+
+func callValidateACLPolicy(_ context.Context, args []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	var rules string
+	if err := args[0].As(&rules); err != nil {
+		return tftypes.Value{}, functionArgumentError(0, "Failed to decode rules: %v", err)
+	}
+
+	errs := validateACLPolicy(rules)
+	errValues := make([]tftypes.Value, len(errs))
+	for i, err := range errs {
+		errValues[i] = tftypes.NewValue(tftypes.String, err)
+	}
+
+	return tftypes.NewValue(aclPolicyValidationType, map[string]tftypes.Value{
+		"valid":  tftypes.NewValue(tftypes.Bool, len(errs) == 0),
+		"errors": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, errValues),
+	}), nil
+}
+
+// validateACLPolicy returns the errors found in the ACL policy rules. Invalid
+// rules are reported in the result instead of failing the call so they can
+// be checked in preconditions.
+func validateACLPolicy(rules string) []string {
+	if _, err := acl.Parse(rules); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/shoenig/test/must"
+)
+
+func TestFunctionValidateACLPolicy(t *testing.T) {
+	s := NewProviderServer(Provider())
+
+	cases := []struct {
+		name  string
+		rules string
+		valid bool
+		err   string
+	}{
+		{
+			name: "valid",
+			rules: `
+namespace "default" {
+  policy       = "read"
+  capabilities = ["submit-job"]
+}
+
+node {
+  policy = "read"
+}
+`,
+			valid: true,
+		},
+		{
+			name:  "invalid syntax",
+			rules: `namespace "default" {`,
+			err:   "Failed to parse ACL Policy",
+		},
+		{
+			name: "invalid capability",
+			rules: `
+namespace "default" {
+  capabilities = ["sumbit-job"]
+}
+`,
+			err: "Invalid namespace capability 'sumbit-job'",
+		},
+		{
+			name: "invalid policy",
+			rules: `
+node {
+  policy = "admin"
+}
+`,
+			err: "Invalid node policy",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, funcErr := testCallFunction(t, s, "validate_acl_policy", tftypes.NewValue(tftypes.String, tc.rules))
+			must.Nil(t, funcErr)
+
+			var attrs map[string]tftypes.Value
+			must.NoError(t, result.As(&attrs))
+
+			var valid bool
+			must.NoError(t, attrs["valid"].As(&valid))
+			must.Eq(t, tc.valid, valid)
+
+			var errValues []tftypes.Value
+			must.NoError(t, attrs["errors"].As(&errValues))
+			if tc.valid {
+				must.SliceEmpty(t, errValues)
+				return
+			}
+			must.Len(t, 1, errValues)
+			var errMsg string
+			must.NoError(t, errValues[0].As(&errMsg))
+			must.StrContains(t, errMsg, tc.err)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// providerFunction is a function that can be called from the Terraform
+// configuration with provider::nomad::<name>.
+//
+// The Terraform Plugin SDK doesn't support provider-defined functions, so
+// they are served directly by providerServer. Functions can't rely on the
+// provider configuration since Terraform may call them before configuring
+// the provider.
+type providerFunction struct {
+	*tfprotov5.Function
+
+	// Call is called with the arguments decoded according to the types of
+	// the parameters of the function, and must return a value of the type of
+	// its return.
+	Call func(ctx context.Context, args []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError)
+}
+
+func (s *providerServer) GetFunctions(ctx context.Context, req *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	return &tfprotov5.GetFunctionsResponse{
+		Functions: s.functionDefinitions(),
+	}, nil
+}
+
+func (s *providerServer) CallFunction(ctx context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	f, ok := s.functions[req.Name]
+	if !ok {
+		return &tfprotov5.CallFunctionResponse{
+			Error: &tfprotov5.FunctionError{
+				Text: fmt.Sprintf("The provider doesn't support the function %q.", req.Name),
+			},
+		}, nil
+	}
+
+	args, funcErr := decodeFunctionArguments(f.Function, req.Arguments)
+	if funcErr != nil {
+		return &tfprotov5.CallFunctionResponse{Error: funcErr}, nil
+	}
+
+	result, funcErr := f.Call(ctx, args)
+	if funcErr != nil {
+		return &tfprotov5.CallFunctionResponse{Error: funcErr}, nil
+	}
+
+	value, err := tfprotov5.NewDynamicValue(f.Return.Type, result)
+	if err != nil {
+		return &tfprotov5.CallFunctionResponse{
+			Error: &tfprotov5.FunctionError{
+				Text: fmt.Sprintf("Failed to encode the result of %s: %v", req.Name, err),
+			},
+		}, nil
+	}
+	return &tfprotov5.CallFunctionResponse{Result: &value}, nil
+}
+
+// functionDefinitions returns the definitions of the functions, indexed by
+// their name.
+func (s *providerServer) functionDefinitions() map[string]*tfprotov5.Function {
+	definitions := make(map[string]*tfprotov5.Function, len(s.functions))
+	for name, f := range s.functions {
+		definitions[name] = f.Function
+	}
+	return definitions
+}
+
+// decodeFunctionArguments decodes the arguments of a call to the function
+// definition according to the types of its parameters.
+func decodeFunctionArguments(definition *tfprotov5.Function, arguments []*tfprotov5.DynamicValue) ([]tftypes.Value, *tfprotov5.FunctionError) {
+	args := make([]tftypes.Value, 0, len(arguments))
+	for i, arg := range arguments {
+		var param *tfprotov5.FunctionParameter
+		switch {
+		case i < len(definition.Parameters):
+			param = definition.Parameters[i]
+		case definition.VariadicParameter != nil:
+			param = definition.VariadicParameter
+		default:
+			return nil, &tfprotov5.FunctionError{
+				Text: fmt.Sprintf("Expected %d arguments, got %d.", len(definition.Parameters), len(arguments)),
+			}
+		}
+
+		if arg == nil {
+			return nil, functionArgumentError(i, "Missing value for argument %q.", param.Name)
+		}
+
+		value, err := arg.Unmarshal(param.Type)
+		if err != nil {
+			return nil, functionArgumentError(i, "Failed to decode argument %q: %v", param.Name, err)
+		}
+		args = append(args, value)
+	}
+
+	if len(args) < len(definition.Parameters) {
+		return nil, &tfprotov5.FunctionError{
+			Text: fmt.Sprintf("Expected %d arguments, got %d.", len(definition.Parameters), len(arguments)),
+		}
+	}
+	return args, nil
+}
+
+// functionArgumentError returns an error about the argument at index i.
+func functionArgumentError(i int, format string, a ...any) *tfprotov5.FunctionError {
+	index := int64(i)
+	return &tfprotov5.FunctionError{
+		Text:             fmt.Sprintf(format, a...),
+		FunctionArgument: &index,
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/shoenig/test/must"
+)
+
+func TestProviderServer_functions(t *testing.T) {
+	s := NewProviderServer(Provider())
+	ctx := context.Background()
+
+	metaResp, err := s.GetMetadata(ctx, &tfprotov5.GetMetadataRequest{})
+	must.NoError(t, err)
+	schemaResp, err := s.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	must.NoError(t, err)
+
+	for name := range providerFunctions() {
+		found := false
+		for _, f := range metaResp.Functions {
+			if f.Name == name {
+				found = true
+			}
+		}
+		must.True(t, found, must.Sprintf("expected function %q in metadata", name))
+
+		definition, ok := schemaResp.Functions[name]
+		must.True(t, ok, must.Sprintf("expected definition for function %q", name))
+		must.NotNil(t, definition.Return)
+	}
+
+	resp, err := s.CallFunction(ctx, &tfprotov5.CallFunctionRequest{Name: "unknown"})
+	must.NoError(t, err)
+	must.NotNil(t, resp.Error)
+
+	_, funcErr := testCallFunction(t, s, "jobspec_to_json")
+	must.NotNil(t, funcErr)
+	must.StrContains(t, funcErr.Text, "Expected 1 arguments, got 0.")
+}
+
+// testCallFunction calls the function with the arguments given and returns its
+// result.
+func testCallFunction(t *testing.T, s tfprotov5.ProviderServer, name string, args ...tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	t.Helper()
+
+	funcsResp, err := s.GetFunctions(context.Background(), &tfprotov5.GetFunctionsRequest{})
+	must.NoError(t, err)
+	definition, ok := funcsResp.Functions[name]
+	must.True(t, ok, must.Sprintf("expected function %q", name))
+
+	arguments := make([]*tfprotov5.DynamicValue, len(args))
+	for i, arg := range args {
+		value, err := tfprotov5.NewDynamicValue(arg.Type(), arg)
+		must.NoError(t, err)
+		arguments[i] = &value
+	}
+
+	resp, err := s.CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{
+		Name:      name,
+		Arguments: arguments,
+	})
+	must.NoError(t, err)
+	if resp.Error != nil {
+		return tftypes.Value{}, resp.Error
+	}
+
+	result, err := resp.Result.Unmarshal(definition.Return.Type)
+	must.NoError(t, err)
+	return result, nil
+}
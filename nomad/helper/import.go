@@ -5,34 +5,33 @@ package helper
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-var (
-	missingNamespaceImportErr = errors.New("missing namespace, the import ID should follow the pattern <id>@<namespace>")
-	missingIDImportErr        = errors.New("missing resource ID, the import ID should follow the pattern <id>@<namespace>")
-)
-
 // NamespacedImporterContext imports a namespaced resource that doesn't have
 // its namespace as part of the Terraform resource ID.
 func NamespacedImporterContext(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
-	namespacedID := d.Id()
-	sepIdx := strings.LastIndex(namespacedID, "@")
-	if sepIdx == -1 {
-		return nil, missingNamespaceImportErr
+	id, ns, err := ParseNamespacedImportID(d.Id(), false)
+	if err != nil {
+		return nil, err
 	}
 
-	ns := namespacedID[sepIdx+1:]
-	if len(ns) == 0 {
-		return nil, missingNamespaceImportErr
-	}
+	d.SetId(id)
+	d.Set("namespace", ns)
 
-	id := namespacedID[:sepIdx]
-	if len(id) == 0 {
-		return nil, missingIDImportErr
+	return []*schema.ResourceData{d}, nil
+}
+
+// OptionalNamespacedImporterContext is like NamespacedImporterContext, but
+// resources imported without a namespace are imported from the default
+// namespace, for resources that used to be imported with their ID only.
+func OptionalNamespacedImporterContext(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	id, ns, err := ParseNamespacedImportID(d.Id(), true)
+	if err != nil {
+		return nil, err
 	}
 
 	d.SetId(id)
@@ -40,3 +39,28 @@ func NamespacedImporterContext(_ context.Context, d *schema.ResourceData, meta a
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// ParseNamespacedImportID splits an import ID that follows the pattern
+// <id>@<namespace>. If optionalNamespace is true, IDs without a namespace are
+// in the default namespace.
+func ParseNamespacedImportID(importID string, optionalNamespace bool) (string, string, error) {
+	sepIdx := strings.LastIndex(importID, "@")
+	if sepIdx == -1 {
+		if optionalNamespace && importID != "" {
+			return importID, "default", nil
+		}
+		return "", "", fmt.Errorf("missing namespace in import ID %q, the import ID should follow the pattern <id>@<namespace>", importID)
+	}
+
+	id := importID[:sepIdx]
+	if len(id) == 0 {
+		return "", "", fmt.Errorf("missing resource ID in import ID %q, the import ID should follow the pattern <id>@<namespace>", importID)
+	}
+
+	ns := importID[sepIdx+1:]
+	if len(ns) == 0 {
+		return "", "", fmt.Errorf("missing namespace in import ID %q, the import ID should follow the pattern <id>@<namespace>", importID)
+	}
+
+	return id, ns, nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestParseNamespacedImportID(t *testing.T) {
+	cases := []struct {
+		importID string
+		optional bool
+		id       string
+		ns       string
+		err      string
+	}{
+		{importID: "example@prod", id: "example", ns: "prod"},
+		{importID: "example@prod", optional: true, id: "example", ns: "prod"},
+		{importID: "team@a@prod", id: "team@a", ns: "prod"},
+		{importID: "example", optional: true, id: "example", ns: "default"},
+		{importID: "example", err: `missing namespace in import ID "example"`},
+		{importID: "example@", err: "missing namespace"},
+		{importID: "@prod", err: "missing resource ID"},
+		{importID: "", optional: true, err: "missing namespace"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.importID, func(t *testing.T) {
+			id, ns, err := ParseNamespacedImportID(tc.importID, tc.optional)
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+				must.ErrorContains(t, err, "<id>@<namespace>")
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.id, id)
+			must.Eq(t, tc.ns, ns)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NamespaceDiffSuppress is the DiffSuppressFunc of the namespace arguments.
+// Nomad stores objects created without a namespace in the default namespace,
+// so an empty namespace in the configuration is the same as "default".
+func NamespaceDiffSuppress(_, old, new string, _ *schema.ResourceData) bool {
+	return NamespaceOrDefault(old) == NamespaceOrDefault(new)
+}
+
+// NamespaceOrDefault returns ns, or the default namespace when it is empty.
+func NamespaceOrDefault(ns string) string {
+	if ns == "" {
+		return api.DefaultNamespace
+	}
+	return ns
+}
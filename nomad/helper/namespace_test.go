@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helper
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestNamespaceDiffSuppress(t *testing.T) {
+	cases := []struct {
+		old, new string
+		suppress bool
+	}{
+		{old: "default", new: "", suppress: true},
+		{old: "", new: "default", suppress: true},
+		{old: "default", new: "default", suppress: true},
+		{old: "default", new: "prod", suppress: false},
+		{old: "prod", new: "", suppress: false},
+	}
+
+	for _, tc := range cases {
+		must.Eq(t, tc.suppress, NamespaceDiffSuppress("namespace", tc.old, tc.new, nil),
+			must.Sprintf("old=%q new=%q", tc.old, tc.new))
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// indexLists list the modify index of all the objects managed by a resource
+// type, by ID, in a single request.
+var indexLists = map[string]func(*api.Client) (map[string]uint64, error){
+	"nomad_acl_policy": func(client *api.Client) (map[string]uint64, error) {
+		policies, _, err := client.ACLPolicies().List(nil)
+		return indexesByID(policies, err, func(p *api.ACLPolicyListStub) (string, uint64) {
+			return p.Name, p.ModifyIndex
+		})
+	},
+	"nomad_acl_role": func(client *api.Client) (map[string]uint64, error) {
+		roles, _, err := client.ACLRoles().List(nil)
+		return indexesByID(roles, err, func(r *api.ACLRoleListStub) (string, uint64) {
+			return r.ID, r.ModifyIndex
+		})
+	},
+	"nomad_namespace": func(client *api.Client) (map[string]uint64, error) {
+		namespaces, _, err := client.Namespaces().List(nil)
+		return indexesByID(namespaces, err, func(ns *api.Namespace) (string, uint64) {
+			return ns.Name, ns.ModifyIndex
+		})
+	},
+	"nomad_node_pool": func(client *api.Client) (map[string]uint64, error) {
+		pools, _, err := client.NodePools().List(nil)
+		return indexesByID(pools, err, func(p *api.NodePool) (string, uint64) {
+			return p.Name, p.ModifyIndex
+		})
+	},
+	"nomad_quota_specification": func(client *api.Client) (map[string]uint64, error) {
+		specs, _, err := client.Quotas().List(nil)
+		return indexesByID(specs, err, func(s *api.QuotaSpec) (string, uint64) {
+			return s.Name, s.ModifyIndex
+		})
+	},
+	"nomad_sentinel_policy": func(client *api.Client) (map[string]uint64, error) {
+		policies, _, err := client.SentinelPolicies().List(nil)
+		return indexesByID(policies, err, func(p *api.SentinelPolicyListStub) (string, uint64) {
+			return p.Name, p.ModifyIndex
+		})
+	},
+	"nomad_variable": func(client *api.Client) (map[string]uint64, error) {
+		vars, _, err := client.Variables().PrefixList("", &api.QueryOptions{Namespace: api.AllNamespacesNamespace})
+		return indexesByID(vars, err, func(v *api.VariableMetadata) (string, uint64) {
+			return v.Path + "@" + v.Namespace, v.ModifyIndex
+		})
+	},
+}
+
+func indexesByID[T any](objs []T, err error, f func(T) (string, uint64)) (map[string]uint64, error) {
+	if err != nil {
+		return nil, err
+	}
+	indexes := make(map[string]uint64, len(objs))
+	for _, obj := range objs {
+		id, index := f(obj)
+		indexes[id] = index
+	}
+	return indexes, nil
+}
+
+// refreshIndexes caches the modify indexes listed by indexLists, by resource
+// type and region.
+type refreshIndexes struct {
+	lock    sync.Mutex
+	indexes map[refreshIndexesKey]*listedIndexes
+}
+
+type refreshIndexesKey struct {
+	typeName string
+	region   string
+}
+
+type listedIndexes struct {
+	once    sync.Once
+	indexes map[string]uint64
+}
+
+// unchanged returns whether the object with the given ID is known to have
+// the given modify index, listing the indexes of the objects of typeName on
+// first use. It returns false whenever this can't be told for sure, so the
+// object is read as usual.
+func (r *refreshIndexes) unchanged(c ProviderConfig, typeName, id string, index uint64) bool {
+	if r == nil || c.client == nil || index == 0 {
+		return false
+	}
+
+	key := refreshIndexesKey{typeName: typeName}
+	if c.config != nil {
+		key.region = c.config.Region
+	}
+	r.lock.Lock()
+	if r.indexes == nil {
+		r.indexes = make(map[refreshIndexesKey]*listedIndexes)
+	}
+	listed, ok := r.indexes[key]
+	if !ok {
+		listed = &listedIndexes{}
+		r.indexes[key] = listed
+	}
+	r.lock.Unlock()
+
+	listed.once.Do(func() {
+		indexes, err := indexLists[typeName](c.client)
+		if err != nil {
+			// The token may not be allowed to list the objects, they are read
+			// one by one instead.
+			log.Printf("[WARN] Unable to list the indexes of %s, refreshing them one by one: %v", typeName, err)
+			return
+		}
+		listed.indexes = indexes
+	})
+
+	current, ok := listed.indexes[id]
+	return ok && current == index
+}
+
+// invalidate drops the indexes of typeName listed so far, after one of its
+// objects is modified by the provider.
+func (r *refreshIndexes) invalidate(typeName string) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for key := range r.indexes {
+		if key.typeName == typeName {
+			delete(r.indexes, key)
+		}
+	}
+}
+
+// withIndexedRefresh wraps the functions of the resources listed in
+// indexLists so the objects whose modify index didn't change since the last
+// read are not read again on refresh. The indexes of all the objects of a
+// type are listed in a single request instead, which is much faster when a
+// configuration manages hundreds of them.
+//
+// Only the functions called by Terraform are wrapped, the reads done by the
+// resources after they write their object are left as-is.
+func withIndexedRefresh(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for typeName, r := range resources {
+		if _, ok := indexLists[typeName]; !ok {
+			continue
+		}
+
+		if r.Exists != nil {
+			r.Exists = indexedExistsFunc(typeName, r.Exists)
+		}
+		if r.Read != nil {
+			r.Read = indexedReadFunc(typeName, r.Read)
+		}
+		if r.ReadContext != nil {
+			r.ReadContext = indexedReadContextFunc(typeName, r.ReadContext)
+		}
+
+		if r.Create != nil {
+			r.Create = invalidateIndexesFunc(typeName, r.Create)
+		}
+		if r.Update != nil {
+			r.Update = schema.UpdateFunc(invalidateIndexesFunc(typeName, schema.CreateFunc(r.Update)))
+		}
+		if r.Delete != nil {
+			r.Delete = schema.DeleteFunc(invalidateIndexesFunc(typeName, schema.CreateFunc(r.Delete)))
+		}
+		if r.CreateContext != nil {
+			r.CreateContext = invalidateIndexesContextFunc(typeName, r.CreateContext)
+		}
+		if r.UpdateContext != nil {
+			r.UpdateContext = schema.UpdateContextFunc(invalidateIndexesContextFunc(typeName, schema.CreateContextFunc(r.UpdateContext)))
+		}
+		if r.DeleteContext != nil {
+			r.DeleteContext = schema.DeleteContextFunc(invalidateIndexesContextFunc(typeName, schema.CreateContextFunc(r.DeleteContext)))
+		}
+	}
+	return resources
+}
+
+// indexUnchanged returns whether the object of d still has the modify index
+// stored in the state.
+func indexUnchanged(typeName string, d *schema.ResourceData, meta any) bool {
+	config, ok := meta.(ProviderConfig)
+	if !ok || d.Id() == "" {
+		return false
+	}
+	index, _ := d.Get("modify_index").(int)
+	if !config.refreshIndexes.unchanged(config, typeName, d.Id(), uint64(index)) {
+		return false
+	}
+	log.Printf("[DEBUG] The modify index of %s %q is still %d, skipping its read", typeName, d.Id(), index)
+	return true
+}
+
+func indexedExistsFunc(typeName string, f schema.ExistsFunc) schema.ExistsFunc {
+	return func(d *schema.ResourceData, meta any) (bool, error) {
+		if indexUnchanged(typeName, d, meta) {
+			return true, nil
+		}
+		return f(d, meta)
+	}
+}
+
+func indexedReadFunc(typeName string, f schema.ReadFunc) schema.ReadFunc {
+	return func(d *schema.ResourceData, meta any) error {
+		if indexUnchanged(typeName, d, meta) {
+			return nil
+		}
+		return f(d, meta)
+	}
+}
+
+func indexedReadContextFunc(typeName string, f schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		if indexUnchanged(typeName, d, meta) {
+			return nil
+		}
+		return f(ctx, d, meta)
+	}
+}
+
+func invalidateIndexesFunc(typeName string, f schema.CreateFunc) schema.CreateFunc {
+	return func(d *schema.ResourceData, meta any) error {
+		if config, ok := meta.(ProviderConfig); ok {
+			defer config.refreshIndexes.invalidate(typeName)
+		}
+		return f(d, meta)
+	}
+}
+
+func invalidateIndexesContextFunc(typeName string, f schema.CreateContextFunc) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		if config, ok := meta.(ProviderConfig); ok {
+			defer config.refreshIndexes.invalidate(typeName)
+		}
+		return f(ctx, d, meta)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/shoenig/test/must"
+)
+
+func TestWithIndexedRefresh(t *testing.T) {
+	var lists, reads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/acl/policies":
+			lists.Add(1)
+			json.NewEncoder(w).Encode([]*api.ACLPolicyListStub{
+				{Name: "ops", ModifyIndex: 10},
+			})
+		case "/v1/acl/policy/ops":
+			reads.Add(1)
+			json.NewEncoder(w).Encode(&api.ACLPolicy{
+				Name:        "ops",
+				Rules:       `namespace "default" { policy = "read" }`,
+				ModifyIndex: 10,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	must.NoError(t, err)
+	meta := ProviderConfig{client: client, refreshIndexes: &refreshIndexes{}}
+
+	r := withIndexedRefresh(map[string]*schema.Resource{
+		"nomad_acl_policy": resourceACLPolicy(),
+	})["nomad_acl_policy"]
+	data := func(index string) *schema.ResourceData {
+		return r.Data(&terraform.InstanceState{
+			ID:         "ops",
+			Attributes: map[string]string{"name": "ops", "modify_index": index},
+		})
+	}
+
+	// The policy is not read when its index didn't change.
+	d := data("10")
+	exists, err := r.Exists(d, meta)
+	must.NoError(t, err)
+	must.True(t, exists)
+	must.NoError(t, r.Read(d, meta))
+	must.Eq(t, 1, lists.Load())
+	must.Eq(t, 0, reads.Load())
+
+	// The indexes are only listed once.
+	d = data("7")
+	exists, err = r.Exists(d, meta)
+	must.NoError(t, err)
+	must.True(t, exists)
+	must.NoError(t, r.Read(d, meta))
+	must.Eq(t, 1, lists.Load())
+	must.Eq(t, 2, reads.Load())
+	must.Eq(t, 10, d.Get("modify_index").(int))
+
+	// The indexes are listed again once the provider modifies a policy.
+	meta.refreshIndexes.invalidate("nomad_acl_policy")
+	must.NoError(t, r.Read(data("10"), meta))
+	must.Eq(t, 2, lists.Load())
+	must.Eq(t, 2, reads.Load())
+
+	// The policies are always read when the option is disabled.
+	meta.refreshIndexes = nil
+	must.NoError(t, r.Read(data("10"), meta))
+	must.Eq(t, 2, lists.Load())
+	must.Eq(t, 3, reads.Load())
+}
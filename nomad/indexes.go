@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// selfIndexedResources are the resources that compute their modify_index in
+// their own CustomizeDiff, because not every update modifies their object.
+var selfIndexedResources = map[string]bool{
+	"nomad_job":      true,
+	"nomad_variable": true,
+}
+
+// withIndexes marks the modify_index attribute of the resources as unknown
+// when they are updated, so the plan shows that the index changes and
+// replace_triggered_by can reference it.
+func withIndexes(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for typeName, r := range resources {
+		if _, ok := r.Schema["modify_index"]; !ok || selfIndexedResources[typeName] {
+			continue
+		}
+		r.CustomizeDiff = indexesCustomizeDiffFunc(r.CustomizeDiff)
+	}
+	return resources
+}
+
+func indexesCustomizeDiffFunc(f schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+		if f != nil {
+			if err := f(ctx, d, meta); err != nil {
+				return err
+			}
+		}
+
+		if d.Id() == "" || !resourceUpdated(d) {
+			return nil
+		}
+		return d.SetNewComputed("modify_index")
+	}
+}
+
+// resourceUpdated returns whether the diff updates the resource. Attributes
+// that are only unknown, like the computed attributes missing from the state,
+// and the destroyArguments don't update it.
+func resourceUpdated(d *schema.ResourceDiff) bool {
+	for _, key := range d.GetChangedKeysPrefix("") {
+		if d.NewValueKnown(key) && !slices.Contains(destroyArguments, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/shoenig/test/must"
+)
+
+func TestWithIndexes(t *testing.T) {
+	r := Provider().ResourcesMap["nomad_namespace"]
+	state := &terraform.InstanceState{
+		ID: "example",
+		Attributes: map[string]string{
+			"id":           "example",
+			"name":         "example",
+			"description":  "before",
+			"create_index": "10",
+			"modify_index": "20",
+		},
+	}
+	meta := ProviderConfig{offline: true}
+
+	// The modify index doesn't change when the namespace is not updated.
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]any{
+		"name":        "example",
+		"description": "before",
+	}), meta)
+	must.NoError(t, err)
+	if diff != nil {
+		_, ok := diff.Attributes["modify_index"]
+		must.False(t, ok)
+	}
+
+	// It's unknown until the namespace is updated, unlike the create index.
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]any{
+		"name":        "example",
+		"description": "after",
+	}), meta)
+	must.NoError(t, err)
+	must.NotNil(t, diff.Attributes["modify_index"])
+	must.True(t, diff.Attributes["modify_index"].NewComputed)
+	_, ok := diff.Attributes["create_index"]
+	must.False(t, ok)
+}
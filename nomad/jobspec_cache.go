@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/copystructure"
+)
+
+const (
+	// jobspecCacheSize is the number of parsed jobspecs kept in memory.
+	jobspecCacheSize = 256
+
+	// jobspecHashCacheSize is the number of jobspec hashes kept in memory.
+	jobspecHashCacheSize = 4096
+)
+
+// jobspecCacheMaxLen is the length of the largest jobspec whose job is
+// cached, so the jobs generated with thousands of groups are not kept in
+// memory. Their hash is still cached.
+var jobspecCacheMaxLen = 1 << 20
+
+// jobspecCache holds the jobs parsed by parseJobspec, by the hash of their
+// jobspec and parser configuration, since the same jobspec is parsed several
+// times during a run, by CustomizeDiff, the register and the drift check of
+// nomad_job.
+var jobspecCache, _ = lru.New[string, *api.Job](jobspecCacheSize)
+
+// jobspecHashCache holds the hashes returned by jobspecHash, so comparing the
+// jobspecs of the state and the configuration, which Terraform does several
+// times during a plan, only parses them once.
+var jobspecHashCache, _ = lru.New[string, string](jobspecHashCacheSize)
+
+// jobspecCacheKey returns the key of the jobspec in the cache. The jobspecs
+// parsed with AllowFS are not cached: they may read files whose content is
+// not part of the key.
+func jobspecCacheKey(raw string, config JobParserConfig) (string, bool) {
+	if config.HCL2.AllowFS {
+		return "", false
+	}
+
+	b, err := json.Marshal(struct {
+		Jobspec string
+		JSON    bool
+		Vars    map[string]string
+	}{raw, config.JSON.Enabled, config.HCL2.Vars})
+	if err != nil {
+		return "", false
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), true
+}
+
+// cachedJobspec returns a copy of the job parsed from the jobspec, if it's
+// in the cache. The callers modify the job, so the cache keeps its own copy.
+func cachedJobspec(key string) (*api.Job, bool) {
+	job, ok := jobspecCache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return copyJob(job)
+}
+
+func cacheJobspec(key string, job *api.Job) {
+	if job, ok := copyJob(job); ok {
+		jobspecCache.Add(key, job)
+	}
+}
+
+func copyJob(job *api.Job) (*api.Job, bool) {
+	c, err := copystructure.Copy(job)
+	if err != nil {
+		log.Printf("[WARN] Unable to copy the parsed job, parsing its jobspec again: %v", err)
+		return nil, false
+	}
+	return c.(*api.Job), true
+}
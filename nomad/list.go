@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// withListArguments adds the filter, sort_by and limit arguments shared by the
+// data sources that list objects to their schema.
+func withListArguments(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["filter"] = &schema.Schema{
+		Description:  "Specifies the expression used to filter the results, using the fields of the objects returned by the Nomad API.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validateExpression,
+	}
+	s["sort_by"] = &schema.Schema{
+		Description: "The attribute of the results to sort them by.",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+	s["limit"] = &schema.Schema{
+		Description:  "The maximum number of results to return.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+	}
+	return s
+}
+
+// listArguments are the values of the arguments added by withListArguments.
+type listArguments struct {
+	filter string
+	sortBy string
+	limit  int
+}
+
+func getListArguments(d *schema.ResourceData) listArguments {
+	a := listArguments{}
+	a.filter, _ = d.Get("filter").(string)
+	a.sortBy, _ = d.Get("sort_by").(string)
+	a.limit, _ = d.Get("limit").(int)
+	return a
+}
+
+// serverFilter returns the filter to send to the Nomad API, combining the
+// filter argument with the expression built by the data source from its
+// other arguments, if any.
+func (a listArguments) serverFilter(filter string) string {
+	switch {
+	case a.filter == "":
+		return filter
+	case filter == "":
+		return a.filter
+	}
+	return fmt.Sprintf("(%s) and (%s)", filter, a.filter)
+}
+
+// perPage returns the number of objects the Nomad API can return, so the
+// results are trimmed server-side. It must only be used when all the results
+// returned by the API are kept.
+func (a listArguments) perPage() int32 {
+	if a.sortBy != "" {
+		return 0
+	}
+	return int32(a.limit)
+}
+
+// filterList returns the objects that match the filter argument, for the
+// endpoints of the Nomad API that don't support filtering.
+func filterList[T any](a listArguments, objs []T) ([]T, error) {
+	if a.filter == "" {
+		return objs, nil
+	}
+
+	eval, err := bexpr.CreateEvaluator(a.filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	result := make([]T, 0, len(objs))
+	for _, obj := range objs {
+		match, err := eval.Evaluate(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate filter: %w", err)
+		}
+		if match {
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
+
+// sortAndLimit sorts the flattened results by the sort_by attribute and keeps
+// the first limit of them. The order of the results is kept when sort_by is
+// not set.
+func (a listArguments) sortAndLimit(items []map[string]any) ([]map[string]any, error) {
+	if a.sortBy != "" {
+		for _, item := range items {
+			if _, ok := item[a.sortBy]; !ok {
+				return nil, fmt.Errorf("invalid sort_by: the results have no %q attribute", a.sortBy)
+			}
+		}
+		sort.SliceStable(items, func(i, j int) bool {
+			return lessListValue(items[i][a.sortBy], items[j][a.sortBy])
+		})
+	}
+
+	if a.limit > 0 && len(items) > a.limit {
+		items = items[:a.limit]
+	}
+	return items, nil
+}
+
+// lessListValue compares two values of an attribute. The attributes of the
+// data sources exporting maps of strings hold numbers as strings, so they are
+// compared as numbers when they can be parsed.
+func lessListValue(a, b any) bool {
+	switch a := a.(type) {
+	case string:
+		b := fmt.Sprint(b)
+		af, aErr := strconv.ParseFloat(a, 64)
+		bf, bErr := strconv.ParseFloat(b, 64)
+		if aErr == nil && bErr == nil {
+			return af < bf
+		}
+		return a < b
+	case int:
+		b, _ := b.(int)
+		return a < b
+	case int64:
+		b, _ := b.(int64)
+		return a < b
+	case uint64:
+		b, _ := b.(uint64)
+		return a < b
+	case bool:
+		b, _ := b.(bool)
+		return !a && b
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
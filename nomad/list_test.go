@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+)
+
+func TestListArguments_serverFilter(t *testing.T) {
+	must.Eq(t, "", listArguments{}.serverFilter(""))
+	must.Eq(t, `JobID == "example"`, listArguments{}.serverFilter(`JobID == "example"`))
+	must.Eq(t, `Status == "running"`, listArguments{filter: `Status == "running"`}.serverFilter(""))
+	must.Eq(t,
+		`(JobID == "example") and (Status == "running")`,
+		listArguments{filter: `Status == "running"`}.serverFilter(`JobID == "example"`),
+	)
+}
+
+func TestListArguments_perPage(t *testing.T) {
+	must.Eq(t, 0, listArguments{}.perPage())
+	must.Eq(t, 5, listArguments{limit: 5}.perPage())
+
+	// The results are sorted by the provider, so all of them must be read.
+	must.Eq(t, 0, listArguments{limit: 5, sortBy: "name"}.perPage())
+}
+
+func TestFilterList(t *testing.T) {
+	policies := []*api.ACLPolicyListStub{
+		{Name: "ops", Description: "Operators"},
+		{Name: "dev", Description: "Developers"},
+		{Name: "readonly"},
+	}
+
+	got, err := filterList(listArguments{}, policies)
+	must.NoError(t, err)
+	must.Eq(t, policies, got)
+
+	got, err = filterList(listArguments{filter: `Description != ""`}, policies)
+	must.NoError(t, err)
+	must.Eq(t, policies[:2], got)
+
+	got, err = filterList(listArguments{filter: `Name matches "^d"`}, policies)
+	must.NoError(t, err)
+	must.Eq(t, policies[1:2], got)
+
+	_, err = filterList(listArguments{filter: `Name ==`}, policies)
+	must.ErrorContains(t, err, "invalid filter")
+}
+
+func TestListArguments_sortAndLimit(t *testing.T) {
+	items := func() []map[string]any {
+		return []map[string]any{
+			{"name": "b", "nodes_healthy": "10", "create_index": uint64(3)},
+			{"name": "c", "nodes_healthy": "9", "create_index": uint64(1)},
+			{"name": "a", "nodes_healthy": "10", "create_index": uint64(2)},
+		}
+	}
+	names := func(items []map[string]any) []string {
+		var result []string
+		for _, item := range items {
+			result = append(result, item["name"].(string))
+		}
+		return result
+	}
+
+	cases := []struct {
+		name string
+		args listArguments
+		want []string
+	}{
+		{
+			name: "unchanged",
+			want: []string{"b", "c", "a"},
+		},
+		{
+			name: "sort by string",
+			args: listArguments{sortBy: "name"},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "sort by number",
+			args: listArguments{sortBy: "create_index"},
+			want: []string{"c", "a", "b"},
+		},
+		{
+			name: "sort by number as string",
+			args: listArguments{sortBy: "nodes_healthy"},
+			want: []string{"c", "b", "a"},
+		},
+		{
+			name: "limit",
+			args: listArguments{limit: 2},
+			want: []string{"b", "c"},
+		},
+		{
+			name: "sort and limit",
+			args: listArguments{sortBy: "name", limit: 1},
+			want: []string{"a"},
+		},
+		{
+			name: "limit above count",
+			args: listArguments{limit: 10},
+			want: []string{"b", "c", "a"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.args.sortAndLimit(items())
+			must.NoError(t, err)
+			must.Eq(t, tc.want, names(got))
+		})
+	}
+
+	_, err := listArguments{sortBy: "missing"}.sortAndLimit(items())
+	must.EqError(t, err, `invalid sort_by: the results have no "missing" attribute`)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	ctymsgpack "github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+// stateMover converts the state of a resource into the state of another
+// resource type. The state is the JSON representation of the resource at its
+// current schema version.
+type stateMover func(state map[string]any) (map[string]any, error)
+
+// stateMovers lists the resource types that can be moved into another one with
+// a moved block, indexed by the source and the target type.
+var stateMovers = map[string]map[string]stateMover{
+	"nomad_external_volume": {
+		"nomad_csi_volume": moveExternalVolumeState,
+	},
+	"nomad_volume": {
+		"nomad_csi_volume_registration": moveVolumeState,
+	},
+}
+
+// MoveResourceState supports moving the deprecated volume resources to their
+// CSI equivalents without replacing the volumes.
+func (s *providerServer) MoveResourceState(ctx context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
+	resp := &tfprotov5.MoveResourceStateResponse{}
+
+	move, ok := stateMovers[req.SourceTypeName][req.TargetTypeName]
+	if !ok || !strings.HasSuffix(req.SourceProviderAddress, "/nomad") {
+		resp.Diagnostics = append(resp.Diagnostics, errorDiagnostic(
+			"Unsupported resource move",
+			fmt.Sprintf("The provider doesn't support moving %s resources from %s to %s.",
+				req.SourceTypeName, req.SourceProviderAddress, req.TargetTypeName),
+		))
+		return resp, nil
+	}
+
+	// Upgrade the source state first so the move only has to handle the
+	// current schema version of the source resource.
+	upgradeResp, err := s.ProviderServer.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: req.SourceTypeName,
+		Version:  req.SourceSchemaVersion,
+		RawState: req.SourceState,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Diagnostics = append(resp.Diagnostics, upgradeResp.Diagnostics...)
+	if upgradeResp.UpgradedState == nil {
+		return resp, nil
+	}
+
+	state, err := s.decodeResourceState(req.SourceTypeName, upgradeResp.UpgradedState)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, errorDiagnostic(
+			fmt.Sprintf("Unable to move %s", req.SourceTypeName), err.Error(),
+		))
+		return resp, nil
+	}
+
+	state, err = move(state)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, errorDiagnostic(
+			fmt.Sprintf("Unable to move %s to %s", req.SourceTypeName, req.TargetTypeName), err.Error(),
+		))
+		return resp, nil
+	}
+
+	rawState, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attributes that don't exist in the target resource are removed, and
+	// those that are missing are set to null, when the state is upgraded.
+	targetResp, err := s.ProviderServer.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: req.TargetTypeName,
+		Version:  int64(s.provider.ResourcesMap[req.TargetTypeName].SchemaVersion),
+		RawState: &tfprotov5.RawState{JSON: rawState},
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Diagnostics = append(resp.Diagnostics, targetResp.Diagnostics...)
+	resp.TargetState = targetResp.UpgradedState
+
+	return resp, nil
+}
+
+// decodeResourceState returns the JSON representation of the state of a
+// resource.
+func (s *providerServer) decodeResourceState(typeName string, state *tfprotov5.DynamicValue) (map[string]any, error) {
+	ty := s.provider.ResourcesMap[typeName].CoreConfigSchema().ImpliedType()
+
+	val, err := ctymsgpack.Unmarshal(state.MsgPack, ty)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode state: %v", err)
+	}
+	raw, err := ctyjson.Marshal(val, ty)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode state: %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode state: %v", err)
+	}
+	return result, nil
+}
+
+// moveExternalVolumeState converts the state of a nomad_external_volume into
+// the state of a nomad_csi_volume. Both resources use the ID of the volume as
+// their ID and share their arguments, except for the type of the volume that
+// could only be "csi".
+func moveExternalVolumeState(state map[string]any) (map[string]any, error) {
+	if t, ok := state["type"].(string); ok && t != "" && t != "csi" {
+		return nil, fmt.Errorf("volumes of type %q can't be moved to nomad_csi_volume", t)
+	}
+	delete(state, "type")
+	return state, nil
+}
+
+// moveVolumeState converts the state of a nomad_volume into the state of a
+// nomad_csi_volume_registration. The deprecated access_mode and
+// attachment_mode attributes are converted into a capability.
+func moveVolumeState(state map[string]any) (map[string]any, error) {
+	if t, ok := state["type"].(string); ok && t != "" && t != "csi" {
+		return nil, fmt.Errorf("volumes of type %q can't be moved to nomad_csi_volume_registration", t)
+	}
+
+	accessMode, _ := state["access_mode"].(string)
+	attachmentMode, _ := state["attachment_mode"].(string)
+	capabilities, _ := state["capability"].([]any)
+	if len(capabilities) == 0 && accessMode != "" && attachmentMode != "" {
+		state["capability"] = []any{
+			map[string]any{
+				"access_mode":     accessMode,
+				"attachment_mode": attachmentMode,
+			},
+		}
+	}
+
+	delete(state, "type")
+	delete(state, "access_mode")
+	delete(state, "attachment_mode")
+	return state, nil
+}
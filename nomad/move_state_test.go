@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProviderServer_moveResourceState(t *testing.T) {
+	s := NewProviderServer(Provider())
+	ctx := context.Background()
+
+	schemaResp, err := s.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !schemaResp.ServerCapabilities.MoveResourceState {
+		t.Fatal("expected the provider to support moving resources")
+	}
+
+	cases := []struct {
+		name       string
+		source     string
+		target     string
+		version    int64
+		state      string
+		capability tftypes.Value
+	}{
+		{
+			name:    "nomad_volume",
+			source:  "nomad_volume",
+			target:  "nomad_csi_volume_registration",
+			version: 1,
+			state: `{
+  "id": "mysql",
+  "type": "csi",
+  "namespace": "prod",
+  "volume_id": "mysql",
+  "name": "mysql",
+  "plugin_id": "aws-ebs0",
+  "external_id": "vol-0123456789",
+  "access_mode": "single-node-writer",
+  "attachment_mode": "file-system",
+  "deregister_on_destroy": true
+}`,
+		},
+		{
+			name:    "nomad_volume v0",
+			source:  "nomad_volume",
+			target:  "nomad_csi_volume_registration",
+			version: 0,
+			state: `{
+  "id": "mysql",
+  "type": "csi",
+  "namespace": "prod",
+  "volume_id": "mysql",
+  "name": "mysql",
+  "plugin_id": "aws-ebs0",
+  "external_id": "vol-0123456789",
+  "access_mode": "single-node-writer",
+  "attachment_mode": "file-system",
+  "deregister_on_destroy": true
+}`,
+		},
+		{
+			name:    "nomad_external_volume",
+			source:  "nomad_external_volume",
+			target:  "nomad_csi_volume",
+			version: 0,
+			state: `{
+  "id": "mysql",
+  "type": "csi",
+  "namespace": "prod",
+  "volume_id": "mysql",
+  "name": "mysql",
+  "plugin_id": "aws-ebs0",
+  "capacity_min": "10GiB",
+  "capability": [
+    {"access_mode": "single-node-writer", "attachment_mode": "file-system"}
+  ]
+}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := s.MoveResourceState(ctx, &tfprotov5.MoveResourceStateRequest{
+				SourceProviderAddress: "registry.terraform.io/hashicorp/nomad",
+				SourceTypeName:        tc.source,
+				SourceSchemaVersion:   tc.version,
+				SourceState:           &tfprotov5.RawState{JSON: []byte(tc.state)},
+				TargetTypeName:        tc.target,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Diagnostics) > 0 {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics[0])
+			}
+
+			val, err := resp.TargetState.Unmarshal(schemaResp.ResourceSchemas[tc.target].ValueType())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var attrs map[string]tftypes.Value
+			if err := val.As(&attrs); err != nil {
+				t.Fatal(err)
+			}
+
+			for name, want := range map[string]string{
+				"id":        "mysql",
+				"namespace": "prod",
+				"volume_id": "mysql",
+				"plugin_id": "aws-ebs0",
+			} {
+				var got string
+				if err := attrs[name].As(&got); err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("expected %s to be %q, got %q", name, want, got)
+				}
+			}
+
+			var capabilities []tftypes.Value
+			if err := attrs["capability"].As(&capabilities); err != nil {
+				t.Fatal(err)
+			}
+			if len(capabilities) != 1 {
+				t.Fatalf("expected 1 capability, got %d", len(capabilities))
+			}
+			var capability map[string]tftypes.Value
+			if err := capabilities[0].As(&capability); err != nil {
+				t.Fatal(err)
+			}
+			var accessMode string
+			if err := capability["access_mode"].As(&accessMode); err != nil {
+				t.Fatal(err)
+			}
+			if accessMode != "single-node-writer" {
+				t.Errorf("expected access_mode to be %q, got %q", "single-node-writer", accessMode)
+			}
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		resp, err := s.MoveResourceState(ctx, &tfprotov5.MoveResourceStateRequest{
+			SourceProviderAddress: "registry.terraform.io/hashicorp/nomad",
+			SourceTypeName:        "nomad_job",
+			SourceState:           &tfprotov5.RawState{JSON: []byte(`{"id": "example"}`)},
+			TargetTypeName:        "nomad_csi_volume",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Diagnostics) == 0 {
+			t.Fatal("expected moving nomad_job to nomad_csi_volume to fail")
+		}
+	})
+}
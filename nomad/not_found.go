@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/hashicorp/nomad/api"
+)
+
+// isNotFoundError returns whether err is returned by the Nomad API because the
+// object requested doesn't exist, for example because it was deleted outside
+// of Terraform.
+//
+// Resources must remove objects that are not found from the state during
+// refresh, either in their Exists or Read function, so Terraform plans to
+// create them again instead of failing.
+func isNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, api.ErrVariablePathNotFound) {
+		return true
+	}
+
+	var respErr api.UnexpectedResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode() == http.StatusNotFound
+	}
+
+	// Some errors are formatted into another error before being returned,
+	// losing their type.
+	m := unexpectedResponseRe.FindStringSubmatch(err.Error())
+	return m != nil && m[1] == "404"
+}
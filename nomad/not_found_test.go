@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/shoenig/test/must"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	must.False(t, isNotFoundError(nil))
+	must.False(t, isNotFoundError(errors.New("Unexpected response code: 403 (job 404 not allowed)")))
+	must.False(t, isNotFoundError(errors.New("error reading job 404")))
+	must.True(t, isNotFoundError(api.ErrVariablePathNotFound))
+	must.True(t, isNotFoundError(fmt.Errorf("error reading variable: %w", api.ErrVariablePathNotFound)))
+	must.True(t, isNotFoundError(fmt.Errorf("error reading job: %s", errors.New("Unexpected response code: 404 (job not found)"))))
+}
+
+// TestResources_deletedOutOfBand checks that the resources whose object was
+// deleted outside of Terraform are removed from the state during refresh.
+func TestResources_deletedOutOfBand(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "object not found")
+	}))
+	defer ts.Close()
+
+	client, err := api.NewClient(&api.Config{Address: ts.URL})
+	must.NoError(t, err)
+	meta := ProviderConfig{client: client, config: &api.Config{Address: ts.URL}}
+
+	resources := []string{
+		"nomad_acl_auth_method",
+		"nomad_acl_binding_rule",
+		"nomad_acl_policy",
+		"nomad_acl_role",
+		"nomad_acl_token",
+		"nomad_csi_volume",
+		"nomad_csi_volume_registration",
+		"nomad_dynamic_host_volume",
+		"nomad_dynamic_host_volume_registration",
+		"nomad_external_volume",
+		"nomad_job",
+		"nomad_namespace",
+		"nomad_node_pool",
+		"nomad_quota_specification",
+		"nomad_sentinel_policy",
+		"nomad_variable",
+		"nomad_volume",
+	}
+
+	provider := Provider()
+	for _, name := range resources {
+		t.Run(name, func(t *testing.T) {
+			r := provider.ResourcesMap[name]
+			state := &terraform.InstanceState{
+				ID: "deleted",
+				Attributes: map[string]string{
+					"id":        "deleted",
+					"name":      "deleted",
+					"namespace": "default",
+					"path":      "deleted/variable",
+				},
+			}
+
+			newState, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+			must.False(t, diags.HasError(), must.Sprintf("%v", diags))
+			if newState != nil {
+				must.Eq(t, "", newState.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// localDataSources are the data sources that can be read without the Nomad
+// API when the provider is offline.
+var localDataSources = map[string]bool{
+	"nomad_job_parser": true,
+}
+
+// isOffline returns whether the provider is configured to not send requests
+// to the Nomad API.
+func isOffline(meta any) bool {
+	config, ok := meta.(ProviderConfig)
+	return ok && config.offline
+}
+
+// withOffline wraps the functions of the resources that require the Nomad API
+// so they fail with an explicit error when the provider is offline, instead
+// of trying to reach a cluster that may not exist.
+//
+// The functions used during plan, like CustomizeDiff, are left as-is and skip
+// the checks that require the Nomad API themselves.
+func withOffline(resources map[string]*schema.Resource, dataSource bool) map[string]*schema.Resource {
+	for typeName, r := range resources {
+		if dataSource && localDataSources[typeName] {
+			continue
+		}
+
+		op := func(action string) apiOperation {
+			return apiOperation{action: action, typeName: typeName, dataSource: dataSource}
+		}
+
+		if r.CreateContext != nil {
+			r.CreateContext = offlineContextFunc(op("create"), r.CreateContext)
+		}
+		if r.ReadContext != nil {
+			r.ReadContext = schema.ReadContextFunc(offlineContextFunc(op("read"), schema.CreateContextFunc(r.ReadContext)))
+		}
+		if r.UpdateContext != nil {
+			r.UpdateContext = schema.UpdateContextFunc(offlineContextFunc(op("update"), schema.CreateContextFunc(r.UpdateContext)))
+		}
+		if r.DeleteContext != nil {
+			r.DeleteContext = schema.DeleteContextFunc(offlineContextFunc(op("delete"), schema.CreateContextFunc(r.DeleteContext)))
+		}
+		if r.Exists != nil {
+			r.Exists = offlineExistsFunc(op("read"), r.Exists)
+		}
+	}
+	return resources
+}
+
+func offlineContextFunc(op apiOperation, f schema.CreateContextFunc) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		if isOffline(meta) {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  offlineError(op).Error(),
+				Detail:   "Run terraform plan with -refresh=false to plan without reading the resources from the Nomad API, or unset the offline argument of the provider.",
+			}}
+		}
+		return f(ctx, d, meta)
+	}
+}
+
+func offlineExistsFunc(op apiOperation, f schema.ExistsFunc) schema.ExistsFunc {
+	return func(d *schema.ResourceData, meta any) (bool, error) {
+		if isOffline(meta) {
+			return false, offlineError(op)
+		}
+		return f(d, meta)
+	}
+}
+
+func offlineError(op apiOperation) error {
+	kind := "resource"
+	if op.dataSource {
+		kind = "data source"
+	}
+	return fmt.Errorf("the provider is offline and can't %s the %s %s", op.action, op.typeName, kind)
+}
+
+// logOfflineSkip logs that a check requiring the Nomad API was skipped since
+// the provider is offline.
+func logOfflineSkip(check string) {
+	log.Printf("[DEBUG] Provider is offline, skipping %s", check)
+}
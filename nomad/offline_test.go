@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shoenig/test/must"
+)
+
+func TestWithOffline(t *testing.T) {
+	calls := 0
+	resources := withOffline(map[string]*schema.Resource{
+		"nomad_namespace": {
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+					ForceNew: true,
+				},
+			},
+			CreateContext: func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+				calls++
+				return nil
+			},
+			ReadContext: func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+				calls++
+				return nil
+			},
+			DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+				calls++
+				return nil
+			},
+		},
+	}, false)
+
+	r := resources["nomad_namespace"]
+	d := r.TestResourceData()
+
+	diags := r.CreateContext(context.Background(), d, ProviderConfig{offline: true})
+	must.Len(t, 1, diags)
+	must.Eq(t, "the provider is offline and can't create the nomad_namespace resource", diags[0].Summary)
+
+	diags = r.ReadContext(context.Background(), d, ProviderConfig{offline: true})
+	must.Len(t, 1, diags)
+	must.Eq(t, "the provider is offline and can't read the nomad_namespace resource", diags[0].Summary)
+	must.Eq(t, 0, calls)
+
+	must.Len(t, 0, r.DeleteContext(context.Background(), d, ProviderConfig{}))
+	must.Eq(t, 1, calls)
+}
+
+func TestDataSourceJobParser_offline(t *testing.T) {
+	r := Provider().DataSourcesMap["nomad_job_parser"]
+	d := r.TestResourceData()
+	must.NoError(t, d.Set("hcl", `
+job "example" {
+  group "cache" {
+    task "redis" {
+      driver = "docker"
+    }
+  }
+}
+`))
+	must.NoError(t, d.Set("canonicalize", true))
+
+	// The address is not reachable, the jobspec must be parsed locally.
+	client, err := api.NewClient(&api.Config{Address: "http://127.0.0.1:0"})
+	must.NoError(t, err)
+	meta := ProviderConfig{client: client, config: &api.Config{}, offline: true}
+
+	diags := r.ReadContext(context.Background(), d, meta)
+	must.Len(t, 0, diags)
+	must.Eq(t, "example", d.Id())
+
+	var job api.Job
+	must.NoError(t, json.Unmarshal([]byte(d.Get("json").(string)), &job))
+	must.Eq(t, "example", *job.ID)
+	must.Eq(t, api.DefaultNamespace, *job.Namespace)
+	must.Eq(t, "docker", job.TaskGroups[0].Tasks[0].Driver)
+}
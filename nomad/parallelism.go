@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"log"
+	"net/http"
+)
+
+// mutationLimiter bounds the number of requests modifying the cluster that
+// are sent at the same time. The zero value doesn't limit requests.
+type mutationLimiter struct {
+	slots chan struct{}
+}
+
+func newMutationLimiter(parallelism int) mutationLimiter {
+	if parallelism <= 0 {
+		return mutationLimiter{}
+	}
+	return mutationLimiter{slots: make(chan struct{}, parallelism)}
+}
+
+// acquire blocks until a request can be sent, or ctx is done. The returned
+// function must be called once the request is done.
+func (l mutationLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+	default:
+		log.Printf("[DEBUG] Waiting for one of the %d concurrent Nomad API writes allowed by parallelism to finish", cap(l.slots))
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return func() { <-l.slots }, nil
+}
+
+// withMutationLimit returns httpClient with a transport that sends no more
+// than the parallelism provider argument of requests modifying the cluster at
+// the same time. Only the requests themselves are limited, so an operation
+// waiting for its change to complete, like a job waiting for its deployment,
+// doesn't hold back the other operations. The client is returned as-is when
+// the requests are not limited.
+func withMutationLimit(httpClient *http.Client, limiter mutationLimiter) *http.Client {
+	if httpClient == nil || limiter.slots == nil {
+		return httpClient
+	}
+	httpClient.Transport = &mutationLimitTransport{
+		base:    httpClient.Transport,
+		limiter: limiter,
+	}
+	return httpClient
+}
+
+// mutationLimitTransport waits for a slot of its limiter before sending the
+// requests that are not reads.
+type mutationLimitTransport struct {
+	base    http.RoundTripper
+	limiter mutationLimiter
+}
+
+func (t *mutationLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return t.base.RoundTrip(req)
+	}
+
+	release, err := t.limiter.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+)
+
+func TestMutationLimitTransport(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	conf := &api.Config{Address: srv.URL, TLSConfig: &api.TLSConfig{}}
+	httpClient, err := newHttpClient(conf)
+	must.NoError(t, err)
+	conf.HttpClient = withMutationLimit(httpClient, newMutationLimiter(2))
+	client, err := api.NewClient(conf)
+	must.NoError(t, err)
+
+	send := func(f func()) {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				f()
+			}()
+		}
+		wg.Wait()
+	}
+
+	send(func() {
+		_, err := client.Namespaces().Register(&api.Namespace{Name: "ops"}, nil)
+		must.NoError(t, err)
+	})
+	must.Eq(t, 2, maxRunning.Load())
+
+	// Reads are not limited.
+	maxRunning.Store(0)
+	send(func() {
+		_, _, err := client.Namespaces().Info("ops", nil)
+		must.NoError(t, err)
+	})
+	must.Greater(t, 2, maxRunning.Load())
+}
+
+func TestMutationLimiter_canceled(t *testing.T) {
+	l := newMutationLimiter(1)
+	release, err := l.acquire(context.Background())
+	must.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = l.acquire(ctx)
+	must.ErrorIs(t, err, context.Canceled)
+
+	// Requests are not limited once the slot is released, or when
+	// parallelism is not set.
+	release()
+	release, err = l.acquire(context.Background())
+	must.NoError(t, err)
+	release()
+	_, err = newMutationLimiter(0).acquire(ctx)
+	must.NoError(t, err)
+}
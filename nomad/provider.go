@@ -4,8 +4,10 @@
 package nomad
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"strings"
@@ -13,12 +15,56 @@ import (
 
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type ProviderConfig struct {
 	client *api.Client
 	config *api.Config
+
+	// transport is the HTTP transport of client. The clients of the other
+	// regions and query options send their requests with it so they share its
+	// connections. It is nil for unix socket addresses.
+	transport http.RoundTripper
+
+	// maxRetries is the number of times requests that fail with a transient
+	// error, or that are rate limited, are retried.
+	maxRetries int
+
+	// offline is set when the provider must not send requests to the Nomad
+	// API.
+	offline bool
+
+	// mutations limits the number of requests modifying the cluster that are
+	// sent at the same time, by the clients of all the regions.
+	mutations mutationLimiter
+
+	// version is the version of the Nomad agent, detected when it's first
+	// needed.
+	version *agentVersion
+
+	// regionClients are the clients used by the resources and data sources
+	// whose region is different from the region of the provider.
+	regionClients *regionClients
+
+	// allowStale is whether the data sources read from any Nomad server by
+	// default, instead of only the leader.
+	allowStale bool
+
+	// queryClients are the clients used by the data sources whose query
+	// options are different from the defaults of the provider.
+	queryClients *queryClients
+
+	// refreshIndexes are the modify indexes of the objects listed to skip
+	// the refresh of the resources that didn't change. It is nil when
+	// refresh_by_index is false.
+	refreshIndexes *refreshIndexes
+
+	// poll controls how often the waiters of the resources query the objects
+	// they wait for.
+	poll pollConfig
 }
 
 func Provider() *schema.Provider {
@@ -112,6 +158,67 @@ func Provider() *schema.Provider {
 				Description: "A set of environment variables that are ignored by the provider when configuring the Nomad API client.",
 				Elem:        &schema.Schema{Type: schema.TypeBool},
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of times requests that fail with transient errors, such as when the cluster has no leader, and requests rate limited with a 429 response are retried. Set to 0 to disable retries.",
+			},
+			"allow_stale": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the data sources read from any Nomad server instead of only the leader by default, which is faster but may return stale results. Data sources can override it with their own allow_stale argument.",
+			},
+			"refresh_by_index": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to list the modify indexes of the ACL policies and roles, namespaces, node pools, quota specifications, Sentinel policies and variables in a single request on refresh, and skip reading the objects that didn't change.",
+			},
+			"poll": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Controls how often the resources waiting for an object, like nomad_job monitoring its deployment, query it.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"interval": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "0s",
+							Description: "The minimum time between two queries of an object. Defaults to querying the object again as soon as it changes.",
+						},
+						"max_interval": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "1m",
+							Description: "The maximum time between two queries of an object when backoff is greater than 1.",
+						},
+						"backoff": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      1.0,
+							ValidateFunc: validation.FloatAtLeast(1),
+							Description:  "The factor the interval is multiplied by after each query, up to max_interval.",
+						},
+					},
+				},
+			},
+			"offline": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NOMAD_OFFLINE", false),
+				Description: "Don't send requests to the Nomad API. Jobspecs and arguments are still validated during plan, but the resources and data sources that require the Nomad API can't be read or applied.",
+			},
+			"parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests modifying the cluster that the provider sends at the same time. Set to 0 to not limit them.",
+			},
 			"skip_verify": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -120,59 +227,119 @@ func Provider() *schema.Provider {
 			},
 		},
 
-		ConfigureFunc: providerConfigure,
+		ConfigureProvider: configureProvider,
 
-		DataSourcesMap: map[string]*schema.Resource{
+		DataSourcesMap: withRegion(withQueryOptions(withTracing(withOffline(withVersionRequirements(withAPIErrorDiagnostics(withContextFuncs(map[string]*schema.Resource{
 			"nomad_acl_policies":        dataSourceAclPolicies(),
 			"nomad_acl_policy":          dataSourceAclPolicy(),
 			"nomad_acl_role":            dataSourceACLRole(),
 			"nomad_acl_roles":           dataSourceACLRoles(),
 			"nomad_acl_token":           dataSourceACLToken(),
+			"nomad_acl_token_self":      dataSourceACLTokenSelf(),
 			"nomad_acl_tokens":          dataSourceACLTokens(),
 			"nomad_allocations":         dataSourceAllocations(),
+			"nomad_cluster_readiness":   dataSourceClusterReadiness(),
 			"nomad_datacenters":         dataSourceDatacenters(),
 			"nomad_deployments":         dataSourceDeployments(),
 			"nomad_dynamic_host_volume": dataSourceDynamicHostVolume(),
+			"nomad_events":              dataSourceEvents(),
 			"nomad_job":                 dataSourceJob(),
 			"nomad_job_parser":          dataSourceJobParser(),
 			"nomad_jwks":                dataSourceJWKS(),
 			"nomad_namespace":           dataSourceNamespace(),
 			"nomad_namespaces":          dataSourceNamespaces(),
+			"nomad_node_allocations":    dataSourceNodeAllocations(),
 			"nomad_node_pool":           dataSourceNodePool(),
 			"nomad_node_pools":          dataSourceNodePools(),
+			"nomad_oidc_discovery":      dataSourceOIDCDiscovery(),
 			"nomad_plugin":              dataSourcePlugin(),
 			"nomad_plugins":             dataSourcePlugins(),
+			"nomad_quota_usage":         dataSourceQuotaUsage(),
 			"nomad_scaling_policies":    dataSourceScalingPolicies(),
 			"nomad_scaling_policy":      dataSourceScalingPolicy(),
 			"nomad_scheduler_config":    dataSourceSchedulerConfig(),
+			"nomad_sentinel_policy":     dataSourceSentinelPolicy(),
 			"nomad_regions":             dataSourceRegions(),
+			"nomad_topology":            dataSourceTopology(),
 			"nomad_volumes":             dataSourceVolumes(),
 			"nomad_variable":            dataSourceVariable(),
-		},
+		}), true), true), true), true)), true),
 
-		ResourcesMap: map[string]*schema.Resource{
+		ResourcesMap: withRegion(withTracing(withOffline(withVersionRequirements(withAPIErrorDiagnostics(withContextFuncs(withIndexedRefresh(withIndexes(withDestroyArguments(map[string]*schema.Resource{
 			"nomad_acl_auth_method":                  resourceACLAuthMethod(),
 			"nomad_acl_binding_rule":                 resourceACLBindingRule(),
 			"nomad_acl_policy":                       resourceACLPolicy(),
 			"nomad_acl_role":                         resourceACLRole(),
 			"nomad_acl_token":                        resourceACLToken(),
+			"nomad_alloc_exec":                       resourceAllocExec(),
+			"nomad_alloc_signal_restart":             resourceAllocSignalRestart(),
 			"nomad_csi_volume":                       resourceCSIVolume(),
 			"nomad_csi_volume_registration":          resourceCSIVolumeRegistration(),
+			"nomad_deployment_control":               resourceDeploymentControl(),
+			"nomad_deployment_promote":               resourceDeploymentPromote(),
 			"nomad_dynamic_host_volume":              resourceDynamicHostVolume(),
 			"nomad_dynamic_host_volume_registration": resourceDynamicHostVolumeRegistration(),
 			"nomad_external_volume":                  resourceExternalVolume(),
 			"nomad_job":                              resourceJob(),
+			"nomad_job_eval":                         resourceJobEval(),
 			"nomad_namespace":                        resourceNamespace(),
 			"nomad_node_pool":                        resourceNodePool(),
+			"nomad_node_purge":                       resourceNodePurge(),
 			"nomad_quota_specification":              resourceQuotaSpecification(),
+			"nomad_recommendation_action":            resourceRecommendationAction(),
+			"nomad_root_key_rotation":                resourceRootKeyRotation(),
 			"nomad_sentinel_policy":                  resourceSentinelPolicy(),
+			"nomad_system_gc":                        resourceSystemGC(),
 			"nomad_volume":                           resourceVolume(),
 			"nomad_scheduler_config":                 resourceSchedulerConfig(),
 			"nomad_variable":                         resourceVariable(),
-		},
+			"nomad_variable_tree":                    resourceVariableTree(),
+		})))), false), false), false), false), false),
+	}
+}
+
+// ephemeralResources returns the ephemeral resources served along with the
+// provider by NewProviderServer.
+func ephemeralResources() map[string]*ephemeralResource {
+	return map[string]*ephemeralResource{
+		"nomad_acl_token": ephemeralACLToken(),
+		"nomad_variable":  ephemeralVariable(),
+	}
+}
+
+// providerFunctions returns the functions served along with the provider by
+// NewProviderServer.
+func providerFunctions() map[string]*providerFunction {
+	return map[string]*providerFunction{
+		"format_jobspec":      functionFormatJobspec(),
+		"jobspec_to_json":     functionJobspecToJSON(),
+		"render_jobspec":      functionRenderJobspec(),
+		"validate_acl_policy": functionValidateACLPolicy(),
 	}
 }
 
+// configureProvider defers all the resources and data sources when the
+// configuration of the provider is not known yet, for example when the
+// address of the cluster is the output of a resource that is created in the
+// same configuration, so Terraform can apply the other changes first.
+// Otherwise the provider is configured by providerConfigure.
+func configureProvider(_ context.Context, req schema.ConfigureProviderRequest, resp *schema.ConfigureProviderResponse) {
+	if req.DeferralAllowed && !req.ResourceData.GetRawConfig().IsWhollyKnown() {
+		log.Printf("[DEBUG] Deferring the resources and data sources since the provider configuration is unknown")
+		resp.Deferred = &schema.Deferred{
+			Reason: schema.DeferredReasonProviderConfigUnknown,
+		}
+		return
+	}
+
+	meta, err := providerConfigure(req.ResourceData)
+	if err != nil {
+		resp.Diagnostics = diag.FromErr(err)
+		return
+	}
+	resp.Meta = meta
+}
+
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	ignoreEnvVars := d.Get("ignore_env_vars").(map[string]interface{})
 	if len(ignoreEnvVars) == 0 {
@@ -187,8 +354,13 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		}
 	}
 
+	address, err := normalizeAddress(d.Get("address").(string))
+	if err != nil {
+		return nil, err
+	}
+
 	conf := api.DefaultConfig()
-	conf.Address = d.Get("address").(string)
+	conf.Address = address
 	conf.SecretID = d.Get("secret_id").(string)
 
 	if region, ok := d.GetOk("region"); ok {
@@ -228,13 +400,17 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	conf.TLSConfig.ClientKeyPEM = []byte(d.Get("key_pem").(string))
 	conf.TLSConfig.Insecure = d.Get("skip_verify").(bool)
 
-	if _, ok := os.LookupEnv("TF_ACC"); ok {
-		// Revert the Nomad API client to non-pooled to avoid EOF errors when
-		// running the test suite since it instantiates the provider multiple
-		// times, creating several clients in parallel.
-		// https://github.com/hashicorp/nomad/pull/12492
-		conf.HttpClient = nonPooledHttpClient()
+	maxRetries := d.Get("max_retries").(int)
+	mutations := newMutationLimiter(d.Get("parallelism").(int))
+	httpClient, err := newHttpClient(conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure Nomad API: %s", err)
+	}
+	var transport http.RoundTripper
+	if httpClient != nil {
+		transport = httpClient.Transport
 	}
+	conf.HttpClient = withTransientRetries(withMutationLimit(httpClient, mutations), maxRetries)
 
 	// Set headers if provided
 	headers := d.Get("headers").([]interface{})
@@ -254,15 +430,76 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	}
 
 	res := ProviderConfig{
-		config: conf,
-		client: client,
+		config:     conf,
+		client:     client,
+		transport:  transport,
+		maxRetries: maxRetries,
+		offline:    d.Get("offline").(bool),
+		mutations:  mutations,
+		version:    &agentVersion{},
+
+		regionClients: &regionClients{},
+		allowStale:    d.Get("allow_stale").(bool),
+		queryClients:  &queryClients{},
+	}
+	if res.poll, err = expandPollConfig(d.Get("poll").([]any)); err != nil {
+		return nil, err
+	}
+	if d.Get("refresh_by_index").(bool) {
+		res.refreshIndexes = &refreshIndexes{}
 	}
 
 	return res, nil
 }
 
+// newHttpClient returns the HTTP client of the Nomad API client configured by
+// conf, with its TLS settings. It returns nil for unix socket addresses, whose
+// HTTP client is created by the Nomad API.
+func newHttpClient(conf *api.Config) (*http.Client, error) {
+	if strings.HasPrefix(conf.Address, "unix://") {
+		return nil, nil
+	}
+
+	if _, ok := os.LookupEnv("TF_ACC"); ok {
+		// Revert the Nomad API client to non-pooled to avoid EOF errors when
+		// running the test suite since it instantiates the provider multiple
+		// times, creating several clients in parallel.
+		// https://github.com/hashicorp/nomad/pull/12492
+		httpClient := nonPooledHttpClient()
+		if err := api.ConfigureTLS(httpClient, conf.TLSConfig); err != nil {
+			return nil, err
+		}
+		return withTracingTransport(httpClient), nil
+	}
+
+	httpClient := pooledHttpClient()
+	if err := api.ConfigureTLS(httpClient, conf.TLSConfig); err != nil {
+		return nil, err
+	}
+	return withTracingTransport(httpClient), nil
+}
+
+// httpClient returns an HTTP client for conf, a copy of the configuration of
+// the provider for another region or other query options, that sends its
+// requests with the transport of the client of the provider.
+func (c ProviderConfig) httpClient(conf *api.Config) (*http.Client, error) {
+	if c.transport == nil {
+		return newHttpClient(conf)
+	}
+	return &http.Client{Transport: c.transport}, nil
+}
+
 func nonPooledHttpClient() *http.Client {
-	httpClient := cleanhttp.DefaultClient()
+	return defaultHttpClient(cleanhttp.DefaultClient())
+}
+
+// pooledHttpClient returns an HTTP client configured like the default client
+// of the Nomad API.
+func pooledHttpClient() *http.Client {
+	return defaultHttpClient(cleanhttp.DefaultPooledClient())
+}
+
+func defaultHttpClient(httpClient *http.Client) *http.Client {
 	transport := httpClient.Transport.(*http.Transport)
 	transport.TLSHandshakeTimeout = 10 * time.Second
 	transport.TLSClientConfig = &tls.Config{
@@ -4,15 +4,19 @@
 package nomad
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -103,6 +107,14 @@ func testCheckEnt(t *testing.T) {
 	}
 }
 
+func testCheckCE(t *testing.T) {
+	t.Helper()
+	v := testGetVersion(t)
+	if v.Metadata() == "ent" {
+		t.Skipf("node version %q is an enterprise build", v.String())
+	}
+}
+
 func testGetVersion(t *testing.T) *version.Version {
 	t.Helper()
 	client := testProvider.Meta().(ProviderConfig).client
@@ -159,6 +171,24 @@ func testCheckCSIPluginAvailable(t *testing.T, pluginID string) {
 	}
 }
 
+func TestProvider_namespaceDefaults(t *testing.T) {
+	for name, r := range Provider().ResourcesMap {
+		ns, ok := r.Schema["namespace"]
+		if !ok || ns.Computed {
+			continue
+		}
+
+		// Objects are stored in the default namespace when the namespace is
+		// not set, and moving them to another namespace replaces them.
+		if ns.Default != "default" || !ns.ForceNew || ns.DiffSuppressFunc == nil {
+			t.Errorf("%s: expected namespace to default to \"default\" and force a new resource", name)
+		}
+		if !ns.DiffSuppressFunc("namespace", "default", "", nil) {
+			t.Errorf("%s: expected an empty namespace to be the default namespace", name)
+		}
+	}
+}
+
 func TestAccNomadProvider_namespace(t *testing.T) {
 	defer func() {
 		os.Unsetenv("NOMAD_NAMESPACE")
@@ -546,3 +576,155 @@ func testAccProviderFactoryInternal(provider **schema.Provider) map[string]func(
 	}
 	return factories
 }
+
+func TestProviderServer_deferredConfig(t *testing.T) {
+	s := NewProviderServer(Provider())
+	ctx := context.Background()
+
+	schemaResp, err := s.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The address of the cluster is not known yet.
+	configResp, err := s.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{
+		Config: testObjectValue(t, schemaResp.Provider.ValueType(), map[string]tftypes.Value{
+			"address": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		}),
+		ClientCapabilities: &tfprotov5.ConfigureProviderClientCapabilities{
+			DeferralAllowed: true,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", configResp.Diagnostics[0])
+	}
+
+	readResp, err := s.ReadDataSource(ctx, &tfprotov5.ReadDataSourceRequest{
+		TypeName: "nomad_namespaces",
+		Config:   testObjectValue(t, schemaResp.DataSourceSchemas["nomad_namespaces"].ValueType(), nil),
+		ClientCapabilities: &tfprotov5.ReadDataSourceClientCapabilities{
+			DeferralAllowed: true,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if readResp.Deferred == nil || readResp.Deferred.Reason != tfprotov5.DeferredReasonProviderConfigUnknown {
+		t.Fatalf("expected nomad_namespaces to be deferred, got %#v", readResp.Deferred)
+	}
+
+	openResp, err := s.OpenEphemeralResource(ctx, &tfprotov5.OpenEphemeralResourceRequest{
+		TypeName: "nomad_variable",
+		Config:   testObjectValue(t, schemaResp.EphemeralResourceSchemas["nomad_variable"].ValueType(), nil),
+		ClientCapabilities: &tfprotov5.OpenEphemeralResourceClientCapabilities{
+			DeferralAllowed: true,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if openResp.Deferred == nil || openResp.Deferred.Reason != tfprotov5.DeferredReasonProviderConfigUnknown {
+		t.Fatalf("expected nomad_variable to be deferred, got %#v", openResp.Deferred)
+	}
+}
+
+// testObjectValue encodes an object of type typ, setting any attribute that
+// isn't in values to null.
+func testObjectValue(t *testing.T, typ tftypes.Type, values map[string]tftypes.Value) *tfprotov5.DynamicValue {
+	t.Helper()
+
+	objType := typ.(tftypes.Object)
+	attrs := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+	for name, attrType := range objType.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+			continue
+		}
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	value, err := tfprotov5.NewDynamicValue(objType, tftypes.NewValue(objType, attrs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &value
+}
+
+func TestProviderConfigure_gzipResponses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("expected gzip to be accepted, got %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("X-Nomad-Index", "1")
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		fmt.Fprint(gz, `[{"Name":"default"}]`)
+	}))
+	defer ts.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]any{
+		"address": ts.URL,
+	})
+	meta, err := providerConfigure(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	namespaces, _, err := meta.(ProviderConfig).client.Namespaces().List(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(namespaces) != 1 || namespaces[0].Name != "default" {
+		t.Fatalf("unexpected namespaces: %#v", namespaces)
+	}
+}
+
+func TestProviderConfigure_sharedTransport(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]any{
+		"address": "https://nomad.example.com:4646",
+	})
+	meta, err := providerConfigure(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := meta.(ProviderConfig)
+	if config.transport == nil {
+		t.Fatal("expected the transport of the provider to be set")
+	}
+
+	region, err := config.forRegion("eu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := config.queryOptionsHttpClient(config.config, queryOptions{allowStale: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The clients of the other regions and query options reuse the
+	// connections of the client of the provider.
+	for name, httpClient := range map[string]*http.Client{"region": region.config.HttpClient, "query options": query} {
+		transport := httpClient.Transport
+		for unwrapped := false; !unwrapped; {
+			switch t := transport.(type) {
+			case *rateLimitTransport:
+				transport = t.base
+			case *transientRetryTransport:
+				transport = t.base
+			case *mutationLimitTransport:
+				transport = t.base
+			case *queryOptionsTransport:
+				transport = t.base
+			default:
+				unwrapped = true
+			}
+		}
+		if transport != config.transport {
+			t.Errorf("expected the %s client to use the transport of the provider", name)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// queryOptionsExcludedDataSources are the data sources that don't get the
+// query options arguments, either because they don't read from the Nomad API
+// or because they already set them from their own arguments.
+var queryOptionsExcludedDataSources = map[string]bool{
+	"nomad_events":     true,
+	"nomad_job_parser": true,
+}
+
+// queryOptions are the options of the queries sent by a data source.
+type queryOptions struct {
+	allowStale bool
+	waitIndex  uint64
+	waitTime   time.Duration
+}
+
+func (o queryOptions) isZero() bool {
+	return o == queryOptions{}
+}
+
+// queryClients caches the Nomad API clients used by the data sources whose
+// query options are different from the defaults of the provider.
+type queryClients struct {
+	lock    sync.Mutex
+	clients map[queryClientKey]*api.Client
+}
+
+type queryClientKey struct {
+	region  string
+	options queryOptions
+}
+
+// withQueryOptions adds the allow_stale, wait_index and wait_time arguments
+// to the data sources and wraps their read function so the queries they send
+// use them instead of the defaults of the provider.
+func withQueryOptions(dataSources map[string]*schema.Resource) map[string]*schema.Resource {
+	for typeName, r := range dataSources {
+		if queryOptionsExcludedDataSources[typeName] || r.ReadContext == nil {
+			continue
+		}
+
+		r.Schema["allow_stale"] = &schema.Schema{
+			Description: "Whether any Nomad server can answer the queries of the data source instead of only the leader, which is faster but may return stale results. Defaults to the allow_stale argument of the provider.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+		}
+		r.Schema["wait_index"] = &schema.Schema{
+			Description:  "Makes the queries of the data source blocking queries that only return once the Nomad index of the results is greater than this value, or after wait_time.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		}
+		r.Schema["wait_time"] = &schema.Schema{
+			Description: "The maximum duration blocking queries wait for, like \"30s\". Defaults to 5 minutes on the Nomad servers.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		}
+
+		r.ReadContext = queryOptionsReadContextFunc(r.ReadContext)
+	}
+	return dataSources
+}
+
+func queryOptionsReadContextFunc(f schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		config, ok := meta.(ProviderConfig)
+		if !ok {
+			return f(ctx, d, meta)
+		}
+
+		opts, err := getQueryOptions(d, config.allowStale)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		config, err = config.withQueryOptions(opts)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, config)
+	}
+}
+
+// getQueryOptions returns the query options set in the configuration of the
+// data source, allow_stale defaulting to the value set in the provider.
+func getQueryOptions(d *schema.ResourceData, allowStale bool) (queryOptions, error) {
+	opts := queryOptions{allowStale: allowStale}
+
+	if raw := d.GetRawConfig(); raw.IsKnown() && !raw.IsNull() {
+		if v := raw.GetAttr("allow_stale"); v.IsKnown() && !v.IsNull() {
+			opts.allowStale = v.True()
+		}
+	}
+	if v, ok := d.Get("wait_index").(int); ok {
+		opts.waitIndex = uint64(v)
+	}
+	if v, _ := d.Get("wait_time").(string); v != "" {
+		wait, err := time.ParseDuration(v)
+		if err != nil {
+			return opts, fmt.Errorf("failed to parse wait_time: %v", err)
+		}
+		opts.waitTime = wait
+	}
+	return opts, nil
+}
+
+// withQueryOptions returns a copy of the provider configuration whose client
+// sends its queries with opts. The configuration is returned as-is when opts
+// are the defaults of the Nomad API.
+func (c ProviderConfig) withQueryOptions(opts queryOptions) (ProviderConfig, error) {
+	if opts.isZero() || c.config == nil {
+		return c, nil
+	}
+
+	if c.queryClients == nil {
+		c.queryClients = &queryClients{}
+	}
+	c.queryClients.lock.Lock()
+	defer c.queryClients.lock.Unlock()
+
+	key := queryClientKey{region: c.config.Region, options: opts}
+	client, ok := c.queryClients.clients[key]
+	if !ok {
+		conf := *c.config
+		conf.TLSConfig = c.config.TLSConfig.Copy()
+
+		httpClient, err := c.queryOptionsHttpClient(&conf, opts)
+		if err != nil {
+			return c, fmt.Errorf("failed to configure Nomad API: %s", err)
+		}
+		conf.HttpClient = httpClient
+
+		client, err = api.NewClient(&conf)
+		if err != nil {
+			return c, fmt.Errorf("failed to configure Nomad API: %s", err)
+		}
+
+		if c.queryClients.clients == nil {
+			c.queryClients.clients = make(map[queryClientKey]*api.Client)
+		}
+		c.queryClients.clients[key] = client
+	}
+
+	c.client = client
+	return c, nil
+}
+
+// queryOptionsHttpClient returns an HTTP client for conf that adds opts to the
+// queries it sends and retries them when they are rate limited.
+func (c ProviderConfig) queryOptionsHttpClient(conf *api.Config, opts queryOptions) (*http.Client, error) {
+	if strings.HasPrefix(conf.Address, "unix://") {
+		return nil, fmt.Errorf("allow_stale, wait_index and wait_time are not supported with a unix socket address")
+	}
+
+	httpClient, err := c.httpClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Transport = &queryOptionsTransport{
+		base:    httpClient.Transport,
+		options: opts,
+	}
+	return withTransientRetries(httpClient, c.maxRetries), nil
+}
+
+// queryOptionsTransport adds the query options to the GET requests it sends,
+// unless they are already set by the caller.
+type queryOptionsTransport struct {
+	base    http.RoundTripper
+	options queryOptions
+}
+
+func (t *queryOptionsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	query := req.URL.Query()
+	set := func(key, value string) {
+		if !query.Has(key) {
+			query.Set(key, value)
+		}
+	}
+	if t.options.allowStale {
+		set("stale", "")
+	}
+	if t.options.waitIndex != 0 {
+		set("index", strconv.FormatUint(t.options.waitIndex, 10))
+	}
+	if t.options.waitTime != 0 {
+		set("wait", fmt.Sprintf("%dms", t.options.waitTime.Milliseconds()))
+	}
+
+	// The request must not be modified by the transport.
+	req = req.Clone(req.Context())
+	req.URL.RawQuery = query.Encode()
+	return t.base.RoundTrip(req)
+}
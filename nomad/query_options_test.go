@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shoenig/test/must"
+)
+
+func TestWithQueryOptions(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query = req.URL.Query()
+		json.NewEncoder(w).Encode([]*api.AllocationListStub{})
+	}))
+	defer srv.Close()
+
+	conf := &api.Config{Address: srv.URL, TLSConfig: &api.TLSConfig{}}
+	client, err := api.NewClient(conf)
+	must.NoError(t, err)
+	meta := ProviderConfig{client: client, config: conf, queryClients: &queryClients{}}
+
+	r := withQueryOptions(map[string]*schema.Resource{
+		"nomad_allocations": dataSourceAllocations(),
+	})["nomad_allocations"]
+
+	// The queries are sent as-is by default.
+	d := r.TestResourceData()
+	diags := r.ReadContext(context.Background(), d, meta)
+	must.SliceEmpty(t, diags)
+	must.False(t, query.Has("stale"))
+	must.False(t, query.Has("index"))
+	must.False(t, query.Has("wait"))
+
+	must.NoError(t, d.Set("wait_index", 42))
+	must.NoError(t, d.Set("wait_time", "10s"))
+	diags = r.ReadContext(context.Background(), d, meta)
+	must.SliceEmpty(t, diags)
+	must.False(t, query.Has("stale"))
+	must.Eq(t, "42", query.Get("index"))
+	must.Eq(t, "10000ms", query.Get("wait"))
+
+	// allow_stale defaults to the value set in the provider.
+	meta.allowStale = true
+	d = r.TestResourceData()
+	diags = r.ReadContext(context.Background(), d, meta)
+	must.SliceEmpty(t, diags)
+	must.True(t, query.Has("stale"))
+	must.False(t, query.Has("index"))
+
+	must.NoError(t, d.Set("wait_time", "1m"))
+	diags = r.ReadContext(context.Background(), d, meta)
+	must.SliceEmpty(t, diags)
+	must.Eq(t, "60000ms", query.Get("wait"))
+
+	must.NoError(t, d.Set("wait_time", "soon"))
+	diags = r.ReadContext(context.Background(), d, meta)
+	must.True(t, diags.HasError())
+	must.StrContains(t, diags[0].Summary, "failed to parse wait_time")
+}
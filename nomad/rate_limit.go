@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+)
+
+// withRateLimitRetries returns httpClient with a transport that retries the
+// requests rejected with a 429 response, by Nomad or by a proxy in front of
+// it, up to maxRetries times. The client is returned as-is when maxRetries is
+// 0.
+func withRateLimitRetries(httpClient *http.Client, maxRetries int) *http.Client {
+	if httpClient == nil || maxRetries <= 0 {
+		return httpClient
+	}
+	httpClient.Transport = &rateLimitTransport{
+		base:       httpClient.Transport,
+		maxRetries: maxRetries,
+	}
+	return httpClient
+}
+
+// rateLimitTransport retries the requests rejected with a 429 response. Those
+// requests were not processed, so all of them can be sent again, waiting for
+// the duration set in the Retry-After header of the response or, when it's
+// missing, for a jittered exponential backoff so the clients rate limited
+// together don't retry together.
+type rateLimitTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= t.maxRetries {
+			return resp, err
+		}
+
+		wait, ok := retryAfter(resp.Header.Get("Retry-After"))
+		if !ok {
+			wait = retryJitter(retryBackoff(attempt))
+		}
+		if wait > retryWaitMax {
+			log.Printf("[WARN] Request %s %s was rate limited for %s, not retrying it", req.Method, req.URL.Path, wait)
+			return resp, nil
+		}
+
+		retry, ok := rewindRequest(req)
+		if !ok {
+			return resp, nil
+		}
+
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		log.Printf("[WARN] Request %s %s was rate limited, retrying in %s", req.Method, req.URL.Path, wait)
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		req = retry
+	}
+}
+
+// rewindRequest returns a request to send req again. The body must be sent
+// again, requests whose body can't be rewound can't be retried.
+func rewindRequest(req *http.Request) (*http.Request, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	retry := req.Clone(req.Context())
+	retry.Body = body
+	return retry, true
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(v); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// retryJitter returns a random duration between half of wait and wait.
+func retryJitter(wait time.Duration) time.Duration {
+	if wait/2 <= 0 {
+		return wait
+	}
+	return wait/2 + rand.N(wait/2)
+}
+
+// withoutRateLimitRetries returns a client like client whose requests are not
+// retried by a rateLimitTransport or a transientRetryTransport, limited by a
+// mutationLimitTransport, nor recorded by a tracingTransport, for the
+// functions of the Nomad API that require the transport of the HTTP client to
+// be an *http.Transport, like the websockets of alloc exec.
+func withoutRateLimitRetries(client *api.Client, conf *api.Config) (*api.Client, error) {
+	if conf == nil || conf.HttpClient == nil {
+		return client, nil
+	}
+
+	transport := conf.HttpClient.Transport
+	for unwrapped := false; !unwrapped; {
+		switch t := transport.(type) {
+		case *rateLimitTransport:
+			transport = t.base
+		case *transientRetryTransport:
+			transport = t.base
+		case *mutationLimitTransport:
+			transport = t.base
+		case *tracingTransport:
+			transport = t.base
+		default:
+			unwrapped = true
+		}
+	}
+	if transport == conf.HttpClient.Transport {
+		return client, nil
+	}
+
+	c := *conf
+	httpClient := *conf.HttpClient
+	httpClient.Transport = transport
+	c.HttpClient = &httpClient
+
+	client, err := api.NewClient(&c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure Nomad API: %s", err)
+	}
+	return client, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+)
+
+func TestRateLimitTransport(t *testing.T) {
+	var requests, limited atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		body, _ := io.ReadAll(req.Body)
+		if req.Method == http.MethodPut && string(body) == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if limited.Add(-1) >= 0 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	newClient := func(maxRetries int) *api.Client {
+		conf := &api.Config{Address: srv.URL, TLSConfig: &api.TLSConfig{}}
+		httpClient, err := newHttpClient(conf)
+		must.NoError(t, err)
+		conf.HttpClient = withRateLimitRetries(httpClient, maxRetries)
+		client, err := api.NewClient(conf)
+		must.NoError(t, err)
+		return client
+	}
+
+	// The requests are retried until they are not rate limited anymore.
+	limited.Store(2)
+	_, _, err := newClient(5).Namespaces().Info("default", nil)
+	must.NoError(t, err)
+	must.Eq(t, 3, requests.Load())
+
+	// The body of the requests is sent again.
+	requests.Store(0)
+	limited.Store(1)
+	_, err = newClient(5).Namespaces().Register(&api.Namespace{Name: "ops"}, nil)
+	must.NoError(t, err)
+	must.Eq(t, 2, requests.Load())
+
+	// The response is returned once all the retries are used.
+	requests.Store(0)
+	limited.Store(10)
+	_, _, err = newClient(2).Namespaces().Info("default", nil)
+	must.ErrorContains(t, err, "Unexpected response code: 429")
+	must.Eq(t, 3, requests.Load())
+
+	// Nothing is retried when the retries are disabled.
+	requests.Store(0)
+	limited.Store(10)
+	_, _, err = newClient(0).Namespaces().Info("default", nil)
+	must.ErrorContains(t, err, "Unexpected response code: 429")
+	must.Eq(t, 1, requests.Load())
+}
+
+func TestRetryAfter(t *testing.T) {
+	wait, ok := retryAfter("")
+	must.False(t, ok)
+
+	wait, ok = retryAfter("12")
+	must.True(t, ok)
+	must.Eq(t, 12*time.Second, wait)
+
+	wait, ok = retryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	must.True(t, ok)
+	must.Eq(t, 0, wait)
+
+	wait, ok = retryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	must.True(t, ok)
+	must.Greater(t, 50*time.Minute, wait)
+
+	_, ok = retryAfter("soon")
+	must.False(t, ok)
+
+	for attempt := range 10 {
+		wait := retryJitter(retryBackoff(attempt))
+		must.Between(t, retryBackoff(attempt)/2, wait, retryBackoff(attempt))
+	}
+}
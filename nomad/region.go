@@ -0,0 +1,254 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// regionClients caches the Nomad API clients used to send requests to regions
+// other than the region of the provider.
+type regionClients struct {
+	lock    sync.Mutex
+	clients map[string]regionClient
+}
+
+type regionClient struct {
+	client  *api.Client
+	config  *api.Config
+	version *agentVersion
+}
+
+// forRegion returns a copy of the provider configuration whose client sends
+// requests to region. The configuration is returned as-is when region is
+// empty or is the region of the provider.
+func (c ProviderConfig) forRegion(region string) (ProviderConfig, error) {
+	if region == "" || c.config == nil || region == c.config.Region {
+		return c, nil
+	}
+
+	if c.regionClients == nil {
+		c.regionClients = &regionClients{}
+	}
+	c.regionClients.lock.Lock()
+	defer c.regionClients.lock.Unlock()
+
+	rc, ok := c.regionClients.clients[region]
+	if !ok {
+		conf := *c.config
+		conf.Region = region
+		conf.TLSConfig = c.config.TLSConfig.Copy()
+
+		httpClient, err := c.httpClient(&conf)
+		if err != nil {
+			return c, fmt.Errorf("failed to configure Nomad API for region %q: %s", region, err)
+		}
+		conf.HttpClient = withTransientRetries(withMutationLimit(httpClient, c.mutations), c.maxRetries)
+
+		client, err := api.NewClient(&conf)
+		if err != nil {
+			return c, fmt.Errorf("failed to configure Nomad API for region %q: %s", region, err)
+		}
+
+		if c.regionClients.clients == nil {
+			c.regionClients.clients = make(map[string]regionClient)
+		}
+		rc = regionClient{client: client, config: &conf, version: &agentVersion{}}
+		c.regionClients.clients[region] = rc
+	}
+
+	c.client = rc.client
+	c.config = rc.config
+	c.version = rc.version
+	return c, nil
+}
+
+// withRegion adds the region argument to the resources and wraps their
+// functions so they send their requests to the region set in their
+// configuration instead of the region of the provider.
+//
+// Resources that already have a computed region attribute that is not
+// configurable, like nomad_job whose region is set in its jobspec, are left
+// as-is.
+func withRegion(resources map[string]*schema.Resource, dataSource bool) map[string]*schema.Resource {
+	for _, r := range resources {
+		added := false
+		if s, ok := r.Schema["region"]; ok {
+			if !s.Optional {
+				continue
+			}
+		} else {
+			r.Schema["region"] = &schema.Schema{
+				Description: "The region of the object. Defaults to the region of the provider.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    !dataSource,
+			}
+			added = true
+		}
+
+		if r.CreateContext != nil {
+			r.CreateContext = regionContextFunc(r.CreateContext)
+		}
+		if r.ReadContext != nil {
+			read := regionContextFunc(schema.CreateContextFunc(r.ReadContext))
+			if added {
+				read = setRegionContextFunc(read)
+			}
+			r.ReadContext = schema.ReadContextFunc(read)
+		}
+		if r.UpdateContext != nil {
+			r.UpdateContext = schema.UpdateContextFunc(regionContextFunc(schema.CreateContextFunc(r.UpdateContext)))
+		}
+		if r.DeleteContext != nil {
+			r.DeleteContext = schema.DeleteContextFunc(regionContextFunc(schema.CreateContextFunc(r.DeleteContext)))
+		}
+		if r.Exists != nil {
+			r.Exists = regionExistsFunc(r.Exists)
+		}
+		if r.CustomizeDiff != nil {
+			r.CustomizeDiff = regionCustomizeDiffFunc(r.CustomizeDiff)
+		}
+		if added && !dataSource {
+			if r.CustomizeDiff != nil {
+				r.CustomizeDiff = customdiff.Sequence(regionDefaultDiff, r.CustomizeDiff)
+			} else {
+				r.CustomizeDiff = regionDefaultDiff
+			}
+		}
+		if r.Importer != nil {
+			if r.Importer.State != nil {
+				r.Importer.State = regionImportFunc(r.Importer.State, added)
+			}
+			if r.Importer.StateContext != nil {
+				r.Importer.StateContext = regionImportContextFunc(r.Importer.StateContext, added)
+			}
+		}
+	}
+	return resources
+}
+
+// effectiveRegion returns the region the requests sent for region are sent
+// to, which is the region of the provider when region is empty.
+func effectiveRegion(meta any, region string) string {
+	if region != "" {
+		return region
+	}
+	if config, ok := meta.(ProviderConfig); ok && config.config != nil {
+		return config.config.Region
+	}
+	return ""
+}
+
+// setRegionContextFunc records the region the object was read from, so
+// setting region to the region of the provider later doesn't replace it.
+func setRegionContextFunc(f schema.CreateContextFunc) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		if !diags.HasError() && d.Id() != "" {
+			d.Set("region", effectiveRegion(meta, d.Get("region").(string)))
+		}
+		return diags
+	}
+}
+
+// regionDefaultDiff removes the diff of region when it is set to the region
+// of the provider for an object created, or imported, without one, since
+// it's still the region the object is in.
+func regionDefaultDiff(_ context.Context, d *schema.ResourceDiff, meta any) error {
+	if d.Id() == "" || !d.HasChange("region") {
+		return nil
+	}
+	old, new := d.GetChange("region")
+	if old.(string) == "" && new.(string) != "" && new.(string) == effectiveRegion(meta, "") {
+		return d.Clear("region")
+	}
+	return nil
+}
+
+// regionMeta returns the provider configuration to use for the given
+// region.
+func regionMeta(meta any, region any) (any, error) {
+	config, ok := meta.(ProviderConfig)
+	if !ok {
+		return meta, nil
+	}
+	r, _ := region.(string)
+	return config.forRegion(r)
+}
+
+func regionContextFunc(f schema.CreateContextFunc) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		meta, err := regionMeta(meta, d.Get("region"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, meta)
+	}
+}
+
+func regionExistsFunc(f schema.ExistsFunc) schema.ExistsFunc {
+	return func(d *schema.ResourceData, meta any) (bool, error) {
+		meta, err := regionMeta(meta, d.Get("region"))
+		if err != nil {
+			return false, err
+		}
+		return f(d, meta)
+	}
+}
+
+func regionCustomizeDiffFunc(f schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+		meta, err := regionMeta(meta, d.Get("region"))
+		if err != nil {
+			return err
+		}
+		return f(ctx, d, meta)
+	}
+}
+
+func regionImportFunc(f schema.StateFunc, setRegion bool) schema.StateFunc {
+	return func(d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+		meta, err := regionMeta(meta, d.Get("region"))
+		if err != nil {
+			return nil, err
+		}
+		imported, err := f(d, meta)
+		if err == nil && setRegion {
+			setImportedRegion(imported, meta)
+		}
+		return imported, err
+	}
+}
+
+func regionImportContextFunc(f schema.StateContextFunc, setRegion bool) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+		meta, err := regionMeta(meta, d.Get("region"))
+		if err != nil {
+			return nil, err
+		}
+		imported, err := f(ctx, d, meta)
+		if err == nil && setRegion {
+			setImportedRegion(imported, meta)
+		}
+		return imported, err
+	}
+}
+
+// setImportedRegion records the region the objects were imported from.
+func setImportedRegion(imported []*schema.ResourceData, meta any) {
+	for _, d := range imported {
+		if d.Get("region").(string) == "" {
+			d.Set("region", effectiveRegion(meta, ""))
+		}
+	}
+}
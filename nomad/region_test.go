@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/shoenig/test/must"
+)
+
+func TestProviderConfig_forRegion(t *testing.T) {
+	conf := api.DefaultConfig()
+	conf.Region = "global"
+	client, err := api.NewClient(conf)
+	must.NoError(t, err)
+
+	config := ProviderConfig{
+		client:        client,
+		config:        conf,
+		regionClients: &regionClients{},
+	}
+
+	c, err := config.forRegion("")
+	must.NoError(t, err)
+	must.Eq(t, client, c.client)
+
+	c, err = config.forRegion("global")
+	must.NoError(t, err)
+	must.Eq(t, client, c.client)
+
+	eu, err := config.forRegion("eu")
+	must.NoError(t, err)
+	must.NotEq(t, client, eu.client)
+	must.Eq(t, "eu", eu.config.Region)
+	must.Eq(t, conf.Address, eu.config.Address)
+	must.Eq(t, "global", conf.Region)
+
+	// The clients are reused.
+	c, err = config.forRegion("eu")
+	must.NoError(t, err)
+	must.Eq(t, eu.client, c.client)
+}
+
+func TestWithRegion(t *testing.T) {
+	var region string
+	resources := withRegion(map[string]*schema.Resource{
+		"nomad_namespace": {
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+					ForceNew: true,
+				},
+			},
+			CreateContext: func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+				region = meta.(ProviderConfig).config.Region
+				return nil
+			},
+		},
+		"nomad_job": {
+			Schema: map[string]*schema.Schema{
+				"region": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}, false)
+
+	r := resources["nomad_namespace"]
+	must.NotNil(t, r.Schema["region"])
+	must.True(t, r.Schema["region"].ForceNew)
+	must.False(t, resources["nomad_job"].Schema["region"].Optional)
+
+	conf := api.DefaultConfig()
+	conf.Region = "global"
+	client, err := api.NewClient(conf)
+	must.NoError(t, err)
+	meta := ProviderConfig{client: client, config: conf, regionClients: &regionClients{}}
+
+	d := r.TestResourceData()
+	must.False(t, r.CreateContext(context.Background(), d, meta).HasError())
+	must.Eq(t, "global", region)
+
+	must.NoError(t, d.Set("region", "eu"))
+	must.False(t, r.CreateContext(context.Background(), d, meta).HasError())
+	must.Eq(t, "eu", region)
+}
+
+func TestWithRegion_providerRegion(t *testing.T) {
+	r := withRegion(map[string]*schema.Resource{
+		"nomad_namespace": {
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+					ForceNew: true,
+				},
+			},
+			ReadContext: func(context.Context, *schema.ResourceData, any) diag.Diagnostics {
+				return nil
+			},
+			Importer: &schema.ResourceImporter{
+				StateContext: schema.ImportStatePassthroughContext,
+			},
+		},
+	}, false)["nomad_namespace"]
+
+	conf := api.DefaultConfig()
+	conf.Region = "global"
+	client, err := api.NewClient(conf)
+	must.NoError(t, err)
+	meta := ProviderConfig{client: client, config: conf, regionClients: &regionClients{}}
+
+	// The region is recorded on refresh and import.
+	d := r.Data(&terraform.InstanceState{ID: "ops", Attributes: map[string]string{"name": "ops"}})
+	must.False(t, r.ReadContext(context.Background(), d, meta).HasError())
+	must.Eq(t, "global", d.Get("region"))
+
+	d = r.Data(&terraform.InstanceState{ID: "ops"})
+	imported, err := r.Importer.StateContext(context.Background(), d, meta)
+	must.NoError(t, err)
+	must.Eq(t, "global", imported[0].Get("region"))
+
+	// Setting the region of the provider on an object created without one
+	// doesn't replace it.
+	state := &terraform.InstanceState{ID: "ops", Attributes: map[string]string{"id": "ops", "name": "ops"}}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]any{
+		"name":   "ops",
+		"region": "global",
+	}), meta)
+	must.NoError(t, err)
+	must.True(t, diff == nil || !diff.RequiresNew())
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]any{
+		"name":   "ops",
+		"region": "eu",
+	}), meta)
+	must.NoError(t, err)
+	must.True(t, diff.RequiresNew())
+}
@@ -4,11 +4,19 @@
 package nomad
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -22,6 +30,8 @@ func resourceACLAuthMethod() *schema.Resource {
 		Read:   resourceACLAuthMethodRead,
 		Exists: resourceACLAuthMethodExists,
 
+		CustomizeDiff: resourceACLAuthMethodCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -72,6 +82,12 @@ func resourceACLAuthMethod() *schema.Resource {
 				Optional:    true,
 				Type:        schema.TypeBool,
 			},
+			"validate_endpoints": {
+				Description: "Check during plan that the JWKS URL or the OIDC discovery URL is reachable and returns a valid document.",
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Default:     false,
+			},
 			"config": {
 				Description: "Configuration specific to the auth method provider.",
 				Required:    true,
@@ -80,6 +96,18 @@ func resourceACLAuthMethod() *schema.Resource {
 				MinItems:    1,
 				Elem:        resourceACLAuthMethodConfig(),
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the ACL auth method was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the ACL auth method was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -132,7 +160,25 @@ func resourceACLAuthMethodConfig() *schema.Resource {
 					"config.0.oidc_client_secret",
 					"config.0.oidc_client_id",
 				},
-				Sensitive: true,
+				ConflictsWith: []string{"config.0.oidc_client_secret_wo"},
+				Sensitive:     true,
+			},
+			"oidc_client_secret_wo": {
+				Description: "The OAuth Client Secret configured with the OIDC provider. This value is write-only and is never stored in state.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				WriteOnly:   true,
+				RequiredWith: []string{
+					"config.0.oidc_client_secret_wo_version",
+					"config.0.oidc_client_id",
+				},
+			},
+			"oidc_client_secret_wo_version": {
+				Description:  "The version of oidc_client_secret_wo, must be changed for new oidc_client_secret_wo values to be sent to Nomad.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"config.0.oidc_client_secret_wo"},
 			},
 			"oidc_client_assertion": {
 				Description: "Configuration for OIDC client assertion / private key JWT.",
@@ -278,46 +324,63 @@ func resourceACLAuthMethodClientAssertionConfig() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"pem_key": {
-							Description: "RSA private key PEM to use to sign the JWT.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Sensitive:   true,
-							ConflictsWith: []string{
-								"config.0.oidc_client_assertion.0.private_key.0.pem_key_file",
-							},
+							Description:  "RSA private key PEM to use to sign the JWT.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ExactlyOneOf: aclAuthMethodPrivateKeyKeys,
+						},
+						"pem_key_wo": {
+							Description:  "RSA private key PEM to use to sign the JWT. This value is write-only and is never stored in state.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							WriteOnly:    true,
+							ExactlyOneOf: aclAuthMethodPrivateKeyKeys,
+							RequiredWith: []string{"config.0.oidc_client_assertion.0.private_key.0.pem_key_wo_version"},
+						},
+						"pem_key_wo_version": {
+							Description:  "The version of pem_key_wo, must be changed for new pem_key_wo values to be sent to Nomad.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							RequiredWith: []string{"config.0.oidc_client_assertion.0.private_key.0.pem_key_wo"},
 						},
 						"pem_key_file": {
-							Description: "Path to an RSA private key PEM on Nomad servers to use to sign the JWT.",
-							Type:        schema.TypeString,
-							Optional:    true,
+							Description:  "Path to an RSA private key PEM on Nomad servers to use to sign the JWT.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ExactlyOneOf: aclAuthMethodPrivateKeyKeys,
 						},
 						"pem_cert": {
-							Description: "An x509 certificate PEM to derive a key ID header.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							ConflictsWith: []string{
-								"config.0.oidc_client_assertion.0.private_key.0.pem_cert_file",
-								"config.0.oidc_client_assertion.0.private_key.0.key_id",
-							},
+							Description:  "An x509 certificate PEM to derive a key ID header.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ExactlyOneOf: aclAuthMethodPrivateKeyIDKeys,
 						},
 						"pem_cert_file": {
-							Description: "Path to an x509 certificate PEM on Nomad servers to derive a key ID header.",
-							Type:        schema.TypeString,
-							Optional:    true,
+							Description:  "Path to an x509 certificate PEM on Nomad servers to derive a key ID header.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ExactlyOneOf: aclAuthMethodPrivateKeyIDKeys,
 						},
 						"key_id_header": {
-							Description: "Name of the header the IDP will use to find the cert to verify the JWT signature.",
+							Description: "Name of the header the IDP will use to find the cert to verify the JWT signature. Defaults to 'kid' when key_id is set, and to 'x5t#S256' otherwise.",
 							Type:        schema.TypeString,
 							Optional:    true,
-							Default:     "x5t#S256",
+							Computed:    true,
 							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice([]string{"x5t#S256", "x5t"}, false),
+								validation.StringInSlice([]string{
+									string(api.OIDCClientAssertionHeaderKid),
+									string(api.OIDCClientAssertionHeaderX5t),
+									string(api.OIDCClientAssertionHeaderX5tS256),
+								}, false),
 							),
 						},
 						"key_id": {
-							Description: "Specific 'kid' header to set on the JWT.",
-							Type:        schema.TypeString,
-							Optional:    true,
+							Description:  "Specific 'kid' header to set on the JWT.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ExactlyOneOf: aclAuthMethodPrivateKeyIDKeys,
 						},
 					},
 				},
@@ -326,6 +389,70 @@ func resourceACLAuthMethodClientAssertionConfig() *schema.Resource {
 	}
 }
 
+var (
+	// aclAuthMethodPrivateKeyKeys are the attributes of the private key of
+	// the client assertion, one of which must be set.
+	aclAuthMethodPrivateKeyKeys = []string{
+		"config.0.oidc_client_assertion.0.private_key.0.pem_key",
+		"config.0.oidc_client_assertion.0.private_key.0.pem_key_wo",
+		"config.0.oidc_client_assertion.0.private_key.0.pem_key_file",
+	}
+
+	// aclAuthMethodClientSecretWOPath and aclAuthMethodPemKeyWOPath are the
+	// paths of the write-only secrets of the auth method config.
+	aclAuthMethodClientSecretWOPath = cty.GetAttrPath("config").IndexInt(0).
+					GetAttr("oidc_client_secret_wo")
+	aclAuthMethodPemKeyWOPath = cty.GetAttrPath("config").IndexInt(0).
+					GetAttr("oidc_client_assertion").IndexInt(0).
+					GetAttr("private_key").IndexInt(0).
+					GetAttr("pem_key_wo")
+
+	// aclAuthMethodPrivateKeyIDKeys are the attributes used to derive the key
+	// ID of the client assertion, one of which must be set.
+	aclAuthMethodPrivateKeyIDKeys = []string{
+		"config.0.oidc_client_assertion.0.private_key.0.pem_cert",
+		"config.0.oidc_client_assertion.0.private_key.0.pem_cert_file",
+		"config.0.oidc_client_assertion.0.private_key.0.key_id",
+	}
+)
+
+func resourceACLAuthMethodCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, _ any) error {
+	if !d.Get("validate_endpoints").(bool) {
+		return nil
+	}
+	// Only check the endpoints when they may have changed, so plans don't
+	// fail when the identity provider is briefly unavailable.
+	if d.Id() != "" && !d.HasChange("config") && !d.HasChange("validate_endpoints") {
+		return nil
+	}
+
+	for _, key := range []string{"config.0.jwks_url", "config.0.jwks_ca_cert", "config.0.oidc_discovery_url", "config.0.discovery_ca_pem"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	if jwksURL := d.Get("config.0.jwks_url").(string); jwksURL != "" {
+		var caPEMs []string
+		if ca := d.Get("config.0.jwks_ca_cert").(string); ca != "" {
+			caPEMs = append(caPEMs, ca)
+		}
+		return checkJWKSEndpoint(ctx, jwksURL, caPEMs)
+	}
+
+	if discoveryURL := d.Get("config.0.oidc_discovery_url").(string); discoveryURL != "" {
+		var caPEMs []string
+		for _, ca := range d.Get("config.0.discovery_ca_pem").([]any) {
+			if ca, ok := ca.(string); ok {
+				caPEMs = append(caPEMs, ca)
+			}
+		}
+		return checkOIDCDiscoveryEndpoint(ctx, discoveryURL, caPEMs)
+	}
+
+	return nil
+}
+
 func resourceACLAuthMethodCreate(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(ProviderConfig)
 	client := providerConfig.client
@@ -450,7 +577,19 @@ func unredactACLAuthMethodResource(d *schema.ResourceData, fetchedAuthMethod, or
 	orig := &aclAuthMethodSecrets{am: originalAuthMethod}
 	unredacted := &aclAuthMethodSecrets{am: fetchedAuthMethod}
 
-	// orig is what we just sent to Nomad, during resourceACLAuthMethod(Create|Update)
+	// The write-only secrets must never be stored in TF state, the values
+	// returned by Nomad are only "redacted".
+	versions := aclAuthMethodWOVersions(d)
+	defer func() {
+		if versions.clientSecret != 0 {
+			unredacted.setClientSecret("")
+		}
+		if versions.pemKey != 0 {
+			unredacted.setClientAssertionPrivateKey("")
+		}
+	}()
+
+	// orig is what we just sent to Nomad, during resourceACLAuthMethod(Create|Update).
 	if orig.hasConfig() {
 		if secret := orig.getClientSecret(); secret != "" {
 			unredacted.setClientSecret(secret)
@@ -471,7 +610,6 @@ func unredactACLAuthMethodResource(d *schema.ResourceData, fetchedAuthMethod, or
 			unredacted.setClientAssertionPrivateKey(key.(string))
 		}
 	}
-	return
 }
 
 func setStateFromACLAuthMethodResource(d *schema.ResourceData, authMethod *api.ACLAuthMethod) {
@@ -481,7 +619,9 @@ func setStateFromACLAuthMethodResource(d *schema.ResourceData, authMethod *api.A
 	_ = d.Set("max_token_ttl", authMethod.MaxTokenTTL.String())
 	_ = d.Set("token_name_format", authMethod.TokenNameFormat)
 	_ = d.Set("default", authMethod.Default)
-	_ = d.Set("config", flattenACLAuthMethodConfig(authMethod.Config))
+	_ = d.Set("config", flattenACLAuthMethodConfig(authMethod.Config, aclAuthMethodWOVersions(d)))
+	_ = d.Set("create_index", int(authMethod.CreateIndex))
+	_ = d.Set("modify_index", int(authMethod.ModifyIndex))
 }
 
 func resourceACLAuthMethodExists(d *schema.ResourceData, meta interface{}) (bool, error) {
@@ -495,7 +635,7 @@ func resourceACLAuthMethodExists(d *schema.ResourceData, meta interface{}) (bool
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			return false, nil
 		}
 		return true, fmt.Errorf("error checking for ACL Auth Method %q: %#v", authMethodName, err)
@@ -534,6 +674,37 @@ func generateNomadACLAuthMethod(d *schema.ResourceData) (*api.ACLAuthMethod, err
 		aclAuthMethod.Config = authMethodConfig
 	}
 
+	// key_id_header is computed, so let Nomad pick the header matching the
+	// key ID source unless it is set explicitly since the value from the
+	// state may not match it anymore.
+	if aclAuthMethod.Config != nil && aclAuthMethod.Config.OIDCClientAssertion != nil &&
+		aclAuthMethod.Config.OIDCClientAssertion.PrivateKey != nil {
+		header, _ := d.GetRawConfigAt(cty.GetAttrPath("config").IndexInt(0).
+			GetAttr("oidc_client_assertion").IndexInt(0).
+			GetAttr("private_key").IndexInt(0).
+			GetAttr("key_id_header"))
+		if header.IsNull() {
+			aclAuthMethod.Config.OIDCClientAssertion.PrivateKey.KeyIDHeader = ""
+		}
+	}
+
+	// The write-only secrets are only available in the configuration.
+	secrets := &aclAuthMethodSecrets{am: &aclAuthMethod}
+	clientSecret, ok, err := getWriteOnlyString(d, aclAuthMethodClientSecretWOPath)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		secrets.setClientSecret(clientSecret)
+	}
+	pemKey, ok, err := getWriteOnlyString(d, aclAuthMethodPemKeyWOPath)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		secrets.setClientAssertionPrivateKey(pemKey)
+	}
+
 	return &aclAuthMethod, nil
 }
 
@@ -714,31 +885,48 @@ func generateNomadACLAuthMethodConfig(intf interface{}) (*api.ACLAuthMethodConfi
 	return &authMethodConfig, nil
 }
 
-func flattenACLAuthMethodConfig(cfg *api.ACLAuthMethodConfig) []any {
+// aclAuthMethodWOVersions returns the versions of the write-only secrets of
+// the auth method config, which Nomad doesn't know about.
+func aclAuthMethodWOVersions(d *schema.ResourceData) aclAuthMethodWriteOnlyVersions {
+	if d == nil {
+		return aclAuthMethodWriteOnlyVersions{}
+	}
+	clientSecret, _ := d.Get("config.0.oidc_client_secret_wo_version").(int)
+	pemKey, _ := d.Get("config.0.oidc_client_assertion.0.private_key.0.pem_key_wo_version").(int)
+	return aclAuthMethodWriteOnlyVersions{clientSecret: clientSecret, pemKey: pemKey}
+}
+
+type aclAuthMethodWriteOnlyVersions struct {
+	clientSecret int
+	pemKey       int
+}
+
+func flattenACLAuthMethodConfig(cfg *api.ACLAuthMethodConfig, versions aclAuthMethodWriteOnlyVersions) []any {
 	if cfg == nil {
 		return nil
 	}
 	result := map[string]any{
-		"jwt_validation_pub_keys": packStringArray(cfg.JWTValidationPubKeys),
-		"jwks_url":                cfg.JWKSURL,
-		"jwks_ca_cert":            cfg.JWKSCACert,
-		"oidc_discovery_url":      cfg.OIDCDiscoveryURL,
-		"oidc_client_id":          cfg.OIDCClientID,
-		"oidc_client_secret":      cfg.OIDCClientSecret,
-		"oidc_enable_pkce":        cfg.OIDCEnablePKCE,
-		"oidc_scopes":             packStringArray(cfg.OIDCScopes),
-		"oidc_disable_userinfo":   cfg.OIDCDisableUserInfo,
-		"bound_audiences":         packStringArray(cfg.BoundAudiences),
-		"bound_issuer":            packStringArray(cfg.BoundIssuer),
-		"allowed_redirect_uris":   packStringArray(cfg.AllowedRedirectURIs),
-		"discovery_ca_pem":        packStringArray(cfg.DiscoveryCaPem),
-		"signing_algs":            packStringArray(cfg.SigningAlgs),
-		"expiration_leeway":       cfg.ExpirationLeeway.String(),
-		"not_before_leeway":       cfg.NotBeforeLeeway.String(),
-		"clock_skew_leeway":       cfg.ClockSkewLeeway.String(),
-		"claim_mappings":          packStringMap(cfg.ClaimMappings),
-		"list_claim_mappings":     packStringMap(cfg.ListClaimMappings),
-		"verbose_logging":         cfg.VerboseLogging,
+		"jwt_validation_pub_keys":       packStringArray(cfg.JWTValidationPubKeys),
+		"jwks_url":                      cfg.JWKSURL,
+		"jwks_ca_cert":                  cfg.JWKSCACert,
+		"oidc_discovery_url":            cfg.OIDCDiscoveryURL,
+		"oidc_client_id":                cfg.OIDCClientID,
+		"oidc_client_secret":            cfg.OIDCClientSecret,
+		"oidc_client_secret_wo_version": versions.clientSecret,
+		"oidc_enable_pkce":              cfg.OIDCEnablePKCE,
+		"oidc_scopes":                   packStringArray(cfg.OIDCScopes),
+		"oidc_disable_userinfo":         cfg.OIDCDisableUserInfo,
+		"bound_audiences":               packStringArray(cfg.BoundAudiences),
+		"bound_issuer":                  packStringArray(cfg.BoundIssuer),
+		"allowed_redirect_uris":         packStringArray(cfg.AllowedRedirectURIs),
+		"discovery_ca_pem":              packStringArray(cfg.DiscoveryCaPem),
+		"signing_algs":                  packStringArray(cfg.SigningAlgs),
+		"expiration_leeway":             cfg.ExpirationLeeway.String(),
+		"not_before_leeway":             cfg.NotBeforeLeeway.String(),
+		"clock_skew_leeway":             cfg.ClockSkewLeeway.String(),
+		"claim_mappings":                packStringMap(cfg.ClaimMappings),
+		"list_claim_mappings":           packStringMap(cfg.ListClaimMappings),
+		"verbose_logging":               cfg.VerboseLogging,
 	}
 	if cfg.OIDCClientAssertion != nil {
 		cAss := map[string]any{
@@ -749,12 +937,13 @@ func flattenACLAuthMethodConfig(cfg *api.ACLAuthMethodConfig) []any {
 		}
 		if cfg.OIDCClientAssertion.PrivateKey != nil {
 			privateKey := map[string]any{
-				"pem_key":       cfg.OIDCClientAssertion.PrivateKey.PemKey,
-				"pem_key_file":  cfg.OIDCClientAssertion.PrivateKey.PemKeyFile,
-				"pem_cert":      cfg.OIDCClientAssertion.PrivateKey.PemCert,
-				"pem_cert_file": cfg.OIDCClientAssertion.PrivateKey.PemCertFile,
-				"key_id":        cfg.OIDCClientAssertion.PrivateKey.KeyID,
-				"key_id_header": cfg.OIDCClientAssertion.PrivateKey.KeyIDHeader,
+				"pem_key":            cfg.OIDCClientAssertion.PrivateKey.PemKey,
+				"pem_key_wo_version": versions.pemKey,
+				"pem_key_file":       cfg.OIDCClientAssertion.PrivateKey.PemKeyFile,
+				"pem_cert":           cfg.OIDCClientAssertion.PrivateKey.PemCert,
+				"pem_cert_file":      cfg.OIDCClientAssertion.PrivateKey.PemCertFile,
+				"key_id":             cfg.OIDCClientAssertion.PrivateKey.KeyID,
+				"key_id_header":      cfg.OIDCClientAssertion.PrivateKey.KeyIDHeader,
 			}
 			cAss["private_key"] = []any{privateKey}
 		}
@@ -858,3 +1047,85 @@ func (a *aclAuthMethodSecrets) setClientAssertionPrivateKey(s string) {
 	}
 	a.am.Config.OIDCClientAssertion.PrivateKey.PemKey = s
 }
+
+// checkJWKSEndpoint returns an error if jwksURL doesn't return a JSON Web Key
+// Set with at least one key.
+func checkJWKSEndpoint(ctx context.Context, jwksURL string, caPEMs []string) error {
+	var jwks struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := fetchAuthMethodDocument(ctx, jwksURL, caPEMs, &jwks); err != nil {
+		return fmt.Errorf("failed to validate jwks_url: %w", err)
+	}
+	if len(jwks.Keys) == 0 {
+		return fmt.Errorf("failed to validate jwks_url: %q doesn't return any key", jwksURL)
+	}
+	return nil
+}
+
+// checkOIDCDiscoveryEndpoint returns an error if discoveryURL doesn't serve an
+// OIDC discovery document for the issuer discoveryURL, as Nomad requires.
+func checkOIDCDiscoveryEndpoint(ctx context.Context, discoveryURL string, caPEMs []string) error {
+	var doc oidcDiscoveryDocument
+	wellKnownURL := strings.TrimSuffix(discoveryURL, "/") + "/.well-known/openid-configuration"
+	if err := fetchAuthMethodDocument(ctx, wellKnownURL, caPEMs, &doc); err != nil {
+		return fmt.Errorf("failed to validate oidc_discovery_url: %w", err)
+	}
+	if doc.Issuer != discoveryURL {
+		return fmt.Errorf("failed to validate oidc_discovery_url: the issuer of the discovery document is %q, it must match %q", doc.Issuer, discoveryURL)
+	}
+	if doc.JWKSURI == "" {
+		return fmt.Errorf("failed to validate oidc_discovery_url: the discovery document of %q doesn't have a jwks_uri", discoveryURL)
+	}
+	return nil
+}
+
+// fetchAuthMethodDocument decodes the JSON document returned by rawURL into
+// out, trusting the CA certificates given in addition to the system ones.
+func fetchAuthMethodDocument(ctx context.Context, rawURL string, caPEMs []string, out any) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %v", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid URL %q: it must be an absolute http or https URL", rawURL)
+	}
+
+	tlsConfig := &tls.Config{}
+	if len(caPEMs) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, ca := range caPEMs {
+			if !pool.AppendCertsFromPEM([]byte(ca)) {
+				return errors.New("failed to parse CA certificate")
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+	}
+
+	log.Printf("[DEBUG] Fetching %q", rawURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %q: %v", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %q from %q", resp.Status, rawURL)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the response of %q: %v", rawURL, err)
+	}
+	return nil
+}
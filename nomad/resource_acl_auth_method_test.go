@@ -4,16 +4,22 @@
 package nomad
 
 import (
+	"context"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"golang.org/x/exp/slices"
 )
@@ -86,6 +92,54 @@ resource "nomad_acl_auth_method" "test-jwt" {
 `, name, defaultVal, uiCallback, name)
 }
 
+func TestResourceACLAuthMethod_writeOnly(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-nomad-test")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.4.4-dev") },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceACLAuthMethod_writeOnlyConfig(name, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("nomad_acl_auth_method.test", "config.0.oidc_client_secret_wo"),
+					resource.TestCheckResourceAttr("nomad_acl_auth_method.test", "config.0.oidc_client_secret", ""),
+					resource.TestCheckResourceAttr("nomad_acl_auth_method.test", "config.0.oidc_client_secret_wo_version", "1"),
+				),
+			},
+			{
+				Config: testResourceACLAuthMethod_writeOnlyConfig(name, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("nomad_acl_auth_method.test", "config.0.oidc_client_secret_wo"),
+					resource.TestCheckResourceAttr("nomad_acl_auth_method.test", "config.0.oidc_client_secret", ""),
+					resource.TestCheckResourceAttr("nomad_acl_auth_method.test", "config.0.oidc_client_secret_wo_version", "2"),
+				),
+			},
+		},
+		CheckDestroy: testResourceACLAuthMethodCheckDestroy(name),
+	})
+}
+
+func testResourceACLAuthMethod_writeOnlyConfig(name string, version int) string {
+	return fmt.Sprintf(`
+resource "nomad_acl_auth_method" "test" {
+  name           = "%s"
+  type           = "OIDC"
+  token_locality = "global"
+  max_token_ttl  = "10m"
+
+  config {
+    oidc_discovery_url            = "https://uk.auth0.com/"
+    oidc_client_id                = "someclientid"
+    oidc_client_secret_wo         = "someclientsecret-%d"
+    oidc_client_secret_wo_version = %d
+    bound_audiences               = ["someclientid"]
+    allowed_redirect_uris         = ["http://localhost:4649/oidc/callback"]
+  }
+}
+`, name, version, version)
+}
+
 func testResourceACLAuthMethodCheck(name, uiCallback, defaultVal string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		const (
@@ -277,10 +331,20 @@ func TestResourceACLAuthMethod_OIDCClientAssertion(t *testing.T) {
 						regexp.MustCompile("RSA PRIVATE KEY-----")),
 					resource.TestMatchResourceAttr(resourceName, attrPrefix+"private_key.0.pem_cert",
 						regexp.MustCompile("CERTIFICATE-----")),
+					resource.TestCheckResourceAttr(resourceName, attrPrefix+"private_key.0.key_id_header", "x5t#S256"),
 					// headers should be removed
 					resource.TestCheckResourceAttr(resourceName, attrPrefix+"extra_headers.%", "0"),
 				),
 			},
+			{
+				Config: clientAssertionResourcesHCL(methodName, clientAssertionPrivateKeyID, true),
+				Check: resource.ComposeTestCheckFunc(
+					// the key ID header defaults to kid along with key_id
+					resource.TestCheckResourceAttr(resourceName, attrPrefix+"private_key.0.key_id", "my-key-id"),
+					resource.TestCheckResourceAttr(resourceName, attrPrefix+"private_key.0.key_id_header", "kid"),
+					resource.TestCheckResourceAttr(resourceName, attrPrefix+"private_key.0.pem_cert", ""),
+				),
+			},
 			{
 				Config: clientAssertionResourcesHCL(methodName, clientAssertionPrivateKeyFile, true),
 				Check: resource.ComposeTestCheckFunc(
@@ -371,6 +435,15 @@ resource "nomad_acl_auth_method" "client_assertion_test" {
         pem_cert = tls_self_signed_cert.test.cert_pem
       }
     }
+`
+	clientAssertionPrivateKeyID clientAssertionBlock = `
+    oidc_client_assertion {
+      key_source = "private_key"
+      private_key {
+        pem_key = tls_private_key.test.private_key_pem
+        key_id  = "my-key-id"
+      }
+    }
 `
 	clientAssertionPrivateKeyFile clientAssertionBlock = `
     oidc_client_assertion {
@@ -409,3 +482,127 @@ resource "local_file" "cert" {
 }
 `
 )
+
+func TestUnredactACLAuthMethodResource(t *testing.T) {
+	fetched := func() *api.ACLAuthMethod {
+		return &api.ACLAuthMethod{Config: &api.ACLAuthMethodConfig{
+			OIDCClientSecret: "redacted",
+			OIDCClientAssertion: &api.OIDCClientAssertion{
+				PrivateKey: &api.OIDCClientAssertionKey{PemKey: "redacted"},
+			},
+		}}
+	}
+	sent := &api.ACLAuthMethod{Config: &api.ACLAuthMethodConfig{
+		OIDCClientSecret: "secret",
+		OIDCClientAssertion: &api.OIDCClientAssertion{
+			PrivateKey: &api.OIDCClientAssertionKey{PemKey: "key"},
+		},
+	}}
+	data := func(secretVersion, keyVersion int) *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, resourceACLAuthMethod().Schema, map[string]any{
+			"config": []any{map[string]any{
+				"oidc_client_secret":            "secret",
+				"oidc_client_secret_wo_version": secretVersion,
+				"oidc_client_assertion": []any{map[string]any{
+					"private_key": []any{map[string]any{
+						"pem_key":            "key",
+						"pem_key_wo_version": keyVersion,
+					}},
+				}},
+			}},
+		})
+	}
+
+	cases := map[string]struct {
+		d      *schema.ResourceData
+		sent   *api.ACLAuthMethod
+		secret string
+		pemKey string
+	}{
+		"write":              {d: data(0, 0), sent: sent, secret: "secret", pemKey: "key"},
+		"refresh":            {d: data(0, 0), secret: "secret", pemKey: "key"},
+		"write-only write":   {d: data(1, 1), sent: sent},
+		"write-only refresh": {d: data(1, 1)},
+		"write-only secret":  {d: data(1, 0), secret: "", pemKey: "key"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			am := fetched()
+			unredactACLAuthMethodResource(tc.d, am, tc.sent)
+			if am.Config.OIDCClientSecret != tc.secret {
+				t.Errorf("expected client secret %q, got %q", tc.secret, am.Config.OIDCClientSecret)
+			}
+			if key := am.Config.OIDCClientAssertion.PrivateKey.PemKey; key != tc.pemKey {
+				t.Errorf("expected private key %q, got %q", tc.pemKey, key)
+			}
+		})
+	}
+}
+
+func TestCheckJWKSEndpoint(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"keys": [{"kty": "RSA", "kid": "key"}]}`)
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"keys": []}`)
+	})
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+	ctx := context.Background()
+
+	if err := checkJWKSEndpoint(ctx, srv.URL+"/jwks", []string{ca}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	cases := map[string]struct {
+		url    string
+		caPEMs []string
+		err    string
+	}{
+		"untrusted CA": {url: srv.URL + "/jwks", err: "failed to reach"},
+		"invalid CA":   {url: srv.URL + "/jwks", caPEMs: []string{"not a cert"}, err: "failed to parse CA certificate"},
+		"not found":    {url: srv.URL + "/typo", caPEMs: []string{ca}, err: "unexpected status"},
+		"no keys":      {url: srv.URL + "/empty", caPEMs: []string{ca}, err: "doesn't return any key"},
+		"relative URL": {url: "/jwks", err: "must be an absolute http or https URL"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := checkJWKSEndpoint(ctx, tc.url, tc.caPEMs)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestCheckOIDCDiscoveryEndpoint(t *testing.T) {
+	var issuer string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"issuer": %q, "jwks_uri": %q}`, issuer, issuer+"/keys")
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	issuer = srv.URL
+	if err := checkOIDCDiscoveryEndpoint(ctx, srv.URL, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := checkOIDCDiscoveryEndpoint(ctx, srv.URL+"/", nil)
+	if err == nil || !strings.Contains(err.Error(), "it must match") {
+		t.Errorf("expected issuer mismatch, got %v", err)
+	}
+
+	issuer = "https://other.example.com"
+	err = checkOIDCDiscoveryEndpoint(ctx, srv.URL, nil)
+	if err == nil || !strings.Contains(err.Error(), "it must match") {
+		t.Errorf("expected issuer mismatch, got %v", err)
+	}
+}
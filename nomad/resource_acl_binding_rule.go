@@ -4,10 +4,13 @@
 package nomad
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
-	"strings"
 
+	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -21,6 +24,8 @@ func resourceACLBindingRule() *schema.Resource {
 		Read:   resourceACLBindingRuleRead,
 		Exists: resourceACLBindingRuleExists,
 
+		CustomizeDiff: resourceACLBindingRuleCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -37,9 +42,16 @@ func resourceACLBindingRule() *schema.Resource {
 				Type:        schema.TypeString,
 			},
 			"selector": {
-				Description: "A boolean expression that matches against verified identity attributes returned from the auth method during login.",
-				Optional:    true,
-				Type:        schema.TypeString,
+				Description:  "A boolean expression that matches against verified identity attributes returned from the auth method during login.",
+				Optional:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validateACLBindingRuleSelector,
+			},
+			"sample_claims": {
+				Description:  `A JSON document with the "value" and "list" claims, as mapped by the auth method, that the selector must match. It is only used during plan and never sent to Nomad.`,
+				Optional:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsJSON,
 			},
 			"bind_type": {
 				Description: `Adjusts how this binding rule is applied at login time. Valid values are "role" and "policy".`,
@@ -56,6 +68,18 @@ func resourceACLBindingRule() *schema.Resource {
 				Optional:    true,
 				Type:        schema.TypeString,
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the ACL binding rule was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the ACL binding rule was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -148,6 +172,8 @@ func resourceACLBindingRuleRead(d *schema.ResourceData, meta interface{}) error
 	_ = d.Set("selector", bindingRule.Selector)
 	_ = d.Set("bind_type", bindingRule.BindType)
 	_ = d.Set("bind_name", bindingRule.BindName)
+	_ = d.Set("create_index", int(bindingRule.CreateIndex))
+	_ = d.Set("modify_index", int(bindingRule.ModifyIndex))
 
 	return nil
 }
@@ -163,7 +189,7 @@ func resourceACLBindingRuleExists(d *schema.ResourceData, meta interface{}) (boo
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			return false, nil
 		}
 		return true, fmt.Errorf("error checking for ACL Binding Rule %q: %#v", bindingRuleID, err)
@@ -183,6 +209,76 @@ func generateNomadACLBindingRule(d *schema.ResourceData) *api.ACLBindingRule {
 	}
 }
 
+// aclBindingRuleClaims is the data a selector is evaluated against when a
+// user logs in, built by Nomad from the claims the auth method maps.
+type aclBindingRuleClaims struct {
+	Value map[string]string   `bexpr:"value" json:"value"`
+	List  map[string][]string `bexpr:"list" json:"list"`
+}
+
+// resourceACLBindingRuleCustomizeDiff evaluates the selector against the
+// sample claims, if any, so a rule that would never match is reported during
+// plan.
+func resourceACLBindingRuleCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("selector") || !d.NewValueKnown("sample_claims") {
+		return nil
+	}
+
+	selector := d.Get("selector").(string)
+	sampleClaims := d.Get("sample_claims").(string)
+	if sampleClaims == "" {
+		return nil
+	}
+
+	return checkACLBindingRuleSelector(selector, sampleClaims)
+}
+
+// checkACLBindingRuleSelector returns an error if the selector doesn't match
+// the claims in the JSON document sampleClaims.
+func checkACLBindingRuleSelector(selector, sampleClaims string) error {
+	var claims aclBindingRuleClaims
+	dec := json.NewDecoder(bytes.NewBufferString(sampleClaims))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&claims); err != nil {
+		return fmt.Errorf("failed to decode sample_claims: %v", err)
+	}
+
+	// An empty selector matches every login.
+	if selector == "" {
+		return nil
+	}
+
+	eval, err := bexpr.CreateEvaluator(selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector: %v", err)
+	}
+	match, err := eval.Evaluate(&claims)
+	if err != nil {
+		return fmt.Errorf("selector %q does not match the sample claims: %v", selector, err)
+	}
+	if !match {
+		return fmt.Errorf("selector %q does not match the sample claims", selector)
+	}
+	return nil
+}
+
+// validateACLBindingRuleSelector checks that the selector is a valid
+// expression.
+func validateACLBindingRuleSelector(i interface{}, k string) ([]string, []error) {
+	selector, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if selector == "" {
+		return nil, nil
+	}
+
+	if _, err := bexpr.CreateEvaluator(selector); err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: %v", k, err)}
+	}
+	return nil, nil
+}
+
 func validateNomadACLBindingRule(d *schema.ResourceData) error {
 	bindName := d.Get("bind_name").(string)
 	bindType := d.Get("bind_type").(string)
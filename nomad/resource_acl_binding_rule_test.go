@@ -231,3 +231,89 @@ func testResourceACLBindingRuleCheckDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func TestCheckACLBindingRuleSelector(t *testing.T) {
+	sampleClaims := `{
+  "value": {"name": "alice", "team": "engineering"},
+  "list": {"groups": ["admins", "developers"]}
+}`
+
+	cases := []struct {
+		name     string
+		selector string
+		claims   string
+		err      string
+	}{
+		{
+			name:     "empty selector",
+			selector: "",
+			claims:   sampleClaims,
+		},
+		{
+			name:     "value match",
+			selector: `value.team == "engineering"`,
+			claims:   sampleClaims,
+		},
+		{
+			name:     "list match",
+			selector: `"admins" in list.groups and value.name != "bob"`,
+			claims:   sampleClaims,
+		},
+		{
+			name:     "no match",
+			selector: `"operators" in list.groups`,
+			claims:   sampleClaims,
+			err:      "does not match the sample claims",
+		},
+		{
+			name:     "missing claim",
+			selector: `value.email == "alice@example.com"`,
+			claims:   sampleClaims,
+			err:      "does not match the sample claims",
+		},
+		{
+			name:     "unknown field",
+			selector: `value.team == "engineering"`,
+			claims:   `{"values": {"team": "engineering"}}`,
+			err:      "failed to decode sample_claims",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkACLBindingRuleSelector(tc.selector, tc.claims)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestValidateACLBindingRuleSelector(t *testing.T) {
+	cases := []struct {
+		selector string
+		err      bool
+	}{
+		{selector: ""},
+		{selector: `value.team == "engineering"`},
+		{selector: `"admins" in list.groups or value.name matches "^a"`},
+		{selector: `value.team ==`, err: true},
+		{selector: `(value.team == "engineering"`, err: true},
+		{selector: `"admins" in`, err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.selector, func(t *testing.T) {
+			_, errs := validateACLBindingRuleSelector(tc.selector, "selector")
+			if tc.err != (len(errs) > 0) {
+				t.Fatalf("expected error: %v, got %v", tc.err, errs)
+			}
+		})
+	}
+}
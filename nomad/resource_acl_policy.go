@@ -7,8 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"strings"
 
+	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -40,9 +40,10 @@ func resourceACLPolicy() *schema.Resource {
 			},
 
 			"rules_hcl": {
-				Description: "HCL or JSON representation of the rules to enforce on this policy. Use file() to specify a file as input.",
-				Required:    true,
-				Type:        schema.TypeString,
+				Description:  "HCL or JSON representation of the rules to enforce on this policy. Use file() to specify a file as input.",
+				Required:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validateACLPolicyRules,
 			},
 
 			"job_acl": {
@@ -76,6 +77,18 @@ func resourceACLPolicy() *schema.Resource {
 					},
 				},
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the ACL policy was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the ACL policy was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -227,6 +240,8 @@ func resourceACLPolicyRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("name", policy.Name)
 	d.Set("description", policy.Description)
 	d.Set("rules_hcl", policy.Rules)
+	d.Set("create_index", int(policy.CreateIndex))
+	d.Set("modify_index", int(policy.ModifyIndex))
 
 	if policy.JobACL != nil {
 		d.Set("job_acl", []map[string]string{{
@@ -250,7 +265,7 @@ func resourceACLPolicyExists(d *schema.ResourceData, meta interface{}) (bool, er
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			return false, nil
 		}
 
@@ -259,3 +274,17 @@ func resourceACLPolicyExists(d *schema.ResourceData, meta interface{}) (bool, er
 
 	return true, nil
 }
+
+// validateACLPolicyRules parses the rules of the policy with the same parser
+// used by the Nomad servers, so invalid blocks, capabilities, and namespace
+// patterns are reported during plan.
+func validateACLPolicyRules(i interface{}, k string) ([]string, []error) {
+	rules, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := acl.Parse(rules); err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid ACL policy: %v", k, err)}
+	}
+	return nil, nil
+}
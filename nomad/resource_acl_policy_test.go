@@ -303,3 +303,64 @@ func testResourceACLPolicy_updateCheck(name string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func TestValidateACLPolicyRules(t *testing.T) {
+	cases := []struct {
+		name  string
+		rules string
+		err   string
+	}{
+		{
+			name: "valid",
+			rules: `
+namespace "prod-*" {
+  policy       = "read"
+  capabilities = ["alloc-exec"]
+}
+
+node {
+  policy = "write"
+}
+`,
+		},
+		{
+			name:  "json",
+			rules: `{"namespace": {"default": {"policy": "read"}}}`,
+		},
+		{
+			name:  "syntax error",
+			rules: `namespace "default" { policy = "read"`,
+			err:   "Failed to parse ACL Policy",
+		},
+		{
+			name:  "invalid capability",
+			rules: `namespace "default" { capabilities = ["not-a-capability"] }`,
+			err:   "Invalid namespace capability",
+		},
+		{
+			name:  "invalid policy",
+			rules: `node { policy = "admin" }`,
+			err:   "Invalid node policy",
+		},
+		{
+			name:  "invalid namespace pattern",
+			rules: `namespace "prod!" { policy = "read" }`,
+			err:   "Invalid namespace name",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errs := validateACLPolicyRules(tc.rules, "rules_hcl")
+			if tc.err == "" {
+				if len(errs) > 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, errs)
+			}
+		})
+	}
+}
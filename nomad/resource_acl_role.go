@@ -4,8 +4,10 @@
 package nomad
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -20,6 +22,8 @@ func resourceACLRole() *schema.Resource {
 		Read:   resourceACLRoleRead,
 		Exists: resourceACLRoleExists,
 
+		CustomizeDiff: resourceACLRoleCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -49,10 +53,93 @@ func resourceACLRole() *schema.Resource {
 					},
 				},
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the ACL role was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the ACL role was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
 
+// resourceACLRoleCustomizeDiff checks that the policies referenced by name
+// exist, so a missing policy is reported during plan. Policies with names that
+// are not known yet, such as policies created in the same apply, are skipped.
+func resourceACLRoleCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("policy") {
+		return nil
+	}
+
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	policies := config.GetAttr("policy")
+	if policies.IsNull() || !policies.IsKnown() {
+		return nil
+	}
+
+	var names []string
+	for it := policies.ElementIterator(); it.Next(); {
+		_, policy := it.Element()
+		if policy.IsNull() || !policy.IsKnown() {
+			continue
+		}
+		name := policy.GetAttr("name")
+		if name.IsNull() || !name.IsKnown() {
+			continue
+		}
+		names = append(names, name.AsString())
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	if isOffline(meta) {
+		logOfflineSkip("the validation of the ACL role policies")
+		return nil
+	}
+
+	client := meta.(ProviderConfig).client
+	existing, _, err := client.ACLPolicies().List(nil)
+	if err != nil {
+		// The token used by the provider may not be allowed to list policies,
+		// in which case Nomad validates them when the role is written.
+		log.Printf("[WARN] Unable to list ACL policies to validate ACL role: %s", err)
+		return nil
+	}
+
+	return checkACLRolePolicies(names, existing)
+}
+
+// checkACLRolePolicies returns an error listing the policies that are not
+// found in existing.
+func checkACLRolePolicies(names []string, existing []*api.ACLPolicyListStub) error {
+	found := make(map[string]struct{}, len(existing))
+	for _, policy := range existing {
+		found[policy.Name] = struct{}{}
+	}
+
+	var missing []string
+	for _, name := range names {
+		if _, ok := found[name]; !ok {
+			missing = append(missing, fmt.Sprintf("%q", name))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	return fmt.Errorf("ACL policies %s referenced by the ACL role do not exist", strings.Join(missing, ", "))
+}
+
 func resourceACLRoleCreate(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(ProviderConfig)
 	client := providerConfig.client
@@ -134,6 +221,8 @@ func resourceACLRoleRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("name", role.Name)
 	d.Set("description", role.Description)
 	d.Set("policy", policies)
+	d.Set("create_index", int(role.CreateIndex))
+	d.Set("modify_index", int(role.ModifyIndex))
 
 	return nil
 }
@@ -148,7 +237,7 @@ func resourceACLRoleExists(d *schema.ResourceData, meta interface{}) (bool, erro
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			return false, nil
 		}
 
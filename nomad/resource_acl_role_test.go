@@ -6,9 +6,11 @@ package nomad
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -36,6 +38,47 @@ func TestResourceACLRole(t *testing.T) {
 	})
 }
 
+func TestResourceACLRole_missingPolicy(t *testing.T) {
+	testResourceName := acctest.RandomWithPrefix("tf-nomad-test")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.4.0-beta.1") },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "nomad_acl_role" "test" {
+  name = %q
+
+  policy {
+    name = %q
+  }
+}
+`, testResourceName, testResourceName+"-missing"),
+				ExpectError: regexp.MustCompile(`ACL policies "` + testResourceName + `-missing" referenced by the ACL role do not exist`),
+			},
+		},
+		CheckDestroy: resourceACLRoleCheckDestroy,
+	})
+}
+
+func TestCheckACLRolePolicies(t *testing.T) {
+	existing := []*api.ACLPolicyListStub{{Name: "read"}, {Name: "write"}}
+
+	if err := checkACLRolePolicies([]string{"read", "write"}, existing); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := checkACLRolePolicies([]string{"read", "ops", "admin"}, existing)
+	if err == nil {
+		t.Fatal("expected error for missing policies")
+	}
+	expected := `ACL policies "admin", "ops" referenced by the ACL role do not exist`
+	if err.Error() != expected {
+		t.Fatalf("expected error %q, got %q", expected, err)
+	}
+}
+
 func testResourceACLRoleConfig(policyName, roleName string) string {
 	return fmt.Sprintf(`
 resource "nomad_acl_policy" "test" {
@@ -4,9 +4,10 @@
 package nomad
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"strings"
+	"sort"
 	"time"
 
 	"github.com/hashicorp/nomad/api"
@@ -21,8 +22,10 @@ func resourceACLToken() *schema.Resource {
 		Read:   resourceACLTokenRead,
 		Exists: resourceACLTokenExists,
 
+		CustomizeDiff: resourceACLTokenCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: resourceACLTokenImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -37,6 +40,11 @@ func resourceACLToken() *schema.Resource {
 				Sensitive:   true,
 				Type:        schema.TypeString,
 			},
+			"secret_id_unavailable": {
+				Description: "Whether the secret ID of the token couldn't be read, for example because it was imported by its accessor ID with a token that isn't a management token.",
+				Computed:    true,
+				Type:        schema.TypeBool,
+			},
 			"name": {
 				Description: "Human-readable name for this token.",
 				Optional:    true,
@@ -72,6 +80,12 @@ func resourceACLToken() *schema.Resource {
 					},
 				},
 			},
+			"effective_policies": {
+				Description: "The ACL policies granted to the token, directly or through its roles.",
+				Computed:    true,
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"global": {
 				Description: "Whether the token should be replicated to all regions or not.",
 				Optional:    true,
@@ -90,16 +104,91 @@ func resourceACLToken() *schema.Resource {
 				Default:     "0s",
 				ForceNew:    true,
 				Type:        schema.TypeString,
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					// errors don't really matter here; on error, the returned duration is 0
+					o, _ := time.ParseDuration(oldValue)
+					n, _ := time.ParseDuration(newValue)
+					return o == n
+				},
+			},
+			"rotate_before": {
+				Description: `Recreate the token when it is within this duration of its expiration time, such as "10m" or "1h".`,
+				Optional:    true,
+				Type:        schema.TypeString,
 			},
 			"expiration_time": {
 				Description: "The point after which a token is considered expired and eligible for destruction.",
 				Computed:    true,
 				Type:        schema.TypeString,
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the ACL token was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the ACL token was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
 
+func resourceACLTokenCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.HasChange("policies") || d.HasChange("role") {
+		if err := d.SetNewComputed("effective_policies"); err != nil {
+			return err
+		}
+	}
+
+	rotateBeforeString := d.Get("rotate_before").(string)
+	if rotateBeforeString == "" {
+		return nil
+	}
+
+	rotateBefore, err := time.ParseDuration(rotateBeforeString)
+	if err != nil {
+		return fmt.Errorf("failed to parse rotate_before: %v", err)
+	}
+
+	// Nothing to rotate until the token has been created.
+	if d.Id() == "" {
+		return nil
+	}
+
+	rotate, err := aclTokenNeedsRotation(d.Get("expiration_time").(string), rotateBefore, time.Now())
+	if err != nil {
+		return err
+	}
+	if rotate {
+		log.Printf("[DEBUG] ACL token %q expires within %s, recreating", d.Id(), rotateBefore)
+		if err := d.SetNewComputed("expiration_time"); err != nil {
+			return err
+		}
+		return d.ForceNew("expiration_time")
+	}
+
+	return nil
+}
+
+// aclTokenNeedsRotation returns true if the token expiration time is within
+// rotateBefore of now. Tokens without an expiration time never need rotation.
+func aclTokenNeedsRotation(expirationTime string, rotateBefore time.Duration, now time.Time) (bool, error) {
+	if expirationTime == "" {
+		return false, nil
+	}
+
+	expiration, err := time.Parse(time.RFC3339, expirationTime)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse expiration_time: %v", err)
+	}
+
+	return !now.Add(rotateBefore).Before(expiration), nil
+}
+
 func resourceACLTokenCreate(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(ProviderConfig)
 	client := providerConfig.client
@@ -181,20 +270,119 @@ func resourceACLTokenRead(d *schema.ResourceData, meta interface{}) error {
 		roles[i] = map[string]interface{}{"id": roleLink.ID, "name": roleLink.Name}
 	}
 
+	effectivePolicies, complete := aclTokenEffectivePolicies(client, token)
+
 	d.Set("accessor_id", token.AccessorID)
-	d.Set("secret_id", token.SecretID)
+	if token.SecretID != "" {
+		d.Set("secret_id", token.SecretID)
+	} else {
+		// Only tokens with a management token can read the secret of other
+		// tokens, keep the one from state instead of storing an empty secret.
+		log.Printf("[WARN] The secret of ACL token %q is not readable, secret_id will not be updated", accessor)
+	}
+	d.Set("secret_id_unavailable", d.Get("secret_id").(string) == "")
 	d.Set("name", token.Name)
 	d.Set("type", token.Type)
 	d.Set("policies", token.Policies)
 	d.Set("role", roles)
+	if complete || d.Get("effective_policies").(*schema.Set).Len() == 0 {
+		d.Set("effective_policies", effectivePolicies)
+	} else {
+		// Keep the policies from state rather than dropping the ones of the
+		// roles that couldn't be read.
+		log.Printf("[WARN] Some roles of ACL token %q are not readable, effective_policies will not be updated", accessor)
+	}
 	d.Set("global", token.Global)
 	d.Set("create_time", token.CreateTime.UTC().String())
 	d.Set("expiration_ttl", token.ExpirationTTL.String())
 	d.Set("expiration_time", expirationTime)
+	d.Set("create_index", int(token.CreateIndex))
+	d.Set("modify_index", int(token.ModifyIndex))
 
 	return nil
 }
 
+// resourceACLTokenImport imports ACL tokens by their accessor ID. Since the
+// secret ID is often the only value at hand, it's also accepted, resolved to
+// the accessor ID of the token and stored as its secret_id.
+func resourceACLTokenImport(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	client := meta.(ProviderConfig).client
+	id := d.Id()
+
+	log.Printf("[DEBUG] Reading ACL token %q to import it", id)
+	_, _, err := client.ACLTokens().Info(id, nil)
+	if err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+	if !isNotFoundError(err) {
+		return nil, fmt.Errorf("error reading ACL token %q: %s", id, err)
+	}
+
+	token, _, err := client.ACLTokens().Self(&api.QueryOptions{AuthToken: id})
+	if err != nil || token == nil {
+		return nil, fmt.Errorf("no ACL token found with accessor ID %q, ACL tokens are imported using their accessor ID", id)
+	}
+	log.Printf("[WARN] The ID used to import ACL token %q is its secret ID, importing it by its accessor ID", token.AccessorID)
+
+	// The secret is known, store it since the token used by the provider may
+	// not be allowed to read it back.
+	d.SetId(token.AccessorID)
+	d.Set("secret_id", id)
+	return []*schema.ResourceData{d}, nil
+}
+
+// aclTokenEffectivePolicies returns the policies granted to the token, either
+// directly or through the roles linked to it, and whether all of its roles
+// could be read. The roles that can't be read, for example because the token
+// used by the provider isn't allowed to, are skipped so they don't prevent
+// refreshing the token.
+func aclTokenEffectivePolicies(client *api.Client, token *api.ACLToken) ([]string, bool) {
+	complete := true
+	roles := make([]*api.ACLRole, 0, len(token.Roles))
+	for _, roleLink := range token.Roles {
+		log.Printf("[DEBUG] Reading ACL role %q of ACL token %q", roleLink.ID, token.AccessorID)
+		role, _, err := client.ACLRoles().Get(roleLink.ID, nil)
+		if err != nil {
+			// The role may have been deleted since it was linked to the token,
+			// in which case it doesn't grant any policy anymore.
+			if !isNotFoundError(err) {
+				log.Printf("[WARN] Failed to read ACL role %q of ACL token %q, its policies are not part of effective_policies: %s", roleLink.ID, token.AccessorID, err)
+				complete = false
+			}
+			continue
+		}
+		roles = append(roles, role)
+	}
+
+	return mergeACLTokenPolicies(token.Policies, roles), complete
+}
+
+// mergeACLTokenPolicies returns the sorted and deduplicated names of the
+// policies given and of the policies of the roles.
+func mergeACLTokenPolicies(policies []string, roles []*api.ACLRole) []string {
+	seen := make(map[string]struct{}, len(policies))
+	for _, policy := range policies {
+		seen[policy] = struct{}{}
+	}
+	for _, role := range roles {
+		if role == nil {
+			continue
+		}
+		for _, link := range role.Policies {
+			if link != nil {
+				seen[link.Name] = struct{}{}
+			}
+		}
+	}
+
+	result := make([]string, 0, len(seen))
+	for policy := range seen {
+		result = append(result, policy)
+	}
+	sort.Strings(result)
+	return result
+}
+
 func resourceACLTokenExists(d *schema.ResourceData, meta interface{}) (bool, error) {
 	providerConfig := meta.(ProviderConfig)
 	client := providerConfig.client
@@ -205,7 +393,7 @@ func resourceACLTokenExists(d *schema.ResourceData, meta interface{}) (bool, err
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			return false, nil
 		}
 
@@ -4,11 +4,18 @@
 package nomad
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -27,6 +34,25 @@ func TestResourceACLToken_import(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				// Importing with the secret ID resolves the accessor ID.
+				ResourceName: "nomad_acl_token.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["nomad_acl_token.test"]
+					if !ok {
+						return "", errors.New("resource not found in state")
+					}
+					return rs.Primary.Attributes["secret_id"], nil
+				},
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "nomad_acl_token.test",
+				ImportState:   true,
+				ImportStateId: "00000000-0000-0000-0000-000000000000",
+				ExpectError:   regexp.MustCompile("no ACL token found with accessor ID"),
+			},
 		},
 
 		CheckDestroy: testResourceACLTokenCheckDestroy,
@@ -443,6 +469,14 @@ resource "nomad_acl_token" "test" {
 			return fmt.Errorf("expected roles.# to be %q, is %q in state",
 				"1", instanceState.Attributes["role.#"])
 		}
+		if instanceState.Attributes["effective_policies.#"] != "1" {
+			return fmt.Errorf("expected effective_policies.# to be %q, is %q in state",
+				"1", instanceState.Attributes["effective_policies.#"])
+		}
+		if instanceState.Attributes["effective_policies.0"] != "terraform-token-test" {
+			return fmt.Errorf("expected effective_policies.0 to be %q, is %q in state",
+				"terraform-token-test", instanceState.Attributes["effective_policies.0"])
+		}
 
 		client := testProvider.Meta().(ProviderConfig).client
 		token, _, err := client.ACLTokens().Info(instanceState.ID, nil)
@@ -470,3 +504,174 @@ resource "nomad_acl_token" "test" {
 
 	return config, checkFn
 }
+
+func TestACLTokenNeedsRotation(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name           string
+		expirationTime string
+		rotateBefore   time.Duration
+		want           bool
+		wantErr        bool
+	}{
+		{
+			name:         "no expiration",
+			rotateBefore: time.Hour,
+			want:         false,
+		},
+		{
+			name:           "outside window",
+			expirationTime: "2025-05-01T14:00:00Z",
+			rotateBefore:   time.Hour,
+			want:           false,
+		},
+		{
+			name:           "inside window",
+			expirationTime: "2025-05-01T12:30:00Z",
+			rotateBefore:   time.Hour,
+			want:           true,
+		},
+		{
+			name:           "expired",
+			expirationTime: "2025-05-01T11:00:00Z",
+			rotateBefore:   time.Hour,
+			want:           true,
+		},
+		{
+			name:           "invalid expiration",
+			expirationTime: "tomorrow",
+			rotateBefore:   time.Hour,
+			wantErr:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := aclTokenNeedsRotation(tc.expirationTime, tc.rotateBefore, now)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMergeACLTokenPolicies(t *testing.T) {
+	roles := []*api.ACLRole{
+		{
+			Name: "ops",
+			Policies: []*api.ACLRolePolicyLink{
+				{Name: "read-jobs"},
+				{Name: "submit-jobs"},
+			},
+		},
+		{
+			Name:     "audit",
+			Policies: []*api.ACLRolePolicyLink{{Name: "read-nodes"}},
+		},
+		nil,
+	}
+
+	got := mergeACLTokenPolicies([]string{"submit-jobs", "admin"}, roles)
+	want := []string{"admin", "read-jobs", "read-nodes", "submit-jobs"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := mergeACLTokenPolicies(nil, nil); len(got) != 0 {
+		t.Errorf("expected no policies, got %v", got)
+	}
+}
+
+func TestACLTokenEffectivePolicies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/acl/role/ops":
+			w.Write([]byte(`{"ID": "ops", "Policies": [{"Name": "read-jobs"}]}`))
+		case "/v1/acl/role/deleted":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("Permission denied"))
+		}
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := &api.ACLToken{
+		Policies: []string{"admin"},
+		Roles:    []*api.ACLTokenRoleLink{{ID: "ops"}, {ID: "deleted"}},
+	}
+	got, complete := aclTokenEffectivePolicies(client, token)
+	if want := []string{"admin", "read-jobs"}; !reflect.DeepEqual(got, want) || !complete {
+		t.Errorf("expected %v and complete policies, got %v and %v", want, got, complete)
+	}
+
+	// The roles that can't be read are skipped.
+	token.Roles = append(token.Roles, &api.ACLTokenRoleLink{ID: "forbidden"})
+	got, complete = aclTokenEffectivePolicies(client, token)
+	if want := []string{"admin", "read-jobs"}; !reflect.DeepEqual(got, want) || complete {
+		t.Errorf("expected %v and incomplete policies, got %v and %v", want, got, complete)
+	}
+}
+
+func TestResourceACLTokenImport_secretID(t *testing.T) {
+	const accessor, secret = "8b4e5c8e-a5f3-7a1e-58a6-fe4b6ccfe5f1", "2b778dd9-f5f1-6f29-b4b4-9a5fa948757a"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/v1/acl/token/self" && req.Header.Get("X-Nomad-Token") == secret:
+			w.Write([]byte(`{"AccessorID": "` + accessor + `", "SecretID": "` + secret + `"}`))
+		case req.URL.Path == "/v1/acl/token/"+accessor:
+			// The token used by the provider can't read the secret.
+			w.Write([]byte(`{"AccessorID": "` + accessor + `", "Type": "client", "CreateTime": "2024-01-01T00:00:00Z"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := ProviderConfig{client: client}
+
+	r := resourceACLToken()
+	d := r.TestResourceData()
+	d.SetId(secret)
+	if _, err := resourceACLTokenImport(context.Background(), d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if err := resourceACLTokenRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != accessor || d.Get("secret_id") != secret || d.Get("secret_id_unavailable") != false {
+		t.Errorf("expected the token to be imported with its secret, got %q, %q and %v", d.Id(), d.Get("secret_id"), d.Get("secret_id_unavailable"))
+	}
+
+	// The secret is unavailable when the token is imported by its accessor.
+	d = r.TestResourceData()
+	d.SetId(accessor)
+	if _, err := resourceACLTokenImport(context.Background(), d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if err := resourceACLTokenRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("secret_id") != "" || d.Get("secret_id_unavailable") != true {
+		t.Errorf("expected the secret to be unavailable, got %q and %v", d.Get("secret_id"), d.Get("secret_id_unavailable"))
+	}
+}
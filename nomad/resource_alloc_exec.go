@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
+)
+
+func resourceAllocExec() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAllocExecCreate,
+		DeleteContext: resourceAllocExecDelete,
+		ReadContext:   resourceAllocExecRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"alloc_id": {
+				Description: "The ID of the allocation to run the command in.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"namespace": {
+				Description:      "The namespace of the allocation.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          api.DefaultNamespace,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
+			},
+			"task": {
+				Description: "The name of the task to run the command in.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"command": {
+				Description: "The command to run and its arguments.",
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"triggers": {
+				Description: "Arbitrary map of values that, when changed, will trigger a new run of the command.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"exit_code": {
+				Description: "The exit code of the command.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"stdout": {
+				Description: "The standard output of the command.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"stderr": {
+				Description: "The standard error of the command.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceAllocExecCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	// The websockets of alloc exec need the HTTP transport of the client.
+	config := meta.(ProviderConfig)
+	client, err := withoutRateLimitRetries(config.client, config.config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	allocID := d.Get("alloc_id").(string)
+	task := d.Get("task").(string)
+	opts := &api.QueryOptions{Namespace: d.Get("namespace").(string)}
+
+	var command []string
+	for _, arg := range d.Get("command").([]any) {
+		command = append(command, arg.(string))
+	}
+
+	log.Printf("[DEBUG] Reading allocation %q", allocID)
+	alloc, _, err := client.Allocations().Info(allocID, opts)
+	if err != nil {
+		return diag.Errorf("error reading allocation %q: %s", allocID, err)
+	}
+	log.Printf("[DEBUG] Read allocation %q", allocID)
+
+	var stdout, stderr bytes.Buffer
+	log.Printf("[DEBUG] Running %q in task %q of allocation %q", strings.Join(command, " "), task, allocID)
+	exitCode, err := client.Allocations().Exec(ctx, alloc, task, false, command,
+		strings.NewReader(""), &stdout, &stderr, nil, opts)
+	if err != nil {
+		return diag.Errorf("error running command in task %q of allocation %q: %s", task, allocID, err)
+	}
+	log.Printf("[DEBUG] Ran command in task %q of allocation %q, exit code is %d", task, allocID, exitCode)
+
+	if exitCode != 0 {
+		return diag.Errorf("command in task %q of allocation %q exited with code %d: %s",
+			task, allocID, exitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	d.SetId(id.UniqueId())
+	d.Set("exit_code", exitCode)
+	d.Set("stdout", stdout.String())
+	d.Set("stderr", stderr.String())
+
+	return nil
+}
+
+// resourceAllocExecDelete only removes the command from state, since running
+// it can't be undone.
+func resourceAllocExecDelete(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// resourceAllocExecRead doesn't refresh anything since the output of the
+// command is only known when it runs.
+func resourceAllocExecRead(_ context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestResourceAllocExec_basic(t *testing.T) {
+	jobID := acctest.RandomWithPrefix("tf-nomad-test")
+	resourceName := "nomad_alloc_exec.test"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testResourceAllocExec_runJob(t, jobID) },
+				Config:    testResourceAllocExec_config(jobID, `["/bin/echo", "hello"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "exit_code", "0"),
+					resource.TestCheckResourceAttr(resourceName, "stdout", "hello\n"),
+					resource.TestCheckResourceAttr(resourceName, "stderr", ""),
+				),
+			},
+			{
+				Config:      testResourceAllocExec_config(jobID, `["/bin/sh", "-c", "echo oops >&2; exit 3"]`),
+				ExpectError: regexp.MustCompile("exited with code 3: oops"),
+			},
+		},
+		CheckDestroy: testResourceDeploymentPromote_deregister(jobID),
+	})
+}
+
+func testResourceAllocExec_config(jobID, command string) string {
+	return fmt.Sprintf(`
+data "nomad_allocations" "test" {
+  filter = "JobID == \"%s\" and ClientStatus == \"running\""
+}
+
+resource "nomad_alloc_exec" "test" {
+  alloc_id = data.nomad_allocations.test.allocations[0].id
+  task     = "test"
+  command  = %s
+}
+`, jobID, command)
+}
+
+// testResourceAllocExec_runJob registers a job with a single task and waits
+// for its allocation to be running.
+func testResourceAllocExec_runJob(t *testing.T, jobID string) {
+	testResourceJobEval_register(t, jobID)
+
+	client := testProvider.Meta().(ProviderConfig).client
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			allocs, _, err := client.Jobs().Allocations(jobID, false, nil)
+			if err != nil {
+				return err
+			}
+			for _, alloc := range allocs {
+				if alloc.ClientStatus == api.AllocClientStatusRunning {
+					return nil
+				}
+			}
+			return fmt.Errorf("no running allocation for job %q yet", jobID)
+		}),
+		wait.Timeout(2*time.Minute),
+		wait.Gap(time.Second),
+	))
+}
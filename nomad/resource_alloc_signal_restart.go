@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
+)
+
+const (
+	allocActionSignal  = "signal"
+	allocActionRestart = "restart"
+)
+
+func resourceAllocSignalRestart() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAllocSignalRestartCreate,
+		DeleteContext: resourceAllocSignalRestartDelete,
+		ReadContext:   resourceAllocSignalRestartRead,
+
+		CustomizeDiff: resourceAllocSignalRestartCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"job_id": {
+				Description: "The ID of the job of the allocations.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"namespace": {
+				Description:      "The namespace of the job.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          api.DefaultNamespace,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
+			},
+			"group": {
+				Description: "Only select the allocations of this task group. All the allocations of the job are selected if not set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"task": {
+				Description: "The task to signal or restart. All the tasks of the allocations are selected if not set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"action": {
+				Description: "The action to apply to the allocations, one of 'signal' or 'restart'.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					allocActionSignal,
+					allocActionRestart,
+				}, false),
+			},
+			"signal": {
+				Description: "The signal to send, such as 'SIGHUP'. Required when action is 'signal'.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Description: "Arbitrary map of values that, when changed, will trigger the action again.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"alloc_ids": {
+				Description: "The IDs of the allocations the action was applied to.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceAllocSignalRestartCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if !d.NewValueKnown("action") || !d.NewValueKnown("signal") {
+		return nil
+	}
+
+	action := d.Get("action").(string)
+	signal := d.Get("signal").(string)
+	switch {
+	case action == allocActionSignal && signal == "":
+		return errors.New("signal must be set when action is \"signal\"")
+	case action == allocActionRestart && signal != "":
+		return errors.New("signal can only be set when action is \"signal\"")
+	}
+	return nil
+}
+
+func resourceAllocSignalRestartCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(ProviderConfig).client
+
+	jobID := d.Get("job_id").(string)
+	group := d.Get("group").(string)
+	task := d.Get("task").(string)
+	action := d.Get("action").(string)
+	signal := d.Get("signal").(string)
+	opts := &api.QueryOptions{Namespace: d.Get("namespace").(string)}
+
+	log.Printf("[DEBUG] Reading allocations of job %q", jobID)
+	stubs, _, err := client.Jobs().Allocations(jobID, false, opts)
+	if err != nil {
+		return diag.Errorf("error reading allocations of job %q: %s", jobID, err)
+	}
+	log.Printf("[DEBUG] Read allocations of job %q", jobID)
+
+	allocIDs := selectRunningAllocs(stubs, group)
+	if len(allocIDs) == 0 {
+		return diag.Errorf("no running allocations found for job %q", jobID)
+	}
+
+	for _, allocID := range allocIDs {
+		alloc := &api.Allocation{ID: allocID}
+
+		log.Printf("[DEBUG] Applying action %q to allocation %q", action, allocID)
+		switch action {
+		case allocActionSignal:
+			err = client.Allocations().Signal(alloc, opts, task, signal)
+		case allocActionRestart:
+			err = client.Allocations().Restart(alloc, task, opts)
+		}
+		if err != nil {
+			return diag.Errorf("error applying action %q to allocation %q: %s", action, allocID, err)
+		}
+		log.Printf("[DEBUG] Applied action %q to allocation %q", action, allocID)
+	}
+
+	d.SetId(id.UniqueId())
+	d.Set("alloc_ids", allocIDs)
+
+	return nil
+}
+
+// resourceAllocSignalRestartDelete only removes the action from state, since
+// signals and restarts can't be undone.
+func resourceAllocSignalRestartDelete(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// resourceAllocSignalRestartRead doesn't refresh anything, allocations being
+// replaced doesn't mean the action needs to be repeated.
+func resourceAllocSignalRestartRead(_ context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
+	return nil
+}
+
+// selectRunningAllocs returns the sorted IDs of the running allocations, only
+// keeping those of the task group given if it's set.
+func selectRunningAllocs(stubs []*api.AllocationListStub, group string) []string {
+	var ids []string
+	for _, stub := range stubs {
+		if stub.ClientStatus != api.AllocClientStatusRunning {
+			continue
+		}
+		if group != "" && stub.TaskGroup != group {
+			continue
+		}
+		ids = append(ids, stub.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
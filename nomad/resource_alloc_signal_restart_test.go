@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/shoenig/test/must"
+)
+
+func TestResourceAllocSignalRestart_basic(t *testing.T) {
+	jobID := acctest.RandomWithPrefix("tf-nomad-test")
+	resourceName := "nomad_alloc_signal_restart.test"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testResourceAllocSignalRestart_config(jobID, "signal", ""),
+				ExpectError: regexp.MustCompile("signal must be set"),
+				PlanOnly:    true,
+			},
+			{
+				PreConfig: func() { testResourceAllocExec_runJob(t, jobID) },
+				Config:    testResourceAllocSignalRestart_config(jobID, "restart", ""),
+				Check:     resource.TestCheckResourceAttr(resourceName, "alloc_ids.#", "1"),
+			},
+			{
+				// sleep ignores SIGWINCH, so the task keeps running.
+				Config: testResourceAllocSignalRestart_config(jobID, "signal", "SIGWINCH"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "alloc_ids.#", "1"),
+			},
+		},
+		CheckDestroy: testResourceDeploymentPromote_deregister(jobID),
+	})
+}
+
+func testResourceAllocSignalRestart_config(jobID, action, signal string) string {
+	return fmt.Sprintf(`
+resource "nomad_alloc_signal_restart" "test" {
+  job_id = %q
+  group  = "test"
+  task   = "test"
+  action = %q
+  signal = %q
+}
+`, jobID, action, signal)
+}
+
+func TestSelectRunningAllocs(t *testing.T) {
+	stubs := []*api.AllocationListStub{
+		{ID: "c", TaskGroup: "web", ClientStatus: api.AllocClientStatusRunning},
+		{ID: "a", TaskGroup: "web", ClientStatus: api.AllocClientStatusRunning},
+		{ID: "b", TaskGroup: "web", ClientStatus: api.AllocClientStatusComplete},
+		{ID: "d", TaskGroup: "db", ClientStatus: api.AllocClientStatusRunning},
+	}
+
+	must.Eq(t, []string{"a", "c", "d"}, selectRunningAllocs(stubs, ""))
+	must.Eq(t, []string{"a", "c"}, selectRunningAllocs(stubs, "web"))
+	must.SliceEmpty(t, selectRunningAllocs(stubs, "cache"))
+}
@@ -6,13 +6,14 @@ package nomad
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"hash/crc32"
 	"log"
-	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -39,11 +40,12 @@ func resourceCSIVolume() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"namespace": {
-				ForceNew:    true,
-				Description: "The namespace in which to create the volume.",
-				Optional:    true,
-				Default:     "default",
-				Type:        schema.TypeString,
+				ForceNew:         true,
+				Description:      "The namespace in which to create the volume.",
+				Optional:         true,
+				Default:          api.DefaultNamespace,
+				Type:             schema.TypeString,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
 			},
 
 			"volume_id": {
@@ -176,15 +178,33 @@ func resourceCSIVolume() *schema.Resource {
 			},
 
 			"secrets": {
-				Description: "An optional key-value map of strings used as credentials for publishing and unpublishing volumes.",
-				Optional:    true,
-				Type:        schema.TypeMap,
-				Sensitive:   true,
+				Description:   "An optional key-value map of strings used as credentials for publishing and unpublishing volumes.",
+				Optional:      true,
+				Type:          schema.TypeMap,
+				Sensitive:     true,
+				ConflictsWith: []string{"secrets_wo"},
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 
+			"secrets_wo": {
+				Description:  "A JSON encoded map of strings used as credentials for publishing and unpublishing volumes. This value is write-only and is never stored in state.",
+				Optional:     true,
+				Type:         schema.TypeString,
+				WriteOnly:    true,
+				ValidateFunc: validation.StringIsJSON,
+				RequiredWith: []string{"secrets_wo_version"},
+			},
+
+			"secrets_wo_version": {
+				Description:  "The version of secrets_wo, must be changed for new secrets_wo values to be sent to Nomad.",
+				Optional:     true,
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"secrets_wo"},
+			},
+
 			"parameters": {
 				Description: "An optional key-value map of strings passed directly to the CSI plugin to configure the volume.",
 				Optional:    true,
@@ -266,6 +286,60 @@ func resourceCSIVolume() *schema.Resource {
 				},
 			},
 
+			"wait_for_plugin": {
+				Description: "Wait for the CSI plugin to become healthy before creating the volume.",
+				Optional:    true,
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timeout": {
+							Description: "How long to wait for the plugin to become healthy.",
+							Optional:    true,
+							Type:        schema.TypeString,
+							Default:     "5m",
+						},
+						"min_healthy_controllers": {
+							Description:  "The minimum number of healthy controller plugins.",
+							Optional:     true,
+							Type:         schema.TypeInt,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"min_healthy_nodes": {
+							Description:  "The minimum number of healthy node plugins.",
+							Optional:     true,
+							Type:         schema.TypeInt,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+
+			"snapshot_on_destroy": {
+				Description: "Take a snapshot of the volume before deleting it. The snapshot is taken with secrets, secrets_wo is not available when the volume is destroyed.",
+				Optional:    true,
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name_prefix": {
+							Description: "The prefix of the name of the snapshot, followed by the volume ID and the time of the snapshot.",
+							Optional:    true,
+							Type:        schema.TypeString,
+						},
+					},
+				},
+			},
+
+			"deregister_on_destroy": deregisterOnDestroySchema("volume"),
+			"retain_on_destroy":     retainOnDestroySchema("volume"),
+
+			"purge_on_destroy": purgeOnDestroySchema("If true, the volume will be deleted from the storage provider on destroy. If false, it is only deregistered from Nomad.", true),
+
+			"force": forceDestroySchema("volume"),
+
 			"capacity": {
 				Computed: true,
 				Type:     schema.TypeInt,
@@ -345,6 +419,18 @@ func resourceCSIVolume() *schema.Resource {
 				Computed:    true,
 				Type:        schema.TypeMap,
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the volume was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the volume was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -368,7 +454,7 @@ func resourceCSIVolumeRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			log.Printf("[DEBUG] CSI volume %q does not exist, so removing", id)
 			d.SetId("")
 			return nil
@@ -382,6 +468,8 @@ func resourceCSIVolumeRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("namespace", volume.Namespace)
 	d.Set("volume_id", volume.ID)
 	d.Set("external_id", volume.ExternalID)
+	d.Set("create_index", int(volume.CreateIndex))
+	d.Set("modify_index", int(volume.ModifyIndex))
 
 	d.Set("capacity", int(volume.Capacity))
 	d.Set("capacity_min_bytes", volume.RequestedCapacityMin)
@@ -460,6 +548,16 @@ func resourceCSIVolumeCreate(ctx context.Context, d *schema.ResourceData, meta i
 		Context:               helper.ToMapStringString(d.Get("context")),
 	}
 
+	secretsWO, ok, err := getWriteOnlyString(d, cty.GetAttrPath("secrets_wo"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if ok {
+		if err := json.Unmarshal([]byte(secretsWO), &volume.Secrets); err != nil {
+			return diag.Errorf("failed to parse secrets_wo, it must be a JSON encoded map of strings: %v", err)
+		}
+	}
+
 	// Unpack the mount_options if we have any and configure the volume struct.
 	mountOpts, ok := d.GetOk("mount_options")
 	if ok {
@@ -485,6 +583,10 @@ func resourceCSIVolumeCreate(ctx context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
+	if err := waitForCSIPlugin(ctx, client, providerConfig.poll, volume.PluginID, d.Get("wait_for_plugin")); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Create the volume.
 	log.Printf("[DEBUG] creating CSI volume %q in namespace %q", volume.ID, volume.Namespace)
 	opts := &api.WriteOptions{
@@ -527,10 +629,90 @@ func resourceCSIVolumeCreate(ctx context.Context, d *schema.ResourceData, meta i
 	return warnings
 }
 
+// waitForCSIPlugin blocks until the plugin has the minimum number of healthy
+// controllers and nodes set in the wait_for_plugin block. It returns
+// immediately if the block is not set.
+func waitForCSIPlugin(ctx context.Context, client *api.Client, poll pollConfig, pluginID string, raw interface{}) error {
+	waitList, ok := raw.([]interface{})
+	if !ok || len(waitList) == 0 || waitList[0] == nil {
+		return nil
+	}
+	wait := waitList[0].(map[string]interface{})
+
+	timeout, err := time.ParseDuration(wait["timeout"].(string))
+	if err != nil {
+		return fmt.Errorf("failed to parse wait_for_plugin timeout: %v", err)
+	}
+	minControllers := wait["min_healthy_controllers"].(int)
+	minNodes := wait["min_healthy_nodes"].(int)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// The plugin is watched with blocking queries, and the list of plugins
+	// while it doesn't exist yet.
+	opts := (&api.QueryOptions{}).WithContext(ctx)
+	listOpts := (&api.QueryOptions{}).WithContext(ctx)
+	pacer := poll.pacer()
+	lastErr := fmt.Errorf("CSI plugin %q not found", pluginID)
+
+	log.Printf("[DEBUG] waiting for CSI plugin %q to become healthy", pluginID)
+	for {
+		plugin, qm, err := client.CSIPlugins().Info(pluginID, opts)
+		switch {
+		case ctx.Err() != nil:
+			return waitDoneError(ctx, timeout, fmt.Sprintf("CSI plugin %q", pluginID), lastErr)
+		case isNotFoundError(err):
+			lastErr = fmt.Errorf("CSI plugin %q not found", pluginID)
+			_, listQM, err := client.CSIPlugins().List(listOpts)
+			if err != nil {
+				if ctx.Err() != nil {
+					continue
+				}
+				return fmt.Errorf("error listing CSI plugins: %s", err)
+			}
+			listOpts.WaitIndex = listQM.LastIndex
+			opts.WaitIndex = 0
+			pacer.wait(ctx)
+			continue
+		case err != nil:
+			return fmt.Errorf("error reading CSI plugin %q: %s", pluginID, err)
+		}
+
+		lastErr = checkCSIPluginHealthy(plugin, minControllers, minNodes)
+		if lastErr == nil {
+			log.Printf("[DEBUG] CSI plugin %q is healthy", pluginID)
+			return nil
+		}
+		log.Printf("[DEBUG] %s", lastErr)
+		opts.WaitIndex = qm.LastIndex
+		pacer.wait(ctx)
+	}
+}
+
+// checkCSIPluginHealthy returns an error if the plugin doesn't have the
+// minimum number of healthy controllers and nodes.
+func checkCSIPluginHealthy(plugin *api.CSIPlugin, minControllers, minNodes int) error {
+	if plugin.ControllersHealthy < minControllers {
+		return fmt.Errorf("CSI plugin %q has %d healthy controllers, waiting for %d",
+			plugin.ID, plugin.ControllersHealthy, minControllers)
+	}
+	if plugin.NodesHealthy < minNodes {
+		return fmt.Errorf("CSI plugin %q has %d healthy nodes, waiting for %d",
+			plugin.ID, plugin.NodesHealthy, minNodes)
+	}
+	return nil
+}
+
 func resourceCSIVolumeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConfig := meta.(ProviderConfig)
 	client := providerConfig.client
 
+	// If deregistration is disabled, then do nothing
+	if skipDeregister(d, "volume") {
+		return nil
+	}
+
 	id := d.Id()
 	log.Printf("[DEBUG] deleting CSI volume: %q", id)
 	opts := &api.WriteOptions{
@@ -540,6 +722,23 @@ func resourceCSIVolumeDelete(ctx context.Context, d *schema.ResourceData, meta i
 		opts.Namespace = "default"
 	}
 
+	if !destroyFlag(d, "purge_on_destroy", true) {
+		log.Printf("[DEBUG] deregistering CSI volume %q without deleting it since 'purge_on_destroy' is false", id)
+		return diag.FromErr(retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete)-time.Minute, func() *retry.RetryError {
+			err := client.CSIVolumes().Deregister(id, destroyFlag(d, "force", true), opts)
+			if err != nil {
+				return retry.RetryableError(fmt.Errorf("error deregistering CSI volume: %s", err))
+			}
+			return nil
+		}))
+	}
+
+	if snapshot, ok := d.GetOk("snapshot_on_destroy"); ok {
+		if err := snapshotCSIVolume(client, d, snapshot.([]interface{}), opts); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return diag.FromErr(retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete)-time.Minute, func() *retry.RetryError {
 		err := client.CSIVolumes().Delete(id, opts)
 		if err != nil {
@@ -548,3 +747,45 @@ func resourceCSIVolumeDelete(ctx context.Context, d *schema.ResourceData, meta i
 		return nil
 	}))
 }
+
+// snapshotCSIVolume takes a snapshot of the volume as configured by
+// snapshot_on_destroy, which must succeed before the volume is deleted.
+func snapshotCSIVolume(client *api.Client, d *schema.ResourceData, config []interface{}, opts *api.WriteOptions) error {
+	var prefix string
+	if len(config) > 0 && config[0] != nil {
+		prefix = config[0].(map[string]interface{})["name_prefix"].(string)
+	}
+
+	snapshot := &api.CSISnapshot{
+		SourceVolumeID: d.Id(),
+		PluginID:       d.Get("plugin_id").(string),
+		Name:           csiVolumeSnapshotName(prefix, d.Id(), time.Now()),
+		Secrets:        helper.ToMapStringString(d.Get("secrets")),
+	}
+
+	// The configuration, and so secrets_wo, is only available when the
+	// volume is deleted to be replaced, not when it is destroyed.
+	if secretsWO, ok, err := getWriteOnlyString(d, cty.GetAttrPath("secrets_wo")); err == nil && ok {
+		if err := json.Unmarshal([]byte(secretsWO), &snapshot.Secrets); err != nil {
+			return fmt.Errorf("failed to parse secrets_wo, it must be a JSON encoded map of strings: %v", err)
+		}
+	} else if d.Get("secrets_wo_version").(int) != 0 {
+		log.Printf("[WARN] secrets_wo is not available to snapshot CSI volume %q, taking the snapshot without secrets", snapshot.SourceVolumeID)
+	}
+
+	log.Printf("[DEBUG] creating snapshot %q of CSI volume %q", snapshot.Name, snapshot.SourceVolumeID)
+	resp, _, err := client.CSIVolumes().CreateSnapshot(snapshot, opts)
+	if err != nil {
+		return fmt.Errorf("error creating snapshot of CSI volume %q before deleting it: %s", snapshot.SourceVolumeID, err)
+	}
+	for _, s := range resp.Snapshots {
+		log.Printf("[INFO] created snapshot %q of CSI volume %q", s.ID, snapshot.SourceVolumeID)
+	}
+	return nil
+}
+
+// csiVolumeSnapshotName returns the name of the snapshot taken before deleting
+// a volume.
+func csiVolumeSnapshotName(prefix, volumeID string, now time.Time) string {
+	return fmt.Sprintf("%s%s-%s", prefix, volumeID, now.UTC().Format("20060102150405"))
+}
@@ -6,6 +6,7 @@ package nomad
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"hash/crc32"
 	"log"
@@ -25,7 +26,7 @@ import (
 func resourceCSIVolumeRegistration() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceCSIVolumeRegistrationCreate,
-		UpdateContext: resourceCSIVolumeRegistrationCreate,
+		UpdateContext: resourceCSIVolumeRegistrationUpdate,
 		DeleteContext: resourceCSIVolumeRegistrationDelete,
 		Read:          resourceCSIVolumeRead,
 
@@ -45,11 +46,12 @@ func resourceCSIVolumeRegistration() *schema.Resource {
 			// - ExternalID
 
 			"namespace": {
-				ForceNew:    true,
-				Description: "The namespace in which to create the volume.",
-				Optional:    true,
-				Default:     "default",
-				Type:        schema.TypeString,
+				ForceNew:         true,
+				Description:      "The namespace in which to create the volume.",
+				Optional:         true,
+				Default:          api.DefaultNamespace,
+				Type:             schema.TypeString,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
 			},
 
 			"volume_id": {
@@ -172,15 +174,33 @@ func resourceCSIVolumeRegistration() *schema.Resource {
 			},
 
 			"secrets": {
-				Description: "An optional key-value map of strings used as credentials for publishing and unpublishing volumes.",
-				Optional:    true,
-				Type:        schema.TypeMap,
-				Sensitive:   true,
+				Description:   "An optional key-value map of strings used as credentials for publishing and unpublishing volumes.",
+				Optional:      true,
+				Type:          schema.TypeMap,
+				Sensitive:     true,
+				ConflictsWith: []string{"secrets_wo"},
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 
+			"secrets_wo": {
+				Description:  "A JSON encoded map of strings used as credentials for publishing and unpublishing volumes. This value is write-only and is never stored in state.",
+				Optional:     true,
+				Type:         schema.TypeString,
+				WriteOnly:    true,
+				ValidateFunc: validation.StringIsJSON,
+				RequiredWith: []string{"secrets_wo_version"},
+			},
+
+			"secrets_wo_version": {
+				Description:  "The version of secrets_wo, must be changed for new secrets_wo values to be sent to Nomad.",
+				Optional:     true,
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"secrets_wo"},
+			},
+
 			"parameters": {
 				Description: "An optional key-value map of strings passed directly to the CSI plugin to configure the volume.",
 				Optional:    true,
@@ -242,12 +262,10 @@ func resourceCSIVolumeRegistration() *schema.Resource {
 				},
 			},
 
-			"deregister_on_destroy": {
-				Description: "If true, the volume will be deregistered on destroy.",
-				Optional:    true,
-				Default:     true,
-				Type:        schema.TypeBool,
-			},
+			"deregister_on_destroy": deregisterOnDestroySchema("volume"),
+			"retain_on_destroy":     retainOnDestroySchema("volume"),
+
+			"force": forceDestroySchema("volume"),
 
 			// computed
 
@@ -320,6 +338,18 @@ func resourceCSIVolumeRegistration() *schema.Resource {
 					},
 				},
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the volume was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the volume was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -373,6 +403,16 @@ func resourceCSIVolumeRegistrationCreate(ctx context.Context, d *schema.Resource
 		AttachmentMode: capabilities[0].AttachmentMode,
 	}
 
+	secretsWO, ok, err := getWriteOnlyString(d, cty.GetAttrPath("secrets_wo"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if ok {
+		if err := json.Unmarshal([]byte(secretsWO), &volume.Secrets); err != nil {
+			return diag.Errorf("failed to parse secrets_wo, it must be a JSON encoded map of strings: %v", err)
+		}
+	}
+
 	// Unpack the mount_options if we have any and configure the volume struct.
 	mountOpts, ok := d.GetOk("mount_options")
 	if ok {
@@ -441,16 +481,65 @@ func resourceCSIVolumeRegistrationCreate(ctx context.Context, d *schema.Resource
 	return warnings
 }
 
+// resourceCSIVolumeRegistrationUpdate registers the volume again to update it
+// in place. Nomad keeps the context of a volume when it is registered without
+// one and rejects new parameters, so the volume is deregistered first in those
+// cases, which only succeeds if it isn't in use.
+func resourceCSIVolumeRegistrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	oldContext, newContext := d.GetChange("context")
+	oldParameters, newParameters := d.GetChange("parameters")
+
+	if csiVolumeRegistrationNeedsReregister(
+		oldContext.(map[string]interface{}), newContext.(map[string]interface{}),
+		oldParameters.(map[string]interface{}), newParameters.(map[string]interface{}),
+	) {
+		client := meta.(ProviderConfig).client
+
+		id := d.Id()
+		opts := &api.WriteOptions{
+			Namespace: d.Get("namespace").(string),
+		}
+		if opts.Namespace == "" {
+			opts.Namespace = "default"
+		}
+
+		log.Printf("[DEBUG] deregistering CSI volume %q to update its context or parameters", id)
+		if err := client.CSIVolumes().Deregister(id, false, opts); err != nil {
+			return diag.Errorf("error deregistering CSI volume %q to update it, the volume must not be in use to remove its context or change its parameters: %s", id, err)
+		}
+	}
+
+	return resourceCSIVolumeRegistrationCreate(ctx, d, meta)
+}
+
+// csiVolumeRegistrationNeedsReregister returns true if the changes to the
+// context or parameters of a volume can't be applied by registering it again.
+func csiVolumeRegistrationNeedsReregister(oldContext, newContext, oldParameters, newParameters map[string]interface{}) bool {
+	if len(oldContext) > 0 && len(newContext) == 0 {
+		return true
+	}
+
+	// Nomad keeps the existing parameters when none are given.
+	if len(newParameters) == 0 {
+		return false
+	}
+	if len(oldParameters) != len(newParameters) {
+		return true
+	}
+	for k, v := range newParameters {
+		if oldParameters[k] != v {
+			return true
+		}
+	}
+	return false
+}
+
 func resourceCSIVolumeRegistrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConfig := meta.(ProviderConfig)
 	client := providerConfig.client
 
 	// If deregistration is disabled, then do nothing
-	deregister_on_destroy := d.Get("deregister_on_destroy").(bool)
-	if !deregister_on_destroy {
-		log.Printf(
-			"[WARN] volume %q will not deregister since "+
-				"'deregister_on_destroy' is %t", d.Id(), deregister_on_destroy)
+	if skipDeregister(d, "volume") {
 		return nil
 	}
 
@@ -464,7 +553,7 @@ func resourceCSIVolumeRegistrationDelete(ctx context.Context, d *schema.Resource
 	}
 
 	return diag.FromErr(retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete)-time.Minute, func() *retry.RetryError {
-		err := client.CSIVolumes().Deregister(id, true, opts)
+		err := client.CSIVolumes().Deregister(id, destroyFlag(d, "force", true), opts)
 		if err != nil {
 			return retry.RetryableError(fmt.Errorf("error deregistering CSI volume: %s", err))
 		}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestCSIVolumeRegistrationNeedsReregister(t *testing.T) {
+	cases := []struct {
+		name          string
+		oldContext    map[string]interface{}
+		newContext    map[string]interface{}
+		oldParameters map[string]interface{}
+		newParameters map[string]interface{}
+		expected      bool
+	}{
+		{
+			name: "no changes",
+		},
+		{
+			name:       "context added",
+			newContext: map[string]interface{}{"a": "1"},
+		},
+		{
+			name:       "context updated",
+			oldContext: map[string]interface{}{"a": "1"},
+			newContext: map[string]interface{}{"a": "2"},
+		},
+		{
+			name:       "context removed",
+			oldContext: map[string]interface{}{"a": "1"},
+			expected:   true,
+		},
+		{
+			name:          "parameters unchanged",
+			oldParameters: map[string]interface{}{"a": "1"},
+			newParameters: map[string]interface{}{"a": "1"},
+		},
+		{
+			name:          "parameters removed",
+			oldParameters: map[string]interface{}{"a": "1"},
+		},
+		{
+			name:          "parameters updated",
+			oldParameters: map[string]interface{}{"a": "1"},
+			newParameters: map[string]interface{}{"a": "2"},
+			expected:      true,
+		},
+		{
+			name:          "parameters added",
+			oldParameters: map[string]interface{}{"a": "1"},
+			newParameters: map[string]interface{}{"a": "1", "b": "2"},
+			expected:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := csiVolumeRegistrationNeedsReregister(tc.oldContext, tc.newContext, tc.oldParameters, tc.newParameters)
+			must.Eq(t, tc.expected, got)
+		})
+	}
+}
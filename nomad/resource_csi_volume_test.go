@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-cty/cty"
@@ -216,26 +217,75 @@ resource "nomad_csi_volume" "test" {
 			},
 		},
 
-		CheckDestroy: func(s *terraform.State) error {
-			for _, s := range s.Modules[0].Resources {
-				if s.Type != "nomad_csi_volume" {
-					continue
-				}
-				if s.Primary == nil {
-					continue
-				}
-				client := testProvider.Meta().(ProviderConfig).client
-				volume, _, err := client.CSIVolumes().Info(s.Primary.ID, nil)
-				if err != nil && strings.Contains(err.Error(), "404") || volume == nil {
-					continue
-				}
-				return fmt.Errorf("volume %q has not been deleted.", volume.ID)
-			}
-			return nil
+		CheckDestroy: testResourceCSIVolume_checkDestroy,
+	})
+}
+
+func TestResourceCSIVolume_secretsWO(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCSIPluginAvailable(t, "hostpath-plugin0")
 		},
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceCSIVolume_secretsWOConfig(1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("nomad_csi_volume.test", "secrets_wo"),
+					resource.TestCheckResourceAttr("nomad_csi_volume.test", "secrets.%", "0"),
+					resource.TestCheckResourceAttr("nomad_csi_volume.test", "secrets_wo_version", "1"),
+				),
+			},
+			{
+				Config: testResourceCSIVolume_secretsWOConfig(2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("nomad_csi_volume.test", "secrets_wo"),
+					resource.TestCheckResourceAttr("nomad_csi_volume.test", "secrets_wo_version", "2"),
+				),
+			},
+		},
+		CheckDestroy: testResourceCSIVolume_checkDestroy,
 	})
 }
 
+func testResourceCSIVolume_secretsWOConfig(version int) string {
+	return fmt.Sprintf(`
+resource "nomad_csi_volume" "test" {
+  plugin_id    = "hostpath-plugin0"
+  volume_id    = "secrets_wo_volume"
+  name         = "secrets_wo_volume"
+  capacity_min = "1GiB"
+
+  capability {
+    access_mode     = "single-node-writer"
+    attachment_mode = "file-system"
+  }
+
+  secrets_wo         = jsonencode({ password = "secret-%d" })
+  secrets_wo_version = %d
+}
+`, version, version)
+}
+
+func testResourceCSIVolume_checkDestroy(s *terraform.State) error {
+	for _, s := range s.Modules[0].Resources {
+		if s.Type != "nomad_csi_volume" {
+			continue
+		}
+		if s.Primary == nil {
+			continue
+		}
+		client := testProvider.Meta().(ProviderConfig).client
+		volume, _, err := client.CSIVolumes().Info(s.Primary.ID, nil)
+		if err != nil && strings.Contains(err.Error(), "404") || volume == nil {
+			continue
+		}
+		return fmt.Errorf("volume %q has not been deleted.", volume.ID)
+	}
+	return nil
+}
+
 /* unit tests */
 
 func TestCSIErrIsRetryable(t *testing.T) {
@@ -258,6 +308,36 @@ func TestCSIErrIsRetryable(t *testing.T) {
 	}
 }
 
+func TestCheckCSIPluginHealthy(t *testing.T) {
+	plugin := &api.CSIPlugin{
+		ID:                 "aws-ebs0",
+		ControllersHealthy: 1,
+		NodesHealthy:       2,
+	}
+
+	cases := []struct {
+		name           string
+		minControllers int
+		minNodes       int
+		expectErr      bool
+	}{
+		{name: "healthy", minControllers: 1, minNodes: 2},
+		{name: "no minimum", minControllers: 0, minNodes: 0},
+		{name: "not enough controllers", minControllers: 2, minNodes: 1, expectErr: true},
+		{name: "not enough nodes", minControllers: 1, minNodes: 3, expectErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkCSIPluginHealthy(plugin, tc.minControllers, tc.minNodes)
+			if tc.expectErr {
+				must.Error(t, err)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestCapacityStateFunc(t *testing.T) {
 	cases := []struct {
 		in, out string
@@ -428,3 +508,10 @@ func TestCheckCapacity(t *testing.T) {
 		})
 	}
 }
+
+func TestCSIVolumeSnapshotName(t *testing.T) {
+	now := time.Date(2025, 4, 16, 10, 20, 30, 0, time.UTC)
+
+	must.Eq(t, "mysql-20250416102030", csiVolumeSnapshotName("", "mysql", now))
+	must.Eq(t, "final-mysql-20250416102030", csiVolumeSnapshotName("final-", "mysql", now))
+}
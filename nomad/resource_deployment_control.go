@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
+)
+
+const (
+	deploymentActionPause  = "pause"
+	deploymentActionResume = "resume"
+	deploymentActionFail   = "fail"
+)
+
+func resourceDeploymentControl() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDeploymentControlWrite,
+		UpdateContext: resourceDeploymentControlWrite,
+		DeleteContext: resourceDeploymentControlDelete,
+		ReadContext:   resourceDeploymentControlRead,
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Description: "The ID of the deployment to control.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"namespace": {
+				Description:      "The namespace of the deployment.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          api.DefaultNamespace,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
+			},
+			"action": {
+				Description: "The action to apply to the deployment, one of 'pause', 'resume' or 'fail'.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					deploymentActionPause,
+					deploymentActionResume,
+					deploymentActionFail,
+				}, false),
+			},
+			"job_id": {
+				Description: "The ID of the job of the deployment.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "The status of the deployment.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status_description": {
+				Description: "The description of the status of the deployment.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceDeploymentControlWrite(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(ProviderConfig).client
+
+	id := d.Get("deployment_id").(string)
+	action := d.Get("action").(string)
+	opts := &api.WriteOptions{Namespace: d.Get("namespace").(string)}
+
+	var err error
+	log.Printf("[DEBUG] Applying action %q to deployment %q", action, id)
+	switch action {
+	case deploymentActionPause:
+		_, _, err = client.Deployments().Pause(id, true, opts)
+	case deploymentActionResume:
+		_, _, err = client.Deployments().Pause(id, false, opts)
+	case deploymentActionFail:
+		_, _, err = client.Deployments().Fail(id, opts)
+	}
+	if err != nil {
+		return diag.Errorf("error applying action %q to deployment %q: %s", action, id, err)
+	}
+	log.Printf("[DEBUG] Applied action %q to deployment %q", action, id)
+
+	d.SetId(id)
+
+	return resourceDeploymentControlRead(ctx, d, meta)
+}
+
+// resourceDeploymentControlDelete only removes the resource from state, the
+// deployment is left as-is.
+func resourceDeploymentControlDelete(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+func resourceDeploymentControlRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(ProviderConfig).client
+
+	deployment, err := getDeployment(client, d.Id(), d.Get("namespace").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if deployment == nil {
+		log.Printf("[DEBUG] Deployment %q not found", d.Id())
+		return nil
+	}
+
+	d.Set("job_id", deployment.JobID)
+	d.Set("status", deployment.Status)
+	d.Set("status_description", deployment.StatusDescription)
+
+	return nil
+}
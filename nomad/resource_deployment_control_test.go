@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceDeploymentControl_basic(t *testing.T) {
+	jobID := acctest.RandomWithPrefix("tf-nomad-test")
+	resourceName := "nomad_deployment_control.test"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testResourceDeploymentPromote_canaryDeployment(t, jobID) },
+				Config:    testResourceDeploymentControl_config(jobID, "pause"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "job_id", jobID),
+					resource.TestCheckResourceAttr(resourceName, "status", api.DeploymentStatusPaused),
+				),
+			},
+			{
+				Config: testResourceDeploymentControl_config(jobID, "resume"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "status", api.DeploymentStatusRunning),
+			},
+			{
+				Config: testResourceDeploymentControl_config(jobID, "fail"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "status", api.DeploymentStatusFailed),
+			},
+		},
+		CheckDestroy: testResourceDeploymentPromote_deregister(jobID),
+	})
+}
+
+func testResourceDeploymentControl_config(jobID, action string) string {
+	return fmt.Sprintf(`
+data "nomad_deployments" "test" {
+  job_id      = %q
+  latest_only = true
+}
+
+resource "nomad_deployment_control" "test" {
+  deployment_id = data.nomad_deployments.test.deployments[0].ID
+  action        = %q
+}
+`, jobID, action)
+}
@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
+)
+
+func resourceDeploymentPromote() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDeploymentPromoteCreate,
+		UpdateContext: resourceDeploymentPromoteUpdate,
+		DeleteContext: resourceDeploymentPromoteDelete,
+		ReadContext:   resourceDeploymentPromoteRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Description: "The ID of the deployment to promote.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"namespace": {
+				Description:      "The namespace of the deployment.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          api.DefaultNamespace,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
+			},
+			"groups": {
+				Description: "The task groups to promote. All the task groups are promoted if not set.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"wait_for_completion": {
+				Description: "Wait for the deployment to complete successfully after the promotion.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"job_id": {
+				Description: "The ID of the job of the deployment.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "The status of the deployment.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status_description": {
+				Description: "The description of the status of the deployment.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceDeploymentPromoteCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(ProviderConfig).client
+
+	id := d.Get("deployment_id").(string)
+	opts := &api.WriteOptions{Namespace: d.Get("namespace").(string)}
+
+	var groups []string
+	for _, group := range d.Get("groups").(*schema.Set).List() {
+		groups = append(groups, group.(string))
+	}
+	sort.Strings(groups)
+
+	var err error
+	if len(groups) == 0 {
+		log.Printf("[DEBUG] Promoting all task groups of deployment %q", id)
+		_, _, err = client.Deployments().PromoteAll(id, opts)
+	} else {
+		log.Printf("[DEBUG] Promoting task groups %s of deployment %q", strings.Join(groups, ", "), id)
+		_, _, err = client.Deployments().PromoteGroups(id, groups, opts)
+	}
+	if err != nil {
+		return diag.Errorf("error promoting deployment %q: %s", id, err)
+	}
+	log.Printf("[DEBUG] Promoted deployment %q", id)
+
+	d.SetId(id)
+
+	if d.Get("wait_for_completion").(bool) {
+		err := waitForDeploymentStatus(ctx, client, meta.(ProviderConfig).poll, id, opts.Namespace, d.Timeout(schema.TimeoutCreate), api.DeploymentStatusSuccessful)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceDeploymentPromoteRead(ctx, d, meta)
+}
+
+// resourceDeploymentPromoteUpdate only stores the new value of
+// wait_for_completion since the deployment has already been promoted.
+func resourceDeploymentPromoteUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	return resourceDeploymentPromoteRead(ctx, d, meta)
+}
+
+// resourceDeploymentPromoteDelete only removes the promotion from state, since
+// promoting a deployment can't be undone.
+func resourceDeploymentPromoteDelete(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+func resourceDeploymentPromoteRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(ProviderConfig).client
+
+	deployment, err := getDeployment(client, d.Id(), d.Get("namespace").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if deployment == nil {
+		// Deployments are eventually garbage collected, but that doesn't mean
+		// the promotion needs to be repeated.
+		log.Printf("[DEBUG] Deployment %q not found", d.Id())
+		return nil
+	}
+
+	d.Set("job_id", deployment.JobID)
+	d.Set("status", deployment.Status)
+	d.Set("status_description", deployment.StatusDescription)
+
+	return nil
+}
+
+// getDeployment returns the deployment with the given ID, or nil if it
+// doesn't exist.
+func getDeployment(client *api.Client, id, namespace string) (*api.Deployment, error) {
+	log.Printf("[DEBUG] Reading deployment %q", id)
+	deployment, _, err := client.Deployments().Info(id, &api.QueryOptions{Namespace: namespace})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading deployment %q: %s", id, err)
+	}
+	log.Printf("[DEBUG] Read deployment %q", id)
+
+	return deployment, nil
+}
+
+// waitForDeploymentStatus waits until the deployment reaches the status
+// wanted, failing if it reaches a terminal status instead. The deployment is
+// watched with blocking queries.
+func waitForDeploymentStatus(ctx context.Context, client *api.Client, poll pollConfig, id, namespace string, timeout time.Duration, wanted string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	log.Printf("[DEBUG] Waiting for deployment %q to be %s", id, wanted)
+	opts := (&api.QueryOptions{Namespace: namespace}).WithContext(ctx)
+	lastErr := fmt.Errorf("deployment %q is not %s yet", id, wanted)
+	pacer := poll.pacer()
+	for {
+		deployment, qm, err := client.Deployments().Info(id, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return waitDoneError(ctx, timeout, fmt.Sprintf("deployment %q", id), lastErr)
+			}
+			if isNotFoundError(err) {
+				return fmt.Errorf("deployment %q not found", id)
+			}
+			return fmt.Errorf("error reading deployment %q: %s", id, err)
+		}
+		if rErr := checkDeploymentStatus(deployment, wanted); rErr != nil {
+			if !rErr.Retryable {
+				return rErr.Err
+			}
+			lastErr = rErr.Err
+			opts.WaitIndex = qm.LastIndex
+			pacer.wait(ctx)
+			continue
+		}
+
+		log.Printf("[DEBUG] Deployment %q is %s", id, wanted)
+		return nil
+	}
+}
+
+// checkDeploymentStatus returns a retryable error while the deployment hasn't
+// reached the status wanted, and a non-retryable error if it reached a
+// terminal status that isn't the one wanted.
+func checkDeploymentStatus(deployment *api.Deployment, wanted string) *retry.RetryError {
+	switch deployment.Status {
+	case wanted:
+		return nil
+	case api.DeploymentStatusSuccessful, api.DeploymentStatusFailed, api.DeploymentStatusCancelled:
+		return retry.NonRetryableError(fmt.Errorf("deployment %q is %s: %s",
+			deployment.ID, deployment.Status, deployment.StatusDescription))
+	default:
+		return retry.RetryableError(fmt.Errorf("deployment %q is %s, waiting for it to be %s",
+			deployment.ID, deployment.Status, wanted))
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestResourceDeploymentPromote_basic(t *testing.T) {
+	jobID := acctest.RandomWithPrefix("tf-nomad-test")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testResourceDeploymentPromote_canaryDeployment(t, jobID) },
+				Config:    testResourceDeploymentPromote_config(jobID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nomad_deployment_promote.test", "job_id", jobID),
+					resource.TestCheckResourceAttr("nomad_deployment_promote.test", "status", api.DeploymentStatusSuccessful),
+				),
+			},
+		},
+		CheckDestroy: testResourceDeploymentPromote_deregister(jobID),
+	})
+}
+
+func testResourceDeploymentPromote_config(jobID string) string {
+	return fmt.Sprintf(`
+data "nomad_deployments" "test" {
+  job_id      = %q
+  latest_only = true
+}
+
+resource "nomad_deployment_promote" "test" {
+  deployment_id       = data.nomad_deployments.test.deployments[0].ID
+  wait_for_completion = true
+}
+`, jobID)
+}
+
+// testResourceDeploymentPromote_canaryDeployment registers two versions of a
+// job with canaries and waits for the second deployment to wait for its
+// canaries to be promoted.
+func testResourceDeploymentPromote_canaryDeployment(t *testing.T, jobID string) {
+	client := testProvider.Meta().(ProviderConfig).client
+
+	register := func(sleep string, wantStatus string) {
+		job := api.NewServiceJob(jobID, jobID, "global", 50)
+		job.Datacenters = []string{"dc1"}
+		job.Update = &api.UpdateStrategy{
+			Canary:          pointer.Of(1),
+			AutoPromote:     pointer.Of(false),
+			MinHealthyTime:  pointer.Of(time.Second),
+			HealthyDeadline: pointer.Of(time.Minute),
+		}
+		job.AddTaskGroup(api.NewTaskGroup("test", 1).AddTask(
+			api.NewTask("test", "raw_exec").
+				SetConfig("command", "/bin/sleep").
+				SetConfig("args", []string{sleep}).
+				Require(&api.Resources{CPU: pointer.Of(100), MemoryMB: pointer.Of(10)}),
+		))
+
+		resp, _, err := client.Jobs().Register(job, nil)
+		must.NoError(t, err)
+
+		must.Wait(t, wait.InitialSuccess(
+			wait.ErrorFunc(func() error {
+				deployment, _, err := client.Jobs().LatestDeployment(jobID, nil)
+				if err != nil {
+					return err
+				}
+				if deployment == nil || deployment.JobModifyIndex < resp.JobModifyIndex {
+					return fmt.Errorf("deployment for job %q not created yet", jobID)
+				}
+				if wantStatus == api.DeploymentStatusRunning {
+					for _, state := range deployment.TaskGroups {
+						if state.HealthyAllocs < state.DesiredCanaries {
+							return fmt.Errorf("canaries of deployment %q not healthy yet", deployment.ID)
+						}
+					}
+				}
+				if err := checkDeploymentStatus(deployment, wantStatus); err != nil {
+					return err.Err
+				}
+				return nil
+			}),
+			wait.Timeout(2*time.Minute),
+			wait.Gap(time.Second),
+		))
+	}
+
+	register("3600", api.DeploymentStatusSuccessful)
+	register("3601", api.DeploymentStatusRunning)
+}
+
+func testResourceDeploymentPromote_deregister(jobID string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testProvider.Meta().(ProviderConfig).client
+		_, _, err := client.Jobs().Deregister(jobID, true, nil)
+		return err
+	}
+}
+
+func TestCheckDeploymentStatus(t *testing.T) {
+	cases := []struct {
+		status    string
+		wanted    string
+		err       bool
+		retryable bool
+	}{
+		{status: api.DeploymentStatusSuccessful, wanted: api.DeploymentStatusSuccessful},
+		{status: api.DeploymentStatusPaused, wanted: api.DeploymentStatusPaused},
+		{status: api.DeploymentStatusRunning, wanted: api.DeploymentStatusSuccessful, err: true, retryable: true},
+		{status: api.DeploymentStatusPending, wanted: api.DeploymentStatusSuccessful, err: true, retryable: true},
+		{status: api.DeploymentStatusFailed, wanted: api.DeploymentStatusSuccessful, err: true},
+		{status: api.DeploymentStatusCancelled, wanted: api.DeploymentStatusSuccessful, err: true},
+		{status: api.DeploymentStatusSuccessful, wanted: api.DeploymentStatusFailed, err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.status+"-"+tc.wanted, func(t *testing.T) {
+			err := checkDeploymentStatus(&api.Deployment{ID: "d1", Status: tc.status}, tc.wanted)
+			if !tc.err {
+				must.Nil(t, err)
+				return
+			}
+			must.NotNil(t, err)
+			must.Eq(t, tc.retryable, err.Retryable)
+		})
+	}
+}
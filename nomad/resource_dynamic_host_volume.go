@@ -4,26 +4,39 @@
 package nomad
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
 )
 
 func resourceDynamicHostVolume() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDynamicHostVolumeWrite,
-		Update: resourceDynamicHostVolumeWrite,
-		Delete: resourceDynamicHostVolumeDelete,
-		Read:   dynamicHostVolumeRead,
-		Exists: resourceDynamicHostVolumeExists,
+		CreateContext: resourceDynamicHostVolumeWrite,
+		UpdateContext: resourceDynamicHostVolumeWrite,
+		DeleteContext: resourceDynamicHostVolumeDelete,
+		Read:          dynamicHostVolumeRead,
+		Exists:        resourceDynamicHostVolumeExists,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		CustomizeDiff: resourceDynamicHostVolumeCustomizeDiff,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: helper.OptionalNamespacedImporterContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -33,15 +46,18 @@ func resourceDynamicHostVolume() *schema.Resource {
 				Required:    true,
 			},
 			"namespace": {
-				Description: "Volume namespace",
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "default",
+				Description:      "Volume namespace",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          api.DefaultNamespace,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
 			},
 			"plugin_id": {
 				Description: "Plugin ID",
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 			},
 			"capability": {
 				Description: "Capability",
@@ -85,6 +101,7 @@ func resourceDynamicHostVolume() *schema.Resource {
 				Description: "Constraints",
 				Type:        schema.TypeList,
 				Optional:    true,
+				ForceNew:    true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"attribute": {
@@ -121,7 +138,10 @@ func resourceDynamicHostVolume() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 			},
+			"deregister_on_destroy": deregisterOnDestroySchema("volume"),
+			"retain_on_destroy":     retainOnDestroySchema("volume"),
 			"parameters": {
 				Description: "Parameters",
 				Type:        schema.TypeMap,
@@ -164,11 +184,81 @@ func resourceDynamicHostVolume() *schema.Resource {
 				Computed: true,
 				Type:     schema.TypeInt,
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the volume was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the volume was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
 
-func resourceDynamicHostVolumeWrite(d *schema.ResourceData, meta any) error {
+// resourceDynamicHostVolumeCustomizeDiff plans the replacement of volumes for
+// changes that Nomad can't apply in place. The parameters, the capacity and
+// new capabilities are updated by calling the plugin again, but the plugin
+// can't shrink a volume below its provisioned capacity or remove capabilities
+// that allocations may rely on.
+func resourceDynamicHostVolumeCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta any) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("capability") {
+		oldCaps, newCaps := d.GetChange("capability")
+		if dynamicHostVolumeCapabilitiesRemoved(oldCaps.([]any), newCaps.([]any)) {
+			log.Printf("[DEBUG] Capabilities removed from dynamic host volume %q, forcing new resource", d.Id())
+			if err := d.ForceNew("capability"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("capacity_max") {
+		max := d.Get("capacity_max").(string)
+		capacity := d.Get("capacity_bytes").(int)
+		if max != "" && capacity > 0 {
+			capacityMax, err := humanize.ParseBytes(max)
+			if err != nil {
+				return fmt.Errorf("could not parse capacity_max value as bytes: %w", err)
+			}
+			if capacityMax < uint64(capacity) {
+				return fmt.Errorf("capacity_max (%s) cannot be less than the provisioned capacity of the volume (%s)",
+					max, humanize.IBytes(uint64(capacity)))
+			}
+		}
+	}
+
+	return nil
+}
+
+// dynamicHostVolumeCapabilitiesRemoved returns whether any of the old
+// capabilities is missing from the new ones.
+func dynamicHostVolumeCapabilitiesRemoved(oldCaps, newCaps []any) bool {
+	key := func(raw any) string {
+		item, _ := raw.(map[string]any)
+		return fmt.Sprintf("%v/%v", item["access_mode"], item["attachment_mode"])
+	}
+
+	wanted := make(map[string]struct{}, len(newCaps))
+	for _, raw := range newCaps {
+		wanted[key(raw)] = struct{}{}
+	}
+	for _, raw := range oldCaps {
+		if _, ok := wanted[key(raw)]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceDynamicHostVolumeWrite(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(ProviderConfig).client
 
 	parameters := make(map[string]string)
@@ -178,12 +268,12 @@ func resourceDynamicHostVolumeWrite(d *schema.ResourceData, meta any) error {
 
 	constraints, err := expandDynamicHostVolumeConstraints(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	caps, err := expandDynamicHostVolumeCapabilities(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var capacityMin, capacityMax uint64
@@ -191,14 +281,14 @@ func resourceDynamicHostVolumeWrite(d *schema.ResourceData, meta any) error {
 	if min != "" {
 		capacityMin, err = humanize.ParseBytes(min)
 		if err != nil {
-			return fmt.Errorf("could not parse capacity_min value as bytes: %w", err)
+			return diag.Errorf("could not parse capacity_min value as bytes: %s", err)
 		}
 	}
 	max := d.Get("capacity_max").(string)
 	if max != "" {
 		capacityMax, err = humanize.ParseBytes(max)
 		if err != nil {
-			return fmt.Errorf("could not parse capacity_max value as bytes: %w", err)
+			return diag.Errorf("could not parse capacity_max value as bytes: %s", err)
 		}
 	}
 
@@ -221,25 +311,41 @@ func resourceDynamicHostVolumeWrite(d *schema.ResourceData, meta any) error {
 	log.Printf("[DEBUG] Upserting dynamic host volume %q", req.Volume.Name)
 	resp, _, err := client.HostVolumes().Create(req, nil)
 	if err != nil {
-		return fmt.Errorf("error upserting node pool %q: %w", req.Volume.Name, err)
+		return diag.Errorf("error upserting node pool %q: %s", req.Volume.Name, err)
 	}
 	log.Printf("[DEBUG] Upserted dynamic host volume %q", resp.Volume.ID)
 	d.SetId(resp.Volume.ID)
 	d.Set("namespace", resp.Volume.Namespace)
 
-	err = dynamicHostVolumeWaitForReady(client, resp.Volume.Namespace, resp.Volume.ID)
+	err = dynamicHostVolumeWaitForReady(ctx, client, meta.(ProviderConfig).poll, resp.Volume.Namespace, resp.Volume.ID, dynamicHostVolumeWriteTimeout(d))
 	if err != nil {
-		return fmt.Errorf("error polling for dynamic host volume readiness: %w", err)
+		return diag.Errorf("error polling for dynamic host volume readiness: %s", err)
 	}
 
-	return dynamicHostVolumeRead(d, meta)
+	return diag.FromErr(dynamicHostVolumeRead(d, meta))
 }
 
-func dynamicHostVolumeWaitForReady(client *api.Client, ns, id string) error {
-	opts := &api.QueryOptions{Namespace: ns}
+// dynamicHostVolumeWriteTimeout returns the timeout of the current create or
+// update operation.
+func dynamicHostVolumeWriteTimeout(d *schema.ResourceData) time.Duration {
+	if d.IsNewResource() {
+		return d.Timeout(schema.TimeoutCreate)
+	}
+	return d.Timeout(schema.TimeoutUpdate)
+}
+
+func dynamicHostVolumeWaitForReady(ctx context.Context, client *api.Client, poll pollConfig, ns, id string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	opts := (&api.QueryOptions{Namespace: ns}).WithContext(ctx)
+	pacer := poll.pacer()
 	for {
 		vol, qm, err := client.HostVolumes().Get(id, opts)
 		if err != nil {
+			if ctx.Err() != nil {
+				return waitDoneError(ctx, timeout, fmt.Sprintf("dynamic host volume %q to be ready", id), nil)
+			}
 			return err
 		}
 		if vol == nil {
@@ -250,23 +356,66 @@ func dynamicHostVolumeWaitForReady(client *api.Client, ns, id string) error {
 		}
 		opts.WaitIndex = qm.LastIndex
 		log.Printf("[DEBUG] Waiting for dynamic host volume %q to be ready", id)
+		pacer.wait(ctx)
 	}
 }
 
-// resourceDynamicHostVolumeDelete is shared between the Create and Register workflows
-func resourceDynamicHostVolumeDelete(d *schema.ResourceData, meta any) error {
+// resourceDynamicHostVolumeDelete is shared between the Create and Register
+// workflows. Nomad refuses to delete volumes that are still claimed by
+// allocations, and unlike for CSI volumes its API has no option to force it,
+// so it waits for the allocations to release the volume first.
+func resourceDynamicHostVolumeDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(ProviderConfig).client
 	ns, id := getDynamicHostVolumeNamespacedID(d)
-	log.Printf("[DEBUG] Deleting dynamic host volume %q", id)
 
-	_, _, err := client.HostVolumes().Delete(
-		&api.HostVolumeDeleteRequest{ID: id},
-		&api.WriteOptions{Namespace: ns},
-	)
-	if err != nil {
-		return fmt.Errorf("could not delete dynamic host volume %q: %w", id, err)
+	// If deregistration is disabled, then do nothing
+	if skipDeregister(d, "dynamic host volume") {
+		return nil
 	}
-	return nil
+
+	err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+		vol, err := getDynamicHostVolume(client, ns, id)
+		if err != nil {
+			return retry.NonRetryableError(fmt.Errorf("error reading dynamic host volume %q: %w", id, err))
+		}
+		if vol == nil {
+			return nil
+		}
+		if err := dynamicHostVolumeInUse(vol); err != nil {
+			log.Printf("[DEBUG] Waiting for dynamic host volume %q to be released: %v", id, err)
+			return retry.RetryableError(err)
+		}
+
+		log.Printf("[DEBUG] Deleting dynamic host volume %q", id)
+		_, _, err = client.HostVolumes().Delete(
+			&api.HostVolumeDeleteRequest{ID: id},
+			&api.WriteOptions{Namespace: ns},
+		)
+		if err != nil {
+			// An allocation may have claimed the volume since it was read.
+			if strings.Contains(err.Error(), "in use by allocations") {
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(fmt.Errorf("could not delete dynamic host volume %q: %w", id, err))
+		}
+		log.Printf("[DEBUG] Deleted dynamic host volume %q", id)
+		return nil
+	})
+	return diag.FromErr(err)
+}
+
+// dynamicHostVolumeInUse returns an error listing the allocations that still
+// claim the volume, or nil if it can be deleted.
+func dynamicHostVolumeInUse(vol *api.HostVolume) error {
+	if len(vol.Allocations) == 0 {
+		return nil
+	}
+
+	allocIDs := make([]string, 0, len(vol.Allocations))
+	for _, alloc := range vol.Allocations {
+		allocIDs = append(allocIDs, alloc.ID)
+	}
+	return fmt.Errorf("dynamic host volume %q is in use by allocations: %s", vol.ID, strings.Join(allocIDs, ", "))
 }
 
 // resourceDynamicHostVolumeExists is shared between the Create and Register workflows
@@ -286,7 +435,7 @@ func resourceDynamicHostVolumeExists(d *schema.ResourceData, meta any) (bool, er
 func getDynamicHostVolume(client *api.Client, ns, id string) (*api.HostVolume, error) {
 	vol, _, err := client.HostVolumes().Get(id, &api.QueryOptions{Namespace: ns})
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			return nil, nil
 		}
 
@@ -4,25 +4,34 @@
 package nomad
 
 import (
-	"fmt"
+	"context"
 	"log"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
 )
 
 func resourceDynamicHostVolumeRegistration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDynamicHostVolumeRegistrationWrite,
-		Update: resourceDynamicHostVolumeRegistrationWrite,
-		Delete: resourceDynamicHostVolumeDelete,
-		Read:   dynamicHostVolumeRead,
-		Exists: resourceDynamicHostVolumeExists,
+		CreateContext: resourceDynamicHostVolumeRegistrationWrite,
+		UpdateContext: resourceDynamicHostVolumeRegistrationWrite,
+		DeleteContext: resourceDynamicHostVolumeDelete,
+		Read:          dynamicHostVolumeRead,
+		Exists:        resourceDynamicHostVolumeExists,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: helper.OptionalNamespacedImporterContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -32,10 +41,12 @@ func resourceDynamicHostVolumeRegistration() *schema.Resource {
 				Required:    true,
 			},
 			"namespace": {
-				Description: "Volume namespace",
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "default",
+				Description:      "Volume namespace",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          api.DefaultNamespace,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
 			},
 			"capacity": {
 				Description: "Provisioned capacity",
@@ -126,6 +137,8 @@ func resourceDynamicHostVolumeRegistration() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"deregister_on_destroy": deregisterOnDestroySchema("volume"),
+			"retain_on_destroy":     retainOnDestroySchema("volume"),
 			"constraint": {
 				Description: "Constraints",
 				Type:        schema.TypeList,
@@ -151,11 +164,23 @@ func resourceDynamicHostVolumeRegistration() *schema.Resource {
 					},
 				},
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the volume was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the volume was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
 
-func resourceDynamicHostVolumeRegistrationWrite(d *schema.ResourceData, meta any) error {
+func resourceDynamicHostVolumeRegistrationWrite(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(ProviderConfig).client
 
 	parameters := make(map[string]string)
@@ -169,13 +194,13 @@ func resourceDynamicHostVolumeRegistrationWrite(d *schema.ResourceData, meta any
 	if capacityStr != "" {
 		capacity, err = humanize.ParseBytes(capacityStr)
 		if err != nil {
-			return fmt.Errorf("could not parse capacity value as bytes: %w", err)
+			return diag.Errorf("could not parse capacity value as bytes: %s", err)
 		}
 	}
 
 	caps, err := expandDynamicHostVolumeCapabilities(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	req := &api.HostVolumeRegisterRequest{
@@ -195,16 +220,16 @@ func resourceDynamicHostVolumeRegistrationWrite(d *schema.ResourceData, meta any
 	log.Printf("[DEBUG] Upserting dynamic host volume %q", req.Volume.Name)
 	resp, _, err := client.HostVolumes().Register(req, nil)
 	if err != nil {
-		return fmt.Errorf("error upserting dynamic host volume %q: %w", req.Volume.Name, err)
+		return diag.Errorf("error upserting dynamic host volume %q: %s", req.Volume.Name, err)
 	}
 	log.Printf("[DEBUG] Upserted dynamic host volume %q", resp.Volume.ID)
 	d.SetId(resp.Volume.ID)
 	d.Set("namespace", resp.Volume.Namespace)
 
-	err = dynamicHostVolumeWaitForReady(client, resp.Volume.Namespace, resp.Volume.ID)
+	err = dynamicHostVolumeWaitForReady(ctx, client, meta.(ProviderConfig).poll, resp.Volume.Namespace, resp.Volume.ID, dynamicHostVolumeWriteTimeout(d))
 	if err != nil {
-		return fmt.Errorf("error polling for dynamic host volume readiness: %w", err)
+		return diag.Errorf("error polling for dynamic host volume readiness: %s", err)
 	}
 
-	return dynamicHostVolumeRead(d, meta)
+	return diag.FromErr(dynamicHostVolumeRead(d, meta))
 }
@@ -288,3 +288,27 @@ func testResourceDynamicHostVolume_delete(t *testing.T, name string) func() {
 		))
 	}
 }
+
+func TestDynamicHostVolumeCapabilitiesRemoved(t *testing.T) {
+	writer := map[string]any{"access_mode": "single-node-writer", "attachment_mode": "file-system"}
+	reader := map[string]any{"access_mode": "single-node-reader-only", "attachment_mode": "file-system"}
+
+	must.False(t, dynamicHostVolumeCapabilitiesRemoved([]any{writer}, []any{writer}))
+	must.False(t, dynamicHostVolumeCapabilitiesRemoved([]any{writer}, []any{reader, writer}))
+	must.False(t, dynamicHostVolumeCapabilitiesRemoved([]any{}, []any{reader}))
+	must.True(t, dynamicHostVolumeCapabilitiesRemoved([]any{writer, reader}, []any{writer}))
+	must.True(t, dynamicHostVolumeCapabilitiesRemoved([]any{writer}, []any{reader}))
+}
+
+func TestDynamicHostVolumeInUse(t *testing.T) {
+	must.NoError(t, dynamicHostVolumeInUse(&api.HostVolume{ID: "vol"}))
+
+	err := dynamicHostVolumeInUse(&api.HostVolume{
+		ID: "vol",
+		Allocations: []*api.AllocationListStub{
+			{ID: "alloc1"},
+			{ID: "alloc2"},
+		},
+	})
+	must.EqError(t, err, `dynamic host volume "vol" is in use by allocations: alloc1, alloc2`)
+}
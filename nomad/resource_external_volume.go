@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
 )
 
 func resourceExternalVolume() *schema.Resource {
@@ -42,11 +43,12 @@ func resourceExternalVolume() *schema.Resource {
 			},
 
 			"namespace": {
-				ForceNew:    true,
-				Description: "The namespace in which to create the volume.",
-				Optional:    true,
-				Default:     "default",
-				Type:        schema.TypeString,
+				ForceNew:         true,
+				Description:      "The namespace in which to create the volume.",
+				Optional:         true,
+				Default:          api.DefaultNamespace,
+				Type:             schema.TypeString,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
 			},
 
 			"volume_id": {
@@ -321,6 +323,18 @@ func resourceExternalVolume() *schema.Resource {
 					},
 				},
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the volume was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the volume was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
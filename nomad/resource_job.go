@@ -5,7 +5,10 @@ package nomad
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
@@ -14,9 +17,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/jobspec2"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"golang.org/x/exp/maps"
 
@@ -25,10 +29,10 @@ import (
 
 func resourceJob() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceJobRegister,
-		Update: resourceJobRegister,
-		Delete: resourceJobDeregister,
-		Read:   resourceJobRead,
+		CreateContext: resourceJobRegister,
+		UpdateContext: resourceJobRegister,
+		Delete:        resourceJobDeregister,
+		ReadContext:   resourceJobRead,
 
 		CustomizeDiff: resourceJobCustomizeDiff,
 
@@ -49,19 +53,22 @@ func resourceJob() *schema.Resource {
 				DiffSuppressFunc: jobspecDiffSuppress,
 			},
 
-			"policy_override": {
-				Description: "Override any soft-mandatory Sentinel policies that fail.",
+			"hash_jobspec": {
+				Description: "If true, only a hash of the rendered jobspec is stored in the Terraform state instead of its content.",
 				Optional:    true,
+				Default:     false,
 				Type:        schema.TypeBool,
 			},
 
-			"deregister_on_destroy": {
-				Description: "If true, the job will be deregistered on destroy.",
+			"policy_override": {
+				Description: "Override any soft-mandatory Sentinel policies that fail.",
 				Optional:    true,
-				Default:     true,
 				Type:        schema.TypeBool,
 			},
 
+			"deregister_on_destroy": deregisterOnDestroySchema("job"),
+			"retain_on_destroy":     retainOnDestroySchema("job"),
+
 			"deregister_on_id_change": {
 				Description: "If true, the job will be deregistered when the job ID changes.",
 				Optional:    true,
@@ -76,6 +83,13 @@ func resourceJob() *schema.Resource {
 				Type:        schema.TypeBool,
 			},
 
+			"monitor_events": {
+				Description: "If detach = false, monitor the deployment with the Nomad event stream and log the events of its tasks as they happen.",
+				Optional:    true,
+				Default:     false,
+				Type:        schema.TypeBool,
+			},
+
 			"deployment_id": {
 				Description: "If detach = false, the ID for the deployment associated with the last job create/update, if one exists.",
 				Computed:    true,
@@ -122,6 +136,18 @@ func resourceJob() *schema.Resource {
 				Type:        schema.TypeString, // it's an int64, so won't fit in our TypeInt
 			},
 
+			"registered_jobspec_hash": {
+				Description: "The hash of the jobspec and of its parser arguments the job was last registered with, used to skip parsing and planning the jobspec while neither it nor the job change.",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+
+			"create_index": {
+				Description: "The Raft index at which the job was created.",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+
 			"name": {
 				Description: "The name of the job, as derived from the jobspec.",
 				Computed:    true,
@@ -188,22 +214,27 @@ func resourceJob() *schema.Resource {
 
 			"task_groups": taskGroupSchema(),
 
-			"purge_on_destroy": {
-				Description: "Whether to purge the job when the resource is destroyed.",
+			"detect_drift": {
+				Description: "If true, the job is planned on refresh to detect the changes made to it outside of Terraform, which requires the ACL token to be allowed to submit the job.",
 				Optional:    true,
+				Default:     false,
 				Type:        schema.TypeBool,
 			},
+
+			"drift": {
+				Description: "The differences between the job running in the cluster and its jobspec, detected when the job is refreshed with detect_drift set. Each entry is the path of a field with its value in the cluster and in the jobspec.",
+				Computed:    true,
+				Type:        schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"purge_on_destroy": purgeOnDestroySchema("If true, the job will be purged on destroy instead of being left for Nomad to garbage collect.", false),
 		},
 	}
 }
 
-const (
-	MonitoringEvaluation = "monitoring_evaluation"
-	EvaluationComplete   = "evaluation_complete"
-	MonitoringDeployment = "monitoring_deployment"
-	DeploymentSuccessful = "deployment_successful"
-)
-
 func taskGroupSchema() *schema.Schema {
 	return &schema.Schema{
 		Computed: true,
@@ -331,7 +362,7 @@ type ResourceFieldGetter interface {
 	Get(string) interface{}
 }
 
-func resourceJobRegister(d *schema.ResourceData, meta interface{}) error {
+func resourceJobRegister(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	timeout := d.Timeout(schema.TimeoutCreate)
 	if !d.IsNewResource() {
 		timeout = d.Timeout(schema.TimeoutUpdate)
@@ -347,12 +378,12 @@ func resourceJobRegister(d *schema.ResourceData, meta interface{}) error {
 	// Read job parsing config.
 	jobParserConfig, err := parseJobParserConfig(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	job, err := parseJobspec(jobspecRaw, jobParserConfig)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if job.Namespace == nil || *job.Namespace == "" {
@@ -360,6 +391,19 @@ func resourceJobRegister(d *schema.ResourceData, meta interface{}) error {
 		job.Namespace = &defaultNamespace
 	}
 
+	// The hash is stored instead of the jobspec when hash_jobspec is set. It
+	// can't be done by a StateFunc on jobspec, which is only given the
+	// jobspec and not hash_jobspec or the HCL2 variables the hash depends
+	// on, so it's computed before registering the job to not fail once the
+	// job is running.
+	var hashedJobspec string
+	if d.Get("hash_jobspec").(bool) {
+		hashedJobspec, err = jobspecHash(jobspecRaw, jobParserConfig)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	// Register the job
 	wantModifyIndexStrI, _ := d.GetChange("modify_index")
 	wantModifyIndex, err := strconv.ParseUint(wantModifyIndexStrI.(string), 10, 64)
@@ -385,7 +429,7 @@ func resourceJobRegister(d *schema.ResourceData, meta interface{}) error {
 		Namespace: *job.Namespace,
 	})
 	if err != nil {
-		return fmt.Errorf("error applying jobspec: %s", err)
+		return diag.Errorf("error applying jobspec: %s", err)
 	}
 
 	if !d.IsNewResource() {
@@ -398,11 +442,19 @@ func resourceJobRegister(d *schema.ResourceData, meta interface{}) error {
 	d.Set("namespace", job.Namespace)
 	d.Set("modify_index", strconv.FormatUint(resp.JobModifyIndex, 10))
 
+	if hashedJobspec != "" {
+		d.Set("jobspec", hashedJobspec)
+	}
+	registeredHash, _ := registeredJobspecHash(jobspecRaw, jobParserConfig)
+	d.Set("registered_jobspec_hash", registeredHash)
+
 	if d.Get("detach") == false && resp.EvalID != "" {
 		log.Printf("[DEBUG] will monitor scheduling/deployment of job '%s' in namespace '%s'", *job.ID, *job.Namespace)
-		deployment, err := monitorDeployment(client, timeout, *job.Namespace, resp.EvalID)
+		deployment, err := monitorDeployment(ctx, client, providerConfig.poll, timeout, *job.Namespace, *job.ID, resp.EvalID, d.Get("monitor_events").(bool))
 		if err != nil {
-			return fmt.Errorf(
+			// The job is registered so its ID and modify index are kept in
+			// the state, even if the wait was canceled.
+			return diag.Errorf(
 				"error waiting for job '%s' to schedule/deploy successfully: %s",
 				*job.ID, err)
 		}
@@ -415,116 +467,108 @@ func resourceJobRegister(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	return resourceJobRead(d, meta) // populate other computed attributes
+	// The job was just registered from its jobspec so it can't have drifted.
+	d.Set("drift", nil)
+	return readJob(d, meta, false) // populate other computed attributes
 }
 
 // monitorDeployment monitors the evalution(s) from a job create/update and,
 // if they result in a deployment, monitors that deployment until completion.
-func monitorDeployment(client *api.Client, timeout time.Duration, namespace string, initialEvalID string) (*api.Deployment, error) {
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{MonitoringEvaluation},
-		Target:     []string{EvaluationComplete},
-		Refresh:    evaluationStateRefreshFunc(client, namespace, initialEvalID),
-		Timeout:    timeout,
-		Delay:      0,
-		MinTimeout: 3 * time.Second,
-	}
-
-	state, err := stateConf.WaitForState()
+// Both are watched with blocking queries so changes are detected as soon as
+// they happen without polling the API. When events is set, the deployment is
+// watched with the event stream instead so the events of its tasks can be
+// logged.
+//
+// It returns as soon as ctx is canceled, for example with Ctrl-C.
+func monitorDeployment(ctx context.Context, client *api.Client, poll pollConfig, timeout time.Duration, namespace, jobID, initialEvalID string, events bool) (*api.Deployment, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	evaluation, err := waitForEvaluation(ctx, client, poll, namespace, initialEvalID)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, waitDoneError(ctx, timeout, fmt.Sprintf("evaluation %q to complete", initialEvalID), nil)
+		}
 		return nil, fmt.Errorf("error waiting for evaluation: %s", err)
 	}
 
-	evaluation := state.(*api.Evaluation)
 	if evaluation.DeploymentID == "" {
 		log.Printf("[WARN] job has been scheduled, but there is no deployment to monitor")
 		return nil, nil
 	}
 
-	stateConf = &resource.StateChangeConf{
-		Pending:    []string{MonitoringDeployment},
-		Target:     []string{DeploymentSuccessful},
-		Refresh:    deploymentStateRefreshFunc(client, namespace, evaluation.DeploymentID),
-		Timeout:    timeout,
-		Delay:      0,
-		MinTimeout: 5 * time.Second,
+	var deployment *api.Deployment
+	if events {
+		deployment, err = monitorDeploymentEvents(ctx, client, namespace, jobID, evaluation.DeploymentID)
+		if errors.As(err, &eventStreamError{}) {
+			log.Printf("[WARN] %s, monitoring deployment '%s' with blocking queries instead", err, evaluation.DeploymentID)
+			deployment, err = waitForJobDeployment(ctx, client, poll, namespace, evaluation.DeploymentID)
+		}
+	} else {
+		deployment, err = waitForJobDeployment(ctx, client, poll, namespace, evaluation.DeploymentID)
 	}
-
-	state, err = stateConf.WaitForState()
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for evaluation: %s", err)
+		if ctx.Err() != nil {
+			return nil, waitDoneError(ctx, timeout, fmt.Sprintf("deployment %q to be successful", evaluation.DeploymentID), nil)
+		}
+		return nil, fmt.Errorf("error waiting for deployment: %s", err)
 	}
-	return state.(*api.Deployment), nil
+	return deployment, nil
 }
 
-// evaluationStateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
-// the evaluation(s) from a job create/update
-func evaluationStateRefreshFunc(client *api.Client, namespace string, initialEvalID string) resource.StateRefreshFunc {
-
-	// evalID is the evaluation that we are currently monitoring. This will change
-	// along with follow-up evaluations.
-	evalID := initialEvalID
-
-	return func() (interface{}, string, error) {
-		// monitor the eval
+// waitForEvaluation watches the evaluation from a job create/update, and the
+// follow-up evaluations it creates, until the last one is complete.
+func waitForEvaluation(ctx context.Context, client *api.Client, poll pollConfig, namespace string, evalID string) (*api.Evaluation, error) {
+	opts := (&api.QueryOptions{Namespace: namespace}).WithContext(ctx)
+	pacer := poll.pacer()
+	for {
 		log.Printf("[DEBUG] monitoring evaluation '%s' in namespace '%s'", evalID, namespace)
-		eval, _, err := client.Evaluations().Info(evalID, &api.QueryOptions{
-			Namespace: namespace,
-		})
+		eval, qm, err := client.Evaluations().Info(evalID, opts)
 		if err != nil {
-			log.Printf("[ERROR] error on Evaluation.Info during deploymentStateRefresh: %s", err)
-			return nil, "", err
+			log.Printf("[ERROR] error on Evaluation.Info while monitoring evaluation: %s", err)
+			return nil, err
 		}
 
-		var state string
 		switch eval.Status {
-		case "complete":
-			// Monitor the next eval in the chain, if present
+		case api.EvalStatusComplete:
 			log.Printf("[DEBUG] evaluation '%v' in namespace '%s' complete", eval.ID, namespace)
-			if eval.NextEval != "" {
-				log.Printf("[DEBUG] will monitor follow-up eval '%v'", eval.ID)
-				evalID = eval.NextEval
-				state = MonitoringEvaluation
-			} else {
-				state = EvaluationComplete
+			if eval.NextEval == "" {
+				return eval, nil
 			}
-		case "failed", "cancelled":
-			return nil, "", fmt.Errorf("evaluation failed: %v", eval.StatusDescription)
+
+			// Monitor the next eval in the chain, reading it without
+			// blocking first since it may already be complete.
+			log.Printf("[DEBUG] will monitor follow-up eval '%v'", eval.NextEval)
+			evalID = eval.NextEval
+			opts.WaitIndex = 0
+		case api.EvalStatusFailed, api.EvalStatusCancelled:
+			return nil, fmt.Errorf("evaluation failed: %v", eval.StatusDescription)
 		default:
-			state = MonitoringEvaluation
+			opts.WaitIndex = qm.LastIndex
+			pacer.wait(ctx)
 		}
-		return eval, state, nil
 	}
 }
 
-// deploymentStateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
-// the deployment from a job create/update
-func deploymentStateRefreshFunc(client *api.Client, namespace string, deploymentID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		// monitor the deployment
-		var state string
-		deployment, _, err := client.Deployments().Info(deploymentID, &api.QueryOptions{
-			Namespace: namespace,
-		})
+// waitForJobDeployment watches the deployment from a job create/update until
+// it is successful.
+func waitForJobDeployment(ctx context.Context, client *api.Client, poll pollConfig, namespace string, deploymentID string) (*api.Deployment, error) {
+	opts := (&api.QueryOptions{Namespace: namespace}).WithContext(ctx)
+	pacer := poll.pacer()
+	for {
+		deployment, qm, err := client.Deployments().Info(deploymentID, opts)
 		if err != nil {
-			log.Printf("[ERROR] error on Deployment.Info during deploymentStateRefresh: %s", err)
-			return nil, "", err
+			log.Printf("[ERROR] error on Deployment.Info while monitoring deployment: %s", err)
+			return nil, err
 		}
-		switch deployment.Status {
-		case "successful":
-			log.Printf("[DEBUG] deployment '%s' in namespace '%s' successful", deployment.ID, namespace)
-			state = DeploymentSuccessful
-		case "failed", "cancelled":
-			log.Printf("[DEBUG] deployment unsuccessful: %s", deployment.StatusDescription)
-			return deployment, "",
-				fmt.Errorf("deployment '%s' terminated with status '%s': '%s'",
-					deployment.ID, deployment.Status, deployment.StatusDescription)
-		default:
-			// don't overwhelm the API server
-			state = MonitoringDeployment
+
+		if done, err := checkJobDeployment(deployment); done {
+			return deployment, err
 		}
-		return deployment, state, nil
+
+		log.Printf("[DEBUG] waiting for deployment '%s' in namespace '%s', currently %s", deployment.ID, namespace, deployment.Status)
+		opts.WaitIndex = qm.LastIndex
+		pacer.wait(ctx)
 	}
 }
 
@@ -533,11 +577,7 @@ func resourceJobDeregister(d *schema.ResourceData, meta interface{}) error {
 	client := providerConfig.client
 
 	// If deregistration is disabled, then do nothing
-	deregister_on_destroy := d.Get("deregister_on_destroy").(bool)
-	if !deregister_on_destroy {
-		log.Printf(
-			"[WARN] job %q will not deregister since "+
-				"'deregister_on_destroy' is %t", d.Id(), deregister_on_destroy)
+	if skipDeregister(d, "job") {
 		return nil
 	}
 
@@ -558,7 +598,14 @@ func resourceJobDeregister(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceJobRead(d *schema.ResourceData, meta interface{}) error {
+func resourceJobRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readJob(d, meta, d.Get("detect_drift").(bool))
+}
+
+// readJob reads the job from the Nomad API and, when detectDrift is set,
+// compares it with its jobspec to report the changes made outside of
+// Terraform in the drift attribute.
+func readJob(d *schema.ResourceData, meta interface{}, detectDrift bool) diag.Diagnostics {
 	providerConfig := meta.(ProviderConfig)
 	client := providerConfig.client
 
@@ -574,16 +621,27 @@ func resourceJobRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			log.Printf("[DEBUG] job %q does not exist, so removing", id)
 			d.SetId("")
 			return nil
 		}
 
-		return fmt.Errorf("error checking for job: %s", err)
+		return diag.Errorf("error checking for job: %s", err)
 	}
 	log.Printf("[DEBUG] found job %q in namespace %q", *job.Name, *job.Namespace)
 
+	if !detectDrift {
+		d.Set("drift", nil)
+	}
+
+	// The jobspec in the state doesn't need to be read and planned again
+	// while the job is still the one registered from it.
+	unchanged := registeredJobUnchanged(d, job)
+	if !unchanged {
+		d.Set("registered_jobspec_hash", "")
+	}
+
 	d.Set("name", job.ID)
 	d.Set("type", job.Type)
 	d.Set("region", job.Region)
@@ -595,6 +653,11 @@ func resourceJobRead(d *schema.ResourceData, meta interface{}) error {
 	} else {
 		d.Set("modify_index", "0")
 	}
+	if job.CreateIndex != nil {
+		d.Set("create_index", strconv.FormatUint(*job.CreateIndex, 10))
+	} else {
+		d.Set("create_index", "0")
+	}
 	d.Set("status", job.Status)
 
 	if d.Get("read_allocation_ids").(bool) {
@@ -612,6 +675,11 @@ func resourceJobRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("allocation_ids", nil)
 	}
 
+	if unchanged {
+		log.Printf("[DEBUG] job %q is still the one registered from its jobspec, skipping its submission and drift detection", id)
+		return nil
+	}
+
 	// Update jobspec submission data if available.
 	// Safely ignore errors as this is an optional step.
 	sub, _, err := client.Jobs().Submission(*job.ID, int(*job.Version), opts)
@@ -624,7 +692,196 @@ func resourceJobRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	return nil
+	// Jobs registered without their source, like the ones created by other
+	// tools, don't have a submission. The JSON representation of the job is
+	// used instead when importing them so the state, and the configuration
+	// generated by Terraform, have a valid jobspec.
+	if d.Get("jobspec").(string) == "" {
+		log.Printf("[DEBUG] no jobspec submitted for job %q, using its JSON representation", id)
+		jobspec, err := jobspecFromJob(job)
+		if err != nil {
+			return diag.Errorf("error generating jobspec for job %q: %s", id, err)
+		}
+		d.Set("jobspec", jobspec)
+		d.Set("json", true)
+	}
+
+	var diags diag.Diagnostics
+	if detectDrift {
+		drift, err := jobDrift(client, d, opts.Namespace)
+		if err != nil {
+			// The drift can't be detected without the permission to plan
+			// the job, so the job can still be read without it.
+			log.Printf("[WARN] failed to detect the changes made to job %q outside of Terraform: %v", id, err)
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("Unable to detect the changes made to job %q outside of Terraform", id),
+				Detail:        fmt.Sprintf("The job could not be planned, its drift attribute was not updated: %v", err),
+				AttributePath: cty.GetAttrPath("drift"),
+			})
+		} else {
+			d.Set("drift", drift)
+
+			// The job was registered from the jobspec in the state, possibly
+			// by an older version of the provider, so it can be skipped on
+			// the next refreshes.
+			if len(drift) == 0 && !d.Get("hash_jobspec").(bool) {
+				if config, err := parseJobParserConfig(d); err == nil {
+					hash, _ := registeredJobspecHash(d.Get("jobspec").(string), config)
+					d.Set("registered_jobspec_hash", hash)
+				}
+			}
+		}
+	}
+
+	return diags
+}
+
+// registeredJobUnchanged returns whether job is still the job registered from
+// the jobspec in the state of d, that is its modify index didn't change since
+// it was last read and the jobspec in the state still has the hash stored in
+// registered_jobspec_hash.
+func registeredJobUnchanged(d *schema.ResourceData, job *api.Job) bool {
+	registered := d.Get("registered_jobspec_hash").(string)
+	if registered == "" || job.JobModifyIndex == nil {
+		return false
+	}
+	if d.Get("modify_index").(string) != strconv.FormatUint(*job.JobModifyIndex, 10) {
+		return false
+	}
+
+	// Only the hash of the jobspec is in the state when hash_jobspec is set.
+	if d.Get("hash_jobspec").(bool) {
+		return true
+	}
+	config, err := parseJobParserConfig(d)
+	if err != nil {
+		return false
+	}
+	hash, ok := registeredJobspecHash(d.Get("jobspec").(string), config)
+	return ok && hash == registered
+}
+
+// jobDrift returns the differences between the job running in the cluster and
+// the job parsed from the jobspec in the state, as planned by Nomad. The
+// jobspec itself is updated from the submission of the job when it is
+// registered outside of Terraform, so it only differs from the job when the
+// job was changed without a new jobspec, for example when it is scaled or
+// stopped.
+func jobDrift(client *api.Client, d *schema.ResourceData, namespace string) ([]string, error) {
+	if d.Get("hash_jobspec").(bool) {
+		log.Printf("[DEBUG] only the hash of the jobspec of job %q is stored, skipping drift detection", d.Id())
+		return nil, nil
+	}
+
+	config, err := parseJobParserConfig(d)
+	if err != nil {
+		return nil, err
+	}
+	job, err := parseJobspec(d.Get("jobspec").(string), config)
+	if err != nil {
+		return nil, err
+	}
+	if job.Namespace == nil || *job.Namespace == "" {
+		job.Namespace = &namespace
+	}
+
+	resp, _, err := client.Jobs().PlanOpts(job, &api.PlanOptions{
+		Diff:           true,
+		PolicyOverride: d.Get("policy_override").(bool),
+	}, &api.WriteOptions{Namespace: *job.Namespace})
+	if err != nil {
+		return nil, err
+	}
+	return flattenJobDiff(resp.Diff), nil
+}
+
+// flattenJobDiff returns one entry per field of diff that differs between the
+// job in the cluster and the planned job, or per object that is only in one
+// of them.
+func flattenJobDiff(diff *api.JobDiff) []string {
+	result := []string{}
+	if diff == nil || diff.Type == "None" {
+		return result
+	}
+
+	result = appendFieldDiffs(result, "", diff.Fields)
+	result = appendObjectDiffs(result, "", diff.Objects)
+	for _, tg := range diff.TaskGroups {
+		path := fmt.Sprintf("group[%q]", tg.Name)
+		if appendAddedOrDeleted(&result, path, tg.Type) {
+			continue
+		}
+		result = appendFieldDiffs(result, path+".", tg.Fields)
+		result = appendObjectDiffs(result, path+".", tg.Objects)
+		for _, task := range tg.Tasks {
+			taskPath := fmt.Sprintf("%s.task[%q]", path, task.Name)
+			if appendAddedOrDeleted(&result, taskPath, task.Type) {
+				continue
+			}
+			result = appendFieldDiffs(result, taskPath+".", task.Fields)
+			result = appendObjectDiffs(result, taskPath+".", task.Objects)
+		}
+	}
+	return result
+}
+
+func appendFieldDiffs(result []string, prefix string, fields []*api.FieldDiff) []string {
+	for _, f := range fields {
+		if f.Type == "None" {
+			continue
+		}
+		result = append(result, fmt.Sprintf("%s%s: %q => %q", prefix, f.Name, f.Old, f.New))
+	}
+	return result
+}
+
+func appendObjectDiffs(result []string, prefix string, objects []*api.ObjectDiff) []string {
+	for _, o := range objects {
+		path := prefix + o.Name
+		if o.Type == "None" || appendAddedOrDeleted(&result, path, o.Type) {
+			continue
+		}
+		result = appendFieldDiffs(result, path+".", o.Fields)
+		result = appendObjectDiffs(result, path+".", o.Objects)
+	}
+	return result
+}
+
+// appendAddedOrDeleted appends an entry for the objects that are only in the
+// cluster or in the jobspec and returns true if there is one.
+func appendAddedOrDeleted(result *[]string, path, diffType string) bool {
+	switch diffType {
+	case "Added":
+		*result = append(*result, path+": not in the cluster")
+	case "Deleted":
+		*result = append(*result, path+": not in the jobspec")
+	default:
+		return false
+	}
+	return true
+}
+
+// jobspecFromJob returns the JSON jobspec of a job read from the Nomad API,
+// without the fields set by the servers.
+func jobspecFromJob(job *api.Job) (string, error) {
+	// Shallow copy since only top-level fields are cleared.
+	j := *job
+	job = &j
+	job.Status = nil
+	job.StatusDescription = nil
+	job.Stable = nil
+	job.Version = nil
+	job.SubmitTime = nil
+	job.CreateIndex = nil
+	job.ModifyIndex = nil
+	job.JobModifyIndex = nil
+
+	out, err := json.MarshalIndent(struct{ Job *api.Job }{Job: job}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
 }
 
 func resourceJobReadSubmission(sub *api.JobSubmission, d *schema.ResourceData, meta any) error {
@@ -632,10 +889,16 @@ func resourceJobReadSubmission(sub *api.JobSubmission, d *schema.ResourceData, m
 		return nil
 	}
 
-	if sub.Source != "" {
+	// The source of the job is not stored when only its hash is kept in the
+	// state.
+	if sub.Source != "" && !d.Get("hash_jobspec").(bool) {
 		d.Set("jobspec", sub.Source)
 	}
 
+	if sub.Format == "json" {
+		d.Set("json", true)
+	}
+
 	if sub.Format == "hcl2" {
 		var err error
 		var hcl2Config HCL2JobParserConfig
@@ -670,7 +933,10 @@ func resourceJobCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta in
 	providerConfig := meta.(ProviderConfig)
 	client := providerConfig.client
 
-	if !d.NewValueKnown("jobspec") {
+	// The jobspec can only be parsed once it, and the variables it is
+	// templated with, are known. Until then the attributes derived from it
+	// are computed, and the jobspec is parsed during apply.
+	if !d.NewValueKnown("jobspec") || !jobParserConfigKnown(d) {
 		d.SetNewComputed("name")
 		d.SetNewComputed("modify_index")
 		d.SetNewComputed("namespace")
@@ -682,6 +948,8 @@ func resourceJobCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta in
 		d.SetNewComputed("deployment_id")
 		d.SetNewComputed("deployment_status")
 		d.SetNewComputed("status")
+		d.SetNewComputed("drift")
+		d.SetNewComputed("registered_jobspec_hash")
 		return nil
 	}
 
@@ -709,19 +977,30 @@ func resourceJobCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta in
 		return err
 	}
 
+	// Report the blocks the Nomad agent doesn't support now instead of
+	// failing to register the job during apply.
+	if err := checkJobVersionRequirements(meta, job); err != nil {
+		return err
+	}
+
 	defaultNamespace := "default"
 	if job.Namespace == nil || *job.Namespace == "" {
 		job.Namespace = &defaultNamespace
 	}
 
-	resp, _, err := client.Jobs().PlanOpts(job, &api.PlanOptions{
-		Diff:           false,
-		PolicyOverride: d.Get("policy_override").(bool),
-	}, &api.WriteOptions{
-		Namespace: *job.Namespace,
-	})
-	if err != nil {
-		log.Printf("[WARN] failed to validate Nomad plan: %s", err)
+	var resp *api.JobPlanResponse
+	if providerConfig.offline {
+		logOfflineSkip("the validation of the job plan")
+	} else {
+		resp, _, err = client.Jobs().PlanOpts(job, &api.PlanOptions{
+			Diff:           false,
+			PolicyOverride: d.Get("policy_override").(bool),
+		}, &api.WriteOptions{
+			Namespace: *job.Namespace,
+		})
+		if err != nil {
+			log.Printf("[WARN] failed to validate Nomad plan: %s", err)
+		}
 	}
 
 	// If we were able to successfully plan then we can safely populate our
@@ -776,6 +1055,8 @@ func resourceJobCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta in
 	d.SetNewComputed("modify_index")
 	// similarly, we won't know the allocation ids until after the job registration eval
 	d.SetNewComputed("allocation_ids")
+	d.SetNewComputed("drift")
+	d.SetNewComputed("registered_jobspec_hash")
 
 	d.SetNew("task_groups", jobTaskGroupsRaw(job.TaskGroups))
 
@@ -846,7 +1127,85 @@ func flattenHCL2JobParserConfig(c HCL2JobParserConfig) []any {
 	}}
 }
 
+// jobspecHashPrefix is the prefix of the jobspec hashes stored in the state
+// when hash_jobspec is set.
+const jobspecHashPrefix = "sha256:"
+
+// jobspecHash returns the hash of the job rendered from a jobspec. The job is
+// hashed instead of the jobspec so, like when its content is stored in the
+// state, changes that don't modify the job don't cause a diff.
+//
+// The hashes are cached, and the job is not kept once hashed, so comparing
+// large jobspecs doesn't hold several copies of their jobs in memory.
+func jobspecHash(raw string, config JobParserConfig) (string, error) {
+	key, cacheable := jobspecCacheKey(raw, config)
+	if cacheable {
+		if hash, ok := jobspecHashCache.Get(key); ok {
+			return hash, nil
+		}
+	}
+
+	job, err := parseJobspecNoCache(raw, config)
+	if err != nil {
+		return "", err
+	}
+	job.Canonicalize()
+
+	out, err := json.Marshal(job)
+	if err != nil {
+		return "", fmt.Errorf("error hashing jobspec: %s", err)
+	}
+	sum := sha256.Sum256(out)
+	hash := jobspecHashPrefix + hex.EncodeToString(sum[:])
+	if cacheable {
+		jobspecHashCache.Add(key, hash)
+	}
+	return hash, nil
+}
+
+// registeredJobspecHash returns the hash stored in registered_jobspec_hash
+// for a jobspec and its parser configuration, ignoring the whitespace around
+// the jobspec. Unlike jobspecHash, it doesn't parse the jobspec. The jobspecs
+// parsed with AllowFS don't have one since the files they read are not part
+// of it.
+func registeredJobspecHash(raw string, config JobParserConfig) (string, bool) {
+	return jobspecCacheKey(strings.TrimSpace(raw), config)
+}
+
+// jobParserConfigKnown returns whether the arguments that configure how the
+// jobspec is parsed are known, for example when an HCL2 variable is set to the
+// attribute of a resource that is not created yet.
+func jobParserConfigKnown(d *schema.ResourceDiff) bool {
+	config := d.GetRawConfig()
+	if !config.IsKnown() {
+		return false
+	}
+	if config.IsNull() {
+		return true
+	}
+	return config.GetAttr("json").IsWhollyKnown() && config.GetAttr("hcl2").IsWhollyKnown()
+}
+
 func parseJobspec(raw string, config JobParserConfig) (*api.Job, error) {
+	key, cacheable := jobspecCacheKey(raw, config)
+	cacheable = cacheable && len(raw) <= jobspecCacheMaxLen
+	if cacheable {
+		if job, ok := cachedJobspec(key); ok {
+			return job, nil
+		}
+	}
+
+	job, err := parseJobspecNoCache(raw, config)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable {
+		cacheJobspec(key, job)
+	}
+	return job, nil
+}
+
+func parseJobspecNoCache(raw string, config JobParserConfig) (*api.Job, error) {
 	var job *api.Job
 	var err error
 
@@ -991,10 +1350,9 @@ func jobspecDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
 }
 
 func jobspecEqual(k, old, new string, d ResourceFieldGetter) bool {
-	var oldJob *api.Job
-	var newJob *api.Job
-	var oldErr error
-	var newErr error
+	if old == new {
+		return true
+	}
 
 	// Read job parsing config.
 	jobParserConfig, err := parseJobParserConfig(d)
@@ -1003,31 +1361,28 @@ func jobspecEqual(k, old, new string, d ResourceFieldGetter) bool {
 		return false
 	}
 
-	switch {
-	case jobParserConfig.JSON.Enabled:
-		oldJob, oldErr = parseJSONJobspec(old)
-		newJob, newErr = parseJSONJobspec(new)
-	default:
-		oldJob, oldErr = parseHCL2Jobspec(old, jobParserConfig.HCL2)
-		newJob, newErr = parseHCL2Jobspec(new, jobParserConfig.HCL2)
+	// The jobspec the job was registered with doesn't need to be parsed.
+	if registered, _ := d.Get("registered_jobspec_hash").(string); registered != "" {
+		if hash, ok := registeredJobspecHash(new, jobParserConfig); ok && hash == registered {
+			log.Printf("[DEBUG] %s has the hash of the registered jobspec", k)
+			return true
+		}
 	}
-	if oldErr != nil {
-		log.Println("error parsing old jobspec")
-		log.Printf("%v\n", oldJob)
-		log.Printf("%v", oldErr)
-		return false
+
+	// The jobs are compared by their hash, only the hash of the jobspec is
+	// in the state when hash_jobspec is set.
+	oldHash := old
+	if !strings.HasPrefix(old, jobspecHashPrefix) {
+		oldHash, err = jobspecHash(old, jobParserConfig)
+		if err != nil {
+			log.Printf("[DEBUG] error hashing old jobspec: %v", err)
+			return false
+		}
 	}
-	if newErr != nil {
-		log.Println("error parsing new jobspec")
-		log.Printf("%v\n", newJob)
-		log.Printf("%v", newErr)
+	newHash, err := jobspecHash(new, jobParserConfig)
+	if err != nil {
+		log.Printf("[DEBUG] error hashing new jobspec: %v", err)
 		return false
 	}
-
-	// Init
-	oldJob.Canonicalize()
-	newJob.Canonicalize()
-
-	// Check for jobspec equality
-	return reflect.DeepEqual(oldJob, newJob)
+	return oldHash == newHash
 }
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
+)
+
+func resourceJobEval() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceJobEvalCreate,
+		DeleteContext: resourceJobEvalDelete,
+		ReadContext:   resourceJobEvalRead,
+
+		Schema: map[string]*schema.Schema{
+			"job_id": {
+				Description: "The ID of the job to evaluate.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"namespace": {
+				Description:      "The namespace of the job.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          api.DefaultNamespace,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
+			},
+			"triggers": {
+				Description: "Arbitrary map of values that, when changed, will trigger a new evaluation of the job.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"force_reschedule": {
+				Description: "Reschedule the failed allocations of the job even if their reschedule policy doesn't allow it.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"eval_id": {
+				Description: "The ID of the evaluation created.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "The status of the evaluation.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceJobEvalCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(ProviderConfig).client
+
+	jobID := d.Get("job_id").(string)
+	opts := api.EvalOptions{ForceReschedule: d.Get("force_reschedule").(bool)}
+	wOpts := &api.WriteOptions{Namespace: d.Get("namespace").(string)}
+
+	log.Printf("[DEBUG] Evaluating job %q", jobID)
+	evalID, _, err := client.Jobs().EvaluateWithOpts(jobID, opts, wOpts)
+	if err != nil {
+		return diag.Errorf("error evaluating job %q: %s", jobID, err)
+	}
+	log.Printf("[DEBUG] Created evaluation %q for job %q", evalID, jobID)
+
+	d.SetId(evalID)
+	d.Set("eval_id", evalID)
+
+	return resourceJobEvalRead(ctx, d, meta)
+}
+
+// resourceJobEvalDelete only removes the evaluation from state, since an
+// evaluation can't be undone.
+func resourceJobEvalDelete(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+func resourceJobEvalRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(ProviderConfig).client
+	id := d.Id()
+
+	log.Printf("[DEBUG] Reading evaluation %q", id)
+	eval, _, err := client.Evaluations().Info(id, &api.QueryOptions{Namespace: d.Get("namespace").(string)})
+	if err != nil {
+		// Evaluations are eventually garbage collected, but that doesn't mean
+		// the job needs to be evaluated again.
+		if isNotFoundError(err) {
+			log.Printf("[DEBUG] Evaluation %q not found", id)
+			return nil
+		}
+		return diag.Errorf("error reading evaluation %q: %s", id, err)
+	}
+	log.Printf("[DEBUG] Read evaluation %q", id)
+
+	d.Set("eval_id", eval.ID)
+	d.Set("status", eval.Status)
+
+	return nil
+}
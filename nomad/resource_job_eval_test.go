@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestResourceJobEval_basic(t *testing.T) {
+	jobID := acctest.RandomWithPrefix("tf-nomad-test")
+	resourceName := "nomad_job_eval.test"
+
+	var firstEvalID string
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testResourceJobEval_register(t, jobID) },
+				Config:    testResourceJobEval_config(jobID, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "job_id", jobID),
+					resource.TestCheckResourceAttrSet(resourceName, "eval_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "status"),
+					func(s *terraform.State) error {
+						firstEvalID = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testResourceJobEval_config(jobID, "2"),
+				Check: func(s *terraform.State) error {
+					if id := s.RootModule().Resources[resourceName].Primary.ID; id == firstEvalID {
+						return fmt.Errorf("expected a new evaluation, got %q again", id)
+					}
+					return nil
+				},
+			},
+		},
+		CheckDestroy: testResourceDeploymentPromote_deregister(jobID),
+	})
+}
+
+func testResourceJobEval_config(jobID, trigger string) string {
+	return fmt.Sprintf(`
+resource "nomad_job_eval" "test" {
+  job_id           = %q
+  force_reschedule = true
+
+  triggers = {
+    trigger = %q
+  }
+}
+`, jobID, trigger)
+}
+
+func testResourceJobEval_register(t *testing.T, jobID string) {
+	client := testProvider.Meta().(ProviderConfig).client
+
+	job := api.NewServiceJob(jobID, jobID, "global", 50)
+	job.Datacenters = []string{"dc1"}
+	job.AddTaskGroup(api.NewTaskGroup("test", 1).AddTask(
+		api.NewTask("test", "raw_exec").
+			SetConfig("command", "/bin/sleep").
+			SetConfig("args", []string{"3600"}).
+			Require(&api.Resources{CPU: pointer.Of(100), MemoryMB: pointer.Of(10)}),
+	))
+
+	_, _, err := client.Jobs().Register(job, nil)
+	must.NoError(t, err)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/nomad/api"
+)
+
+// monitorDeploymentEvents watches the deployment from a job create/update with
+// the Nomad event stream until it is successful. The events of the tasks of
+// the allocations of the deployment are logged as they are received.
+//
+// The error returned when the event stream can't be used, for example because
+// it is disabled on the agent, is an eventStreamError so the deployment can be
+// watched with blocking queries instead.
+func monitorDeploymentEvents(ctx context.Context, client *api.Client, namespace, jobID, deploymentID string) (*api.Deployment, error) {
+	deployment, qm, err := client.Deployments().Info(deploymentID, &api.QueryOptions{Namespace: namespace})
+	if err != nil {
+		return nil, err
+	}
+	if done, err := checkJobDeployment(deployment); done {
+		return deployment, err
+	}
+
+	// Only the events at or after the index of the deployment are streamed
+	// so the changes that happen between the read above and the subscription
+	// are not missed.
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	topics := map[api.Topic][]string{
+		api.TopicDeployment: {jobID},
+		api.TopicAllocation: {jobID},
+	}
+	eventsCh, err := client.EventStream().Stream(streamCtx, topics, qm.LastIndex, &api.QueryOptions{Namespace: namespace})
+	if err != nil {
+		return nil, eventStreamError{err}
+	}
+
+	log.Printf("[DEBUG] monitoring deployment '%s' in namespace '%s' with the event stream", deploymentID, namespace)
+	tasks := newTaskEventLogger()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+
+		case events, ok := <-eventsCh:
+			if !ok {
+				return nil, eventStreamError{fmt.Errorf("event stream closed")}
+			}
+			if events.Err != nil {
+				return nil, eventStreamError{events.Err}
+			}
+
+			for _, event := range events.Events {
+				switch event.Topic {
+				case api.TopicAllocation:
+					alloc, err := event.Allocation()
+					if err != nil || alloc == nil || alloc.DeploymentID != deploymentID {
+						continue
+					}
+					tasks.log(alloc)
+
+				case api.TopicDeployment:
+					d, err := event.Deployment()
+					if err != nil || d == nil || d.ID != deploymentID {
+						continue
+					}
+					log.Printf("[INFO] deployment '%s' is %s: %s", d.ID, d.Status, d.StatusDescription)
+					if done, err := checkJobDeployment(d); done {
+						return d, err
+					}
+				}
+			}
+		}
+	}
+}
+
+// eventStreamError is returned by monitorDeploymentEvents when the event
+// stream fails.
+type eventStreamError struct {
+	err error
+}
+
+func (e eventStreamError) Error() string {
+	return fmt.Sprintf("error reading event stream: %s", e.err)
+}
+
+func (e eventStreamError) Unwrap() error {
+	return e.err
+}
+
+// checkJobDeployment returns whether the deployment is done, and an error if
+// it didn't succeed.
+func checkJobDeployment(deployment *api.Deployment) (bool, error) {
+	switch deployment.Status {
+	case api.DeploymentStatusSuccessful:
+		log.Printf("[DEBUG] deployment '%s' in namespace '%s' successful", deployment.ID, deployment.Namespace)
+		return true, nil
+	case api.DeploymentStatusFailed, api.DeploymentStatusCancelled:
+		log.Printf("[DEBUG] deployment unsuccessful: %s", deployment.StatusDescription)
+		return true, fmt.Errorf("deployment '%s' terminated with status '%s': '%s'",
+			deployment.ID, deployment.Status, deployment.StatusDescription)
+	}
+	return false, nil
+}
+
+// taskEventLogger logs the events of the tasks of allocations, skipping the
+// events that were already logged since allocation events include all the
+// events of their tasks.
+type taskEventLogger struct {
+	// seen is the time of the last event logged for each task, indexed by the
+	// allocation ID and the task name. Nomad only keeps the last events of
+	// the tasks, so their number can't tell which ones were logged.
+	seen map[string]map[string]int64
+}
+
+func newTaskEventLogger() *taskEventLogger {
+	return &taskEventLogger{seen: make(map[string]map[string]int64)}
+}
+
+func (l *taskEventLogger) log(alloc *api.Allocation) {
+	if l.seen[alloc.ID] == nil {
+		l.seen[alloc.ID] = make(map[string]int64)
+	}
+
+	names := make([]string, 0, len(alloc.TaskStates))
+	for name := range alloc.TaskStates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		state := alloc.TaskStates[name]
+		last, logged := l.seen[alloc.ID][name]
+		for _, event := range state.Events {
+			if event == nil || logged && event.Time <= last {
+				continue
+			}
+			msg := event.DisplayMessage
+			if msg == "" {
+				msg = event.Message
+			}
+			log.Printf("[INFO] allocation '%s' task '%s': %s: %s", alloc.ID[:min(8, len(alloc.ID))], name, event.Type, msg)
+			last, logged = max(last, event.Time), true
+		}
+		if logged {
+			l.seen[alloc.ID][name] = last
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+)
+
+func TestMonitorDeploymentEvents(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/deployment/deploy1", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-Nomad-Index", "5")
+		json.NewEncoder(w).Encode(api.Deployment{ID: "deploy1", Status: api.DeploymentStatusRunning})
+	})
+	mux.HandleFunc("/v1/event/stream", func(w http.ResponseWriter, req *http.Request) {
+		must.Eq(t, "5", req.URL.Query().Get("index"))
+		must.SliceContainsAll(t, []string{"Deployment:example", "Allocation:example"}, req.URL.Query()["topic"])
+
+		enc := json.NewEncoder(w)
+		enc.Encode(api.Events{Index: 6, Events: []api.Event{{
+			Topic: api.TopicAllocation,
+			Index: 6,
+			Payload: map[string]any{
+				"Allocation": api.Allocation{
+					ID:           "aaaaaaaa-0000-0000-0000-000000000000",
+					DeploymentID: "deploy1",
+					TaskStates: map[string]*api.TaskState{
+						"web": {Events: []*api.TaskEvent{{Type: "Started", DisplayMessage: "Task started by client"}}},
+					},
+				},
+			},
+		}}})
+		enc.Encode(api.Events{Index: 7, Events: []api.Event{{
+			Topic: api.TopicDeployment,
+			Index: 7,
+			Payload: map[string]any{
+				"Deployment": api.Deployment{ID: "other", Status: api.DeploymentStatusFailed},
+			},
+		}, {
+			Topic: api.TopicDeployment,
+			Index: 7,
+			Payload: map[string]any{
+				"Deployment": api.Deployment{ID: "deploy1", Status: api.DeploymentStatusSuccessful},
+			},
+		}}})
+		w.(http.Flusher).Flush()
+		<-req.Context().Done()
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	must.NoError(t, err)
+
+	deployment, err := monitorDeploymentEvents(context.Background(), client, "default", "example", "deploy1")
+	must.NoError(t, err)
+	must.Eq(t, "deploy1", deployment.ID)
+	must.Eq(t, api.DeploymentStatusSuccessful, deployment.Status)
+}
+
+func TestMonitorDeploymentEvents_streamError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/deployment/deploy1", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-Nomad-Index", "5")
+		json.NewEncoder(w).Encode(api.Deployment{ID: "deploy1", Status: api.DeploymentStatusRunning})
+	})
+	mux.HandleFunc("/v1/event/stream", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Permission denied"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	must.NoError(t, err)
+
+	_, err = monitorDeploymentEvents(context.Background(), client, "default", "example", "deploy1")
+	must.Error(t, err)
+	must.True(t, errors.As(err, &eventStreamError{}))
+}
+
+func TestTaskEventLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	l := newTaskEventLogger()
+	alloc := &api.Allocation{
+		ID: "alloc1",
+		TaskStates: map[string]*api.TaskState{
+			"web": {Events: []*api.TaskEvent{{Type: "Received", Time: 1}}},
+		},
+	}
+	l.log(alloc)
+	must.Eq(t, 1, strings.Count(buf.String(), "task 'web'"))
+
+	alloc.TaskStates["web"].Events = append(alloc.TaskStates["web"].Events, &api.TaskEvent{Type: "Started", Time: 2})
+	alloc.TaskStates["sidecar"] = &api.TaskState{Events: []*api.TaskEvent{{Type: "Received", Time: 1}}}
+	l.log(alloc)
+	must.Eq(t, 2, strings.Count(buf.String(), "task 'web'"))
+	must.Eq(t, 1, strings.Count(buf.String(), "task 'sidecar'"))
+
+	// Nomad drops the oldest events of the tasks, the new ones are still
+	// logged once the number of events stops growing.
+	buf.Reset()
+	alloc.TaskStates["web"].Events = []*api.TaskEvent{{Type: "Started", Time: 2}, {Type: "Restarting", Time: 3}}
+	l.log(alloc)
+	l.log(alloc)
+	must.Eq(t, 1, strings.Count(buf.String(), "task 'web'"))
+	must.StrContains(t, buf.String(), "Restarting")
+}
@@ -4,9 +4,12 @@
 package nomad
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"regexp"
@@ -21,9 +24,13 @@ import (
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-provider-nomad/nomad/helper/pointer"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -2369,6 +2376,128 @@ func TestVolumeSorting(t *testing.T) {
 	require.ElementsMatch(tg1, tg2)
 }
 
+func TestResourceJob_importWithoutSubmission(t *testing.T) {
+	jobID := acctest.RandomWithPrefix("tf-nomad-test")
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			{
+				// The job is registered through the API, so it doesn't have a
+				// submission with its source.
+				PreConfig: func() { testResourceJobEval_register(t, jobID) },
+				Config: `
+resource "nomad_job" "test" {
+  jobspec = ""
+}
+`,
+				ResourceName:  "nomad_job.test",
+				ImportState:   true,
+				ImportStateId: jobID + "@default",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 state, got %d", len(states))
+					}
+					attrs := states[0].Attributes
+					if attrs["json"] != "true" {
+						return fmt.Errorf("expected json to be true, got %q", attrs["json"])
+					}
+					job, err := parseJSONJobspec(attrs["jobspec"])
+					if err != nil {
+						return fmt.Errorf("failed to parse generated jobspec: %v", err)
+					}
+					if *job.ID != jobID || len(job.TaskGroups) != 1 {
+						return fmt.Errorf("unexpected job in generated jobspec: %#v", job)
+					}
+					if job.Version != nil || job.JobModifyIndex != nil {
+						return fmt.Errorf("expected generated jobspec to not have server fields")
+					}
+					return nil
+				},
+			},
+		},
+		CheckDestroy: testResourceDeploymentPromote_deregister(jobID),
+	})
+}
+
+func TestJobspecFromJob(t *testing.T) {
+	require := require.New(t)
+
+	job := api.NewServiceJob("example", "example", "global", 50)
+	job.Datacenters = []string{"dc1"}
+	job.Status = pointer.Of("running")
+	job.Version = pointer.Of(uint64(3))
+	job.JobModifyIndex = pointer.Of(uint64(42))
+	job.AddTaskGroup(api.NewTaskGroup("cache", 2).AddTask(
+		api.NewTask("redis", "docker").SetConfig("image", "redis:7"),
+	))
+
+	jobspec, err := jobspecFromJob(job)
+	require.NoError(err)
+
+	// The job read from the API is left as-is.
+	require.Equal("running", *job.Status)
+
+	parsed, err := parseJobspec(jobspec, JobParserConfig{JSON: JSONJobParserConfig{Enabled: true}})
+	require.NoError(err)
+	require.Equal("example", *parsed.ID)
+	require.Equal([]string{"dc1"}, parsed.Datacenters)
+	require.Equal("redis:7", parsed.TaskGroups[0].Tasks[0].Config["image"])
+	require.Nil(parsed.Status)
+	require.Nil(parsed.Version)
+	require.Nil(parsed.JobModifyIndex)
+}
+
+func TestParseJobspec_cache(t *testing.T) {
+	require := require.New(t)
+
+	jobspec := `
+variable "count" {
+  type = number
+}
+
+job "cached" {
+  group "web" {
+    count = var.count
+
+    task "server" {
+      driver = "docker"
+      config {
+        image = "nginx"
+      }
+    }
+  }
+}
+`
+	config := JobParserConfig{HCL2: HCL2JobParserConfig{Vars: map[string]string{"count": "2"}}}
+	key, ok := jobspecCacheKey(jobspec, config)
+	require.True(ok)
+	jobspecCache.Remove(key)
+
+	job, err := parseJobspec(jobspec, config)
+	require.NoError(err)
+	require.True(jobspecCache.Contains(key))
+
+	// The job returned is a copy, so changing it doesn't change the cache.
+	job.Namespace = pointer.Of("changed")
+	job.TaskGroups[0].Count = pointer.Of(5)
+
+	cached, err := parseJobspec(jobspec, config)
+	require.NoError(err)
+	require.Nil(cached.Namespace)
+	require.Equal(2, *cached.TaskGroups[0].Count)
+	require.Equal("nginx", cached.TaskGroups[0].Tasks[0].Config["image"])
+
+	// The variables are part of the key.
+	other, err := parseJobspec(jobspec, JobParserConfig{HCL2: HCL2JobParserConfig{Vars: map[string]string{"count": "3"}}})
+	require.NoError(err)
+	require.Equal(3, *other.TaskGroups[0].Count)
+
+	// The jobspecs that may read files are not cached.
+	_, ok = jobspecCacheKey(jobspec, JobParserConfig{HCL2: HCL2JobParserConfig{AllowFS: true}})
+	require.False(ok)
+}
+
 var testResourceJob_invalidNomadServerConfig = `
 provider "nomad" {
 	alias = "tf_test"
@@ -3196,3 +3325,396 @@ func testResourceJob_statusCheck(t *testing.T, status string) r.TestCheckFunc {
 		return nil
 	}
 }
+
+func TestMonitorDeployment_blockingQueries(t *testing.T) {
+	// The evaluation is complete after one blocking query, and has a
+	// follow-up evaluation that creates the deployment.
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		queries = append(queries, req.URL.Path+"?index="+req.URL.Query().Get("index"))
+
+		var body any
+		switch {
+		case req.URL.Path == "/v1/evaluation/eval1" && req.URL.Query().Get("index") == "":
+			w.Header().Set("X-Nomad-Index", "10")
+			body = api.Evaluation{ID: "eval1", Status: api.EvalStatusPending}
+		case req.URL.Path == "/v1/evaluation/eval1":
+			w.Header().Set("X-Nomad-Index", "11")
+			body = api.Evaluation{ID: "eval1", Status: api.EvalStatusComplete, NextEval: "eval2"}
+		case req.URL.Path == "/v1/evaluation/eval2":
+			w.Header().Set("X-Nomad-Index", "12")
+			body = api.Evaluation{ID: "eval2", Status: api.EvalStatusComplete, DeploymentID: "deploy1"}
+		case req.URL.Path == "/v1/deployment/deploy1" && req.URL.Query().Get("index") == "":
+			w.Header().Set("X-Nomad-Index", "12")
+			body = api.Deployment{ID: "deploy1", Status: api.DeploymentStatusRunning}
+		case req.URL.Path == "/v1/deployment/deploy1":
+			w.Header().Set("X-Nomad-Index", "20")
+			body = api.Deployment{ID: "deploy1", Status: api.DeploymentStatusSuccessful}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	require.NoError(t, err)
+
+	deployment, err := monitorDeployment(context.Background(), client, pollConfig{}, time.Minute, "default", "example", "eval1", false)
+	require.NoError(t, err)
+	require.Equal(t, "deploy1", deployment.ID)
+	require.Equal(t, []string{
+		"/v1/evaluation/eval1?index=",
+		"/v1/evaluation/eval1?index=10",
+		"/v1/evaluation/eval2?index=",
+		"/v1/deployment/deploy1?index=",
+		"/v1/deployment/deploy1?index=12",
+	}, queries)
+}
+
+func TestMonitorDeployment_canceled(t *testing.T) {
+	// The evaluation never completes, the wait must stop as soon as the
+	// operation is canceled instead of waiting for the timeout.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("index") != "" {
+			<-req.Context().Done()
+			return
+		}
+		w.Header().Set("X-Nomad-Index", "10")
+		json.NewEncoder(w).Encode(api.Evaluation{ID: "eval1", Status: api.EvalStatusPending})
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = monitorDeployment(ctx, client, pollConfig{}, time.Hour, "default", "example", "eval1", false)
+	require.EqualError(t, err, `canceled while waiting for evaluation "eval1" to complete`)
+	require.Less(t, time.Since(start), time.Minute)
+}
+
+func TestResourceJob_unknownHCL2Vars(t *testing.T) {
+	s := NewProviderServer(Provider())
+	ctx := context.Background()
+
+	schemaResp, err := s.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+
+	configResp, err := s.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{
+		Config: testObjectValue(t, schemaResp.Provider.ValueType(), map[string]tftypes.Value{
+			"offline": tftypes.NewValue(tftypes.Bool, true),
+		}),
+	})
+	require.NoError(t, err)
+	require.Empty(t, configResp.Diagnostics)
+
+	// The count of the job is the attribute of a resource that is not
+	// created yet, so the jobspec can't be parsed during plan.
+	jobType := schemaResp.ResourceSchemas["nomad_job"].ValueType()
+	hcl2Type := jobType.(tftypes.Object).AttributeTypes["hcl2"]
+	hcl2ElemType := hcl2Type.(tftypes.List).ElementType
+	config := testObjectValue(t, jobType, map[string]tftypes.Value{
+		"jobspec": tftypes.NewValue(tftypes.String, `
+variable "count" {
+  type = number
+}
+
+job "example" {
+  group "example" {
+    count = var.count
+
+    task "example" {
+      driver = "docker"
+    }
+  }
+}
+`),
+		"hcl2": tftypes.NewValue(hcl2Type, []tftypes.Value{
+			tftypes.NewValue(hcl2ElemType, map[string]tftypes.Value{
+				"allow_fs": tftypes.NewValue(tftypes.Bool, false),
+				"vars": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"count": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				}),
+			}),
+		}),
+	})
+
+	planResp, err := s.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         "nomad_job",
+		PriorState:       testObjectValue(t, jobType, nil),
+		ProposedNewState: config,
+		Config:           config,
+	})
+	require.NoError(t, err)
+	require.Empty(t, planResp.Diagnostics)
+
+	planned, err := planResp.PlannedState.Unmarshal(jobType)
+	require.NoError(t, err)
+	var attrs map[string]tftypes.Value
+	require.NoError(t, planned.As(&attrs))
+	require.False(t, attrs["name"].IsKnown())
+	require.False(t, attrs["modify_index"].IsKnown())
+}
+
+func TestJobspecHash(t *testing.T) {
+	jobspec := `
+job "example" {
+  group "example" {
+    task "example" {
+      driver = "docker"
+    }
+  }
+}
+`
+	hash, err := jobspecHash(jobspec, JobParserConfig{})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, jobspecHashPrefix))
+
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, map[string]any{
+		"jobspec":      jobspec,
+		"hash_jobspec": true,
+	})
+
+	// Formatting changes don't modify the job.
+	formatted := strings.ReplaceAll(jobspec, "  ", "    ")
+	require.True(t, jobspecEqual("jobspec", hash, formatted, d))
+
+	changed := strings.ReplaceAll(jobspec, "docker", "exec")
+	require.False(t, jobspecEqual("jobspec", hash, changed, d))
+
+	// The hash is compared even if hash_jobspec was just unset, so the
+	// content of the jobspec is stored again without changing the job.
+	d = schema.TestResourceDataRaw(t, resourceJob().Schema, map[string]any{
+		"jobspec": jobspec,
+	})
+	require.True(t, jobspecEqual("jobspec", hash, jobspec, d))
+}
+
+func TestJobspecEqual_largeJobspec(t *testing.T) {
+	defer func(n int) { jobspecCacheMaxLen = n }(jobspecCacheMaxLen)
+	jobspecCacheMaxLen = 1024
+
+	var b strings.Builder
+	b.WriteString("job \"large\" {\n")
+	for i := 0; b.Len() <= jobspecCacheMaxLen; i++ {
+		fmt.Fprintf(&b, `
+  group "group-%[1]d" {
+    task "task-%[1]d" {
+      driver = "docker"
+      config {
+        image = "nginx"
+      }
+    }
+  }
+`, i)
+	}
+	b.WriteString("}\n")
+	jobspec := b.String()
+	formatted := strings.ReplaceAll(jobspec, "  ", "    ")
+
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, map[string]any{
+		"jobspec": formatted,
+	})
+	require.True(t, jobspecEqual("jobspec", jobspec, formatted, d))
+	require.False(t, jobspecEqual("jobspec", jobspec, strings.Replace(formatted, "nginx", "redis", 1), d))
+
+	// Only the hashes of the jobspecs this large are kept in memory.
+	key, ok := jobspecCacheKey(jobspec, JobParserConfig{})
+	require.True(t, ok)
+	require.True(t, jobspecHashCache.Contains(key))
+	require.False(t, jobspecCache.Contains(key))
+
+	_, err := parseJobspec(jobspec, JobParserConfig{})
+	require.NoError(t, err)
+	require.False(t, jobspecCache.Contains(key))
+}
+
+func TestRegisteredJobspecHash(t *testing.T) {
+	// Not a valid jobspec, so it can only be found equal without parsing it.
+	jobspec := `job "example" { invalid`
+	hash, ok := registeredJobspecHash(jobspec, JobParserConfig{})
+	require.True(t, ok)
+
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, map[string]any{
+		"jobspec": jobspec,
+	})
+	require.False(t, jobspecEqual("jobspec", "sha256:old", jobspec, d))
+
+	require.NoError(t, d.Set("registered_jobspec_hash", hash))
+	require.True(t, jobspecEqual("jobspec", "sha256:old", jobspec+"\n", d))
+	require.False(t, jobspecEqual("jobspec", "sha256:old", strings.Replace(jobspec, "example", "other", 1), d))
+
+	// The job is not read again while its modify index doesn't change.
+	require.NoError(t, d.Set("modify_index", "42"))
+	job := &api.Job{JobModifyIndex: pointer.Of(uint64(42))}
+	require.True(t, registeredJobUnchanged(d, job))
+
+	job.JobModifyIndex = pointer.Of(uint64(43))
+	require.False(t, registeredJobUnchanged(d, job))
+
+	job.JobModifyIndex = pointer.Of(uint64(42))
+	require.NoError(t, d.Set("jobspec", strings.Replace(jobspec, "example", "other", 1)))
+	require.False(t, registeredJobUnchanged(d, job))
+
+	// The jobspecs parsed with the file system functions are always parsed.
+	_, ok = registeredJobspecHash(jobspec, JobParserConfig{HCL2: HCL2JobParserConfig{AllowFS: true}})
+	require.False(t, ok)
+}
+
+func TestResourceJob_drift(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			{
+				Config: strings.Replace(testResourceJob_initialConfig, "jobspec = <<EOT", "detect_drift = true\n\tjobspec = <<EOT", 1),
+				Check: r.ComposeTestCheckFunc(
+					testResourceJob_initialCheck(t),
+					r.TestCheckResourceAttr("nomad_job.test", "drift.#", "0"),
+				),
+			},
+			// Scale the job outside of Terraform, the refresh reports the
+			// new count.
+			{
+				PreConfig: func() {
+					client := testProvider.Meta().(ProviderConfig).client
+					_, _, err := client.Jobs().Scale("foo", "foo", pointer.Of(2), "scaled outside of Terraform", false, nil, nil)
+					require.NoError(t, err)
+				},
+				RefreshState: true,
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("nomad_job.test", "drift.#", "1"),
+					r.TestCheckResourceAttr("nomad_job.test", "drift.0", `group["foo"].Count: "2" => "1"`),
+					r.TestCheckResourceAttr("nomad_job.test", "task_groups.0.count", "2"),
+				),
+			},
+		},
+		CheckDestroy: testResourceJob_checkDestroy("foo"),
+	})
+}
+
+func TestReadJob_detectDrift(t *testing.T) {
+	var plans int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/job/foo":
+			job := api.NewServiceJob("foo", "foo", "global", 50)
+			job.Namespace = pointer.Of("default")
+			job.Version = pointer.Of(uint64(0))
+			job.JobModifyIndex = pointer.Of(uint64(10))
+			json.NewEncoder(w).Encode(job)
+		case "/v1/job/foo/plan":
+			plans++
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("Permission denied"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	require.NoError(t, err)
+	meta := ProviderConfig{client: client}
+
+	data := func(detectDrift bool) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceJob().Schema, map[string]any{
+			"jobspec":      `job "foo" {}`,
+			"detect_drift": detectDrift,
+		})
+		d.SetId("foo")
+		return d
+	}
+
+	// The job is not planned by default.
+	diags := resourceJobRead(context.Background(), data(false), meta)
+	require.Empty(t, diags)
+	require.Equal(t, 0, plans)
+
+	// Failing to plan the job doesn't fail the refresh, but is reported.
+	diags = resourceJobRead(context.Background(), data(true), meta)
+	require.Equal(t, 1, plans)
+	require.Len(t, diags, 1)
+	require.Equal(t, diag.Warning, diags[0].Severity)
+	require.Contains(t, diags[0].Detail, "Permission denied")
+}
+
+func TestFlattenJobDiff(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal([]string{}, flattenJobDiff(nil))
+	require.Equal([]string{}, flattenJobDiff(&api.JobDiff{Type: "None"}))
+
+	diff := &api.JobDiff{
+		Type: "Edited",
+		Fields: []*api.FieldDiff{
+			{Type: "Edited", Name: "Stop", Old: "true", New: "false"},
+			{Type: "None", Name: "Priority", Old: "50", New: "50"},
+		},
+		TaskGroups: []*api.TaskGroupDiff{
+			{
+				Type: "Edited",
+				Name: "web",
+				Fields: []*api.FieldDiff{
+					{Type: "Edited", Name: "Count", Old: "3", New: "1"},
+				},
+				Tasks: []*api.TaskDiff{
+					{
+						Type: "Edited",
+						Name: "server",
+						Objects: []*api.ObjectDiff{
+							{
+								Type: "Edited",
+								Name: "Config",
+								Fields: []*api.FieldDiff{
+									{Type: "Edited", Name: "image", Old: "nginx:1.27", New: "nginx:1.26"},
+								},
+							},
+						},
+					},
+					{Type: "Deleted", Name: "sidecar"},
+				},
+			},
+			{Type: "Added", Name: "cache"},
+		},
+	}
+	require.Equal([]string{
+		`Stop: "true" => "false"`,
+		`group["web"].Count: "3" => "1"`,
+		`group["web"].task["server"].Config.image: "nginx:1.27" => "nginx:1.26"`,
+		`group["web"].task["sidecar"]: not in the jobspec`,
+		`group["cache"]: not in the cluster`,
+	}, flattenJobDiff(diff))
+}
+
+func TestResourceJobCustomizeDiff_registeredJobspecHash(t *testing.T) {
+	r := resourceJob()
+	state := &terraform.InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"jobspec":                 `job "foo" {}`,
+			"modify_index":            "10",
+			"registered_jobspec_hash": "abc",
+		},
+	}
+	meta := ProviderConfig{offline: true}
+
+	for name, jobspec := range map[string]any{
+		"changed": `job "foo" { datacenters = ["dc1"] }`,
+		// The value of unknown attributes in the configuration given to Diff.
+		"unknown": "74D93920-ED26-11E3-AC10-0800200C9A66",
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]any{"jobspec": jobspec})
+			diff, err := r.Diff(context.Background(), state, config, meta)
+			require.NoError(t, err)
+			require.NotNil(t, diff.Attributes["registered_jobspec_hash"])
+			require.True(t, diff.Attributes["registered_jobspec_hash"].NewComputed)
+		})
+	}
+}
@@ -4,27 +4,37 @@
 package nomad
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNamespace() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNamespaceWrite,
-		Update: resourceNamespaceWrite,
-		Delete: resourceNamespaceDelete,
-		Read:   resourceNamespaceRead,
-		Exists: resourceNamespaceExists,
+		Create:        resourceNamespaceWrite,
+		Update:        resourceNamespaceWrite,
+		DeleteContext: resourceNamespaceDelete,
+		Read:          resourceNamespaceRead,
+		Exists:        resourceNamespaceExists,
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceNamespaceCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "Unique name for this namespace.",
@@ -72,6 +82,32 @@ func resourceNamespace() *schema.Resource {
 				// pool is set to `default` if not set.
 				Computed: true,
 			},
+
+			"deregister_on_destroy": deregisterOnDestroySchema("namespace"),
+			"retain_on_destroy":     retainOnDestroySchema("namespace"),
+			"force_destroy": {
+				Description: "If true, the jobs, variables and volumes of the namespace are deleted on destroy before the namespace itself. Requires force_destroy_confirmation to be set to the name of the namespace.",
+				Optional:    true,
+				Default:     false,
+				Type:        schema.TypeBool,
+			},
+			"force_destroy_confirmation": {
+				Description: "Must be set to the name of the namespace for force_destroy to be enabled.",
+				Optional:    true,
+				Type:        schema.TypeString,
+			},
+
+			"create_index": {
+				Description: "The Raft index at which the namespace was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the namespace was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -126,6 +162,102 @@ func resourceNamespaceNodePoolConfig() *schema.Resource {
 	}
 }
 
+// resourceNamespaceCustomizeDiff checks that the task drivers listed in the
+// capabilities are fingerprinted by at least one client, to catch typos in
+// driver names during plan instead of when jobs fail to be placed.
+func resourceNamespaceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta any) error {
+	if err := checkNamespaceForceDestroy(d); err != nil {
+		return err
+	}
+
+	if !d.HasChange("capabilities") || !d.NewValueKnown("capabilities") {
+		return nil
+	}
+
+	var drivers []string
+	for _, key := range []string{"capabilities.0.enabled_task_drivers", "capabilities.0.disabled_task_drivers"} {
+		for _, driver := range d.Get(key).([]any) {
+			if driver, ok := driver.(string); ok {
+				drivers = append(drivers, driver)
+			}
+		}
+	}
+	if len(drivers) == 0 {
+		return nil
+	}
+	if isOffline(meta) {
+		logOfflineSkip("the check of the task drivers")
+		return nil
+	}
+
+	client := meta.(ProviderConfig).client
+	nodes, _, err := client.Nodes().List(nil)
+	if err != nil {
+		// The token used may not be allowed to read nodes, the check is only
+		// a convenience so it's skipped.
+		log.Printf("[WARN] Unable to list nodes to check the task drivers of namespace %q: %s", d.Get("name"), err)
+		return nil
+	}
+	if len(nodes) == 0 {
+		log.Printf("[WARN] No clients registered, skipping the check of the task drivers of namespace %q", d.Get("name"))
+		return nil
+	}
+
+	known := make(map[string]struct{})
+	for _, node := range nodes {
+		for name := range node.Drivers {
+			known[name] = struct{}{}
+		}
+	}
+
+	if unknown := unknownTaskDrivers(drivers, known); len(unknown) > 0 {
+		return fmt.Errorf("task drivers %s are not fingerprinted by any client, known task drivers are %s",
+			strings.Join(unknown, ", "), strings.Join(sortedTaskDrivers(known), ", "))
+	}
+	return nil
+}
+
+// checkNamespaceForceDestroy returns an error when force_destroy is enabled
+// without force_destroy_confirmation matching the name of the namespace, so
+// the objects of a namespace can't be deleted by mistake.
+func checkNamespaceForceDestroy(d *schema.ResourceDiff) error {
+	if !d.Get("force_destroy").(bool) || !d.NewValueKnown("name") || !d.NewValueKnown("force_destroy_confirmation") {
+		return nil
+	}
+	name := d.Get("name").(string)
+	if d.Get("force_destroy_confirmation").(string) != name {
+		return fmt.Errorf("force_destroy_confirmation must be set to %q, the name of the namespace, to enable force_destroy", name)
+	}
+	return nil
+}
+
+// unknownTaskDrivers returns the sorted list of drivers that aren't known.
+func unknownTaskDrivers(drivers []string, known map[string]struct{}) []string {
+	seen := make(map[string]struct{})
+	var unknown []string
+	for _, driver := range drivers {
+		if _, ok := known[driver]; ok {
+			continue
+		}
+		if _, ok := seen[driver]; ok {
+			continue
+		}
+		seen[driver] = struct{}{}
+		unknown = append(unknown, driver)
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+func sortedTaskDrivers(drivers map[string]struct{}) []string {
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func resourceNamespaceWrite(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(ProviderConfig).client
 
@@ -163,55 +295,127 @@ func resourceNamespaceWrite(d *schema.ResourceData, meta interface{}) error {
 	return resourceNamespaceRead(d, meta)
 }
 
-func resourceNamespaceDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceNamespaceDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(ProviderConfig).client
 	name := d.Id()
 
-	log.Printf("[DEBUG] Deleting namespace %q", name)
-	retries := 0
-	for {
-		var err error
-		if name == api.DefaultNamespace {
-			log.Printf("[DEBUG] Can't delete default namespace, clearing attributes instead")
-			d.Set("description", "Default shared namespace")
-			d.Set("quota", "")
-			err = resourceNamespaceWrite(d, meta)
-		} else {
-			// make sure there are no quota specs associated with that namespace
-			if d.Get("quota") != "" {
-				d.Set("quota", "")
-				err = resourceNamespaceWrite(d, meta)
-				if err != nil {
-					return err
-				}
-			}
-			_, err = client.Namespaces().Delete(name, nil)
+	// If deregistration is disabled, then do nothing
+	if skipDeregister(d, "namespace") {
+		return nil
+	}
+
+	if name == api.DefaultNamespace {
+		log.Printf("[DEBUG] Can't delete default namespace, clearing attributes instead")
+		d.Set("description", "Default shared namespace")
+		d.Set("quota", "")
+		if err := resourceNamespaceWrite(d, meta); err != nil {
+			return diag.FromErr(err)
 		}
+		log.Printf("[DEBUG] %s namespace reset", name)
+		return nil
+	}
 
-		if err == nil {
-			break
-		} else if retries < 10 {
-			if strings.Contains(err.Error(), "has non-terminal jobs") || strings.Contains(err.Error(), "has non-terminal allocations") {
-				log.Printf("[WARN] could not delete namespace %q because of non-terminal jobs, will pause and retry", name)
-				time.Sleep(5 * time.Second)
-				retries++
-				continue
-			}
-			return fmt.Errorf("error deleting namespace %q: %s", name, err.Error())
-		} else {
-			return fmt.Errorf("too many failures attempting to delete namespace %q: %s", name, err.Error())
+	if destroyFlag(d, "force_destroy", false) {
+		if d.Get("force_destroy_confirmation").(string) != name {
+			return diag.Errorf("error deleting namespace %q: force_destroy_confirmation must be set to %q to enable force_destroy", name, name)
+		}
+		if err := emptyNamespace(ctx, client, name, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.Errorf("error deleting namespace %q: %s", name, err)
 		}
 	}
 
-	if name == api.DefaultNamespace {
-		log.Printf("[DEBUG] %s namespace reset", name)
-	} else {
-		log.Printf("[DEBUG] Deleted namespace %q", name)
+	// make sure there are no quota specs associated with that namespace
+	if d.Get("quota") != "" {
+		d.Set("quota", "")
+		if err := resourceNamespaceWrite(d, meta); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
+	log.Printf("[DEBUG] Deleting namespace %q", name)
+	err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+		_, err := client.Namespaces().Delete(name, nil)
+		if err == nil {
+			return nil
+		}
+		if strings.Contains(err.Error(), "has non-terminal jobs") || strings.Contains(err.Error(), "has non-terminal allocations") {
+			log.Printf("[WARN] could not delete namespace %q because of non-terminal jobs, will pause and retry", name)
+			return retry.RetryableError(err)
+		}
+		return retry.NonRetryableError(err)
+	})
+	if err != nil {
+		return diag.Errorf("error deleting namespace %q: %s", name, err)
+	}
+	log.Printf("[DEBUG] Deleted namespace %q", name)
+
 	return nil
 }
 
+// emptyNamespace purges the jobs and deletes the variables and volumes of the
+// namespace so it can be deleted.
+func emptyNamespace(ctx context.Context, client *api.Client, name string, timeout time.Duration) error {
+	q := &api.QueryOptions{Namespace: name}
+	w := &api.WriteOptions{Namespace: name}
+
+	jobs, _, err := client.Jobs().List(q)
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, job := range jobs {
+		log.Printf("[DEBUG] Purging job %q of namespace %q", job.ID, name)
+		if _, _, err := client.Jobs().Deregister(job.ID, true, w); err != nil {
+			return fmt.Errorf("failed to purge job %q: %w", job.ID, err)
+		}
+	}
+
+	vars, _, err := client.Variables().PrefixList("", q)
+	if err != nil {
+		return fmt.Errorf("failed to list variables: %w", err)
+	}
+	for _, v := range vars {
+		log.Printf("[DEBUG] Deleting variable %q of namespace %q", v.Path, name)
+		if _, err := client.Variables().Delete(v.Path, w); err != nil {
+			return fmt.Errorf("failed to delete variable %q: %w", v.Path, err)
+		}
+	}
+
+	// The volumes can only be deleted once the allocations of the jobs
+	// purged above no longer claim them.
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		vols, _, err := client.CSIVolumes().List(q)
+		if err != nil {
+			return retry.NonRetryableError(fmt.Errorf("failed to list CSI volumes: %w", err))
+		}
+		for _, vol := range vols {
+			log.Printf("[DEBUG] Deregistering CSI volume %q of namespace %q", vol.ID, name)
+			if err := client.CSIVolumes().Deregister(vol.ID, true, w); err != nil {
+				return retry.RetryableError(fmt.Errorf("failed to deregister CSI volume %q: %w", vol.ID, err))
+			}
+		}
+
+		hostVols, _, err := client.HostVolumes().List(nil, q)
+		if err != nil {
+			// Dynamic host volumes require Nomad 1.10.
+			if isNotFoundError(err) {
+				return nil
+			}
+			return retry.NonRetryableError(fmt.Errorf("failed to list dynamic host volumes: %w", err))
+		}
+		for _, vol := range hostVols {
+			log.Printf("[DEBUG] Deleting dynamic host volume %q of namespace %q", vol.ID, name)
+			_, _, err := client.HostVolumes().Delete(&api.HostVolumeDeleteRequest{ID: vol.ID}, w)
+			if err != nil {
+				if strings.Contains(err.Error(), "in use by allocations") {
+					return retry.RetryableError(fmt.Errorf("failed to delete dynamic host volume %q: %w", vol.ID, err))
+				}
+				return retry.NonRetryableError(fmt.Errorf("failed to delete dynamic host volume %q: %w", vol.ID, err))
+			}
+		}
+		return nil
+	})
+}
+
 func resourceNamespaceRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(ProviderConfig).client
 	name := d.Id()
@@ -230,6 +434,8 @@ func resourceNamespaceRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("meta", namespace.Meta)
 	d.Set("capabilities", flattenNamespaceCapabilities(namespace.Capabilities))
 	d.Set("node_pool_config", flattenNamespaceNodePoolConfig(namespace.NodePoolConfiguration))
+	d.Set("create_index", int(namespace.CreateIndex))
+	d.Set("modify_index", int(namespace.ModifyIndex))
 
 	return nil
 }
@@ -245,7 +451,7 @@ func resourceNamespaceExists(d *schema.ResourceData, meta interface{}) (bool, er
 		// rather than a nil result, so we must check this way.
 		// there's an open issue to resolve this situation:
 		// https://github.com/hashicorp/nomad/issues/1849
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			return false, nil
 		}
 
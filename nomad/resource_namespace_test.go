@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper/pointer"
 )
 
 func TestResourceNamespace_import(t *testing.T) {
@@ -213,6 +214,74 @@ resource "nomad_namespace" "test" {
 	})
 }
 
+func TestResourceNamespace_unknownTaskDriver(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-nomad-test")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "nomad_namespace" "test" {
+  name = "%s"
+
+  capabilities {
+    enabled_task_drivers = ["dokcer", "exec"]
+  }
+}
+`, name),
+				ExpectError: regexp.MustCompile("task drivers dokcer are not fingerprinted by any client"),
+			},
+		},
+		CheckDestroy: testResourceNamespace_checkDestroy(name),
+	})
+}
+
+func TestResourceNamespace_forceDestroy(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-nomad-test")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testResourceNamespace_forceDestroyConfig(name, "wrong"),
+				ExpectError: regexp.MustCompile(`force_destroy_confirmation must be set to "` + name + `"`),
+			},
+			{
+				Config: testResourceNamespace_forceDestroyConfig(name, name),
+				Check:  testResourceNamespace_addObjects(name),
+			},
+		},
+
+		// The job and the variable registered outside of Terraform would
+		// prevent the namespace from being deleted without force_destroy.
+		CheckDestroy: testResourceNamespace_checkDestroy(name),
+	})
+}
+
+func TestUnknownTaskDrivers(t *testing.T) {
+	known := map[string]struct{}{"docker": {}, "exec": {}, "raw_exec": {}}
+
+	cases := []struct {
+		drivers []string
+		want    []string
+	}{
+		{drivers: nil, want: nil},
+		{drivers: []string{"docker", "exec"}, want: nil},
+		{drivers: []string{"exec", "dokcer"}, want: []string{"dokcer"}},
+		{drivers: []string{"qemu", "dokcer", "qemu"}, want: []string{"dokcer", "qemu"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(strings.Join(tc.drivers, ","), func(t *testing.T) {
+			got := unknownTaskDrivers(tc.drivers, known)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected unknown task drivers (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func testResourceNamespace_initialConfig(name string) string {
 	return fmt.Sprintf(`
 resource "nomad_namespace" "test" {
@@ -231,6 +300,45 @@ resource "nomad_namespace" "test" {
 `, name)
 }
 
+func testResourceNamespace_forceDestroyConfig(name, confirmation string) string {
+	return fmt.Sprintf(`
+resource "nomad_namespace" "test" {
+  name                       = "%s"
+  force_destroy              = true
+  force_destroy_confirmation = "%s"
+}
+`, name, confirmation)
+}
+
+func testResourceNamespace_addObjects(name string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testProvider.Meta().(ProviderConfig).client
+
+		job := api.NewServiceJob("force-destroy", "force-destroy", "global", 50)
+		job.Namespace = pointer.Of(name)
+		job.Datacenters = []string{"dc1"}
+		job.AddTaskGroup(api.NewTaskGroup("test", 1).AddTask(
+			api.NewTask("test", "raw_exec").
+				SetConfig("command", "/bin/sleep").
+				SetConfig("args", []string{"3600"}).
+				Require(&api.Resources{CPU: pointer.Of(100), MemoryMB: pointer.Of(10)}),
+		))
+		if _, _, err := client.Jobs().Register(job, nil); err != nil {
+			return fmt.Errorf("failed to register job: %w", err)
+		}
+
+		v := &api.Variable{
+			Namespace: name,
+			Path:      "force-destroy",
+			Items:     api.VariableItems{"key": "value"},
+		}
+		if _, _, err := client.Variables().Create(v, &api.WriteOptions{Namespace: name}); err != nil {
+			return fmt.Errorf("failed to create variable: %w", err)
+		}
+		return nil
+	}
+}
+
 func testResourceNamespace_configWithQuota(name, quota string) string {
 	return fmt.Sprintf(`
 resource "nomad_quota_specification" "test_quota" {
@@ -4,12 +4,16 @@
 package nomad
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
@@ -21,13 +25,23 @@ const (
 	nodePoolMemoryOversubscriptionDisabled = "disabled"
 )
 
+// errNodePoolSchedulerConfigEnt is returned when scheduler_config is set on a
+// Nomad cluster that doesn't run Nomad Enterprise.
+var errNodePoolSchedulerConfigEnt = errors.New("scheduler_config is only supported in Nomad Enterprise")
+
 func resourceNodePool() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNodePoolWrite,
-		Update: resourceNodePoolWrite,
-		Delete: resourceNodePoolDelete,
-		Read:   resourceNodePoolRead,
-		Exists: resourceNodePoolExists,
+		Create:        resourceNodePoolWrite,
+		Update:        resourceNodePoolWrite,
+		DeleteContext: resourceNodePoolDelete,
+		Read:          resourceNodePoolRead,
+		Exists:        resourceNodePoolExists,
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: resourceNodePoolCustomizeDiff,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -66,6 +80,18 @@ func resourceNodePool() *schema.Resource {
 				MaxItems:    1,
 				Optional:    true,
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the node pool was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the node pool was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -102,6 +128,36 @@ func resourceNodePoolSchedulerConfiguration() *schema.Resource {
 
 }
 
+// resourceNodePoolCustomizeDiff reports during plan that scheduler_config
+// can't be used when the cluster doesn't run Nomad Enterprise.
+func resourceNodePoolCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta any) error {
+	if !d.HasChange("scheduler_config") || len(d.Get("scheduler_config").([]any)) == 0 {
+		return nil
+	}
+	if isOffline(meta) {
+		logOfflineSkip("the check of the Nomad Enterprise license")
+		return nil
+	}
+	if _, ok := meta.(ProviderConfig).nomadVersion(); ok {
+		// The version requirements of scheduler_config have already been
+		// checked against the version of the agent.
+		return nil
+	}
+
+	client := meta.(ProviderConfig).client
+
+	// The license endpoint is only available in Nomad Enterprise, other
+	// errors such as missing permissions are left for the apply to report.
+	_, _, err := client.Operator().LicenseGet(nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "Nomad Enterprise only endpoint") {
+			return errNodePoolSchedulerConfigEnt
+		}
+		log.Printf("[WARN] Failed to check if Nomad Enterprise is used for node pool scheduler_config: %v", err)
+	}
+	return nil
+}
+
 func resourceNodePoolRead(d *schema.ResourceData, meta any) error {
 	client := meta.(ProviderConfig).client
 	name := d.Id()
@@ -119,6 +175,8 @@ func resourceNodePoolRead(d *schema.ResourceData, meta any) error {
 	sw.Set("description", pool.Description)
 	sw.Set("meta", pool.Meta)
 	sw.Set("scheduler_config", flattenNodePoolSchedulerConfiguration(pool.SchedulerConfiguration))
+	sw.Set("create_index", int(pool.CreateIndex))
+	sw.Set("modify_index", int(pool.ModifyIndex))
 
 	return sw.Error()
 }
@@ -145,6 +203,9 @@ func resourceNodePoolWrite(d *schema.ResourceData, meta any) error {
 
 	log.Printf("[DEBUG] Upserting node pool %q", pool.Name)
 	if _, err := client.NodePools().Register(pool, nil); err != nil {
+		if strings.Contains(err.Error(), "Node Pools Governance") {
+			return fmt.Errorf("error upserting node pool %q: %w: %v", pool.Name, errNodePoolSchedulerConfigEnt, err)
+		}
 		return fmt.Errorf("error upserting node pool %q: %w", pool.Name, err)
 	}
 	log.Printf("[DEBUG] Upserted node pool %q", pool.Name)
@@ -153,28 +214,24 @@ func resourceNodePoolWrite(d *schema.ResourceData, meta any) error {
 	return resourceNodePoolRead(d, meta)
 }
 
-func resourceNodePoolDelete(d *schema.ResourceData, meta any) error {
+func resourceNodePoolDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(ProviderConfig).client
 	name := d.Id()
 
 	log.Printf("[DEBUG] Deleting node pool %q", name)
-	retries := 0
-	for {
+	err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
 		_, err := client.NodePools().Delete(name, nil)
 		if err == nil {
-			break
+			return nil
 		}
-
-		retry := strings.Contains(err.Error(), "has non-terminal jobs") ||
-			strings.Contains(err.Error(), "has nodes")
-		if retries < 10 && retry {
+		if strings.Contains(err.Error(), "has non-terminal jobs") || strings.Contains(err.Error(), "has nodes") {
 			log.Printf("[INFO] could not delete node pool %q, retrying: %v", name, err)
-			time.Sleep(5 * time.Second)
-			retries++
-			continue
+			return retry.RetryableError(err)
 		}
-
-		return fmt.Errorf("failed to delete node pool %q: %w", name, err)
+		return retry.NonRetryableError(err)
+	})
+	if err != nil {
+		return diag.Errorf("failed to delete node pool %q: %s", name, err)
 	}
 	log.Printf("[DEBUG] Deleted node pool %q", name)
 
@@ -188,7 +245,7 @@ func resourceNodePoolExists(d *schema.ResourceData, meta any) (bool, error) {
 	log.Printf("[DEBUG] Checking if node pool %q exists", name)
 	resp, _, err := client.NodePools().Info(name, nil)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			return false, nil
 		}
 
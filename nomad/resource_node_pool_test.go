@@ -52,6 +52,45 @@ func TestResourceNodePool_schedulerConfig(t *testing.T) {
 	})
 }
 
+func TestResourceNodePool_schedulerConfigDrift(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-nomad-test")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.6.0"); testCheckEnt(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceNodePoolConfig_schedConfig(name),
+				Check:  testResourceNodePoolCheck_schedConfig(name),
+			},
+
+			// Changes made outside of Terraform should be reverted.
+			{
+				PreConfig: testResourceNodePool_setSchedConfig(t, name, &api.NodePoolSchedulerConfiguration{
+					SchedulerAlgorithm: api.SchedulerAlgorithmBinpack,
+				}),
+				Config: testResourceNodePoolConfig_schedConfig(name),
+				Check:  testResourceNodePoolCheck_schedConfig(name),
+			},
+		},
+		CheckDestroy: testResourceNodePool_checkDestroy(name),
+	})
+}
+
+func TestResourceNodePool_schedulerConfigCE(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-nomad-test")
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.6.0"); testCheckCE(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testResourceNodePoolConfig_schedConfig(name),
+				ExpectError: regexp.MustCompile("scheduler_config is only supported in Nomad Enterprise"),
+			},
+		},
+		CheckDestroy: testResourceNodePool_checkDestroy(name),
+	})
+}
+
 func TestResourceNodePool_refresh(t *testing.T) {
 	name := acctest.RandomWithPrefix("tf-nomad-test")
 	resource.Test(t, resource.TestCase{
@@ -342,6 +381,20 @@ func testResourceNodePool_checkDestroy(name string) resource.TestCheckFunc {
 	}
 }
 
+func testResourceNodePool_setSchedConfig(t *testing.T, name string, config *api.NodePoolSchedulerConfiguration) func() {
+	return func() {
+		client := testProvider.Meta().(ProviderConfig).client
+		pool, _, err := client.NodePools().Info(name, nil)
+		if err != nil {
+			t.Fatalf("error fetching node pool %q: %v", name, err)
+		}
+		pool.SchedulerConfiguration = config
+		if _, err := client.NodePools().Register(pool, nil); err != nil {
+			t.Fatalf("error updating node pool %q: %v", name, err)
+		}
+	}
+}
+
 func testResourceNodePool_delete(t *testing.T, name string) func() {
 	return func() {
 		client := testProvider.Meta().(ProviderConfig).client
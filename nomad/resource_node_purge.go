@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceNodePurge() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNodePurgeCreate,
+		DeleteContext: resourceNodePurgeDelete,
+		ReadContext:   resourceNodePurgeRead,
+
+		Schema: map[string]*schema.Schema{
+			"node_id": {
+				Description: "The ID of the node to purge.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Description: "Arbitrary map of values that, when changed, will trigger a new purge of the node.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"force": {
+				Description: "Purge the node even if its status isn't 'down'.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"eval_ids": {
+				Description: "The IDs of the evaluations created to reschedule the allocations of the node.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceNodePurgeCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(ProviderConfig).client
+	id := d.Get("node_id").(string)
+
+	log.Printf("[DEBUG] Reading node %q", id)
+	node, _, err := client.Nodes().Info(id, nil)
+	if err != nil {
+		if !isNotFoundError(err) {
+			return diag.Errorf("error reading node %q: %s", id, err)
+		}
+
+		// The node may have already been purged or garbage collected.
+		log.Printf("[DEBUG] Node %q not found, nothing to purge", id)
+		d.SetId(id)
+		d.Set("eval_ids", []string{})
+		return nil
+	}
+
+	if err := checkNodePurgeable(node, d.Get("force").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Purging node %q", id)
+	resp, _, err := client.Nodes().Purge(id, nil)
+	if err != nil {
+		return diag.Errorf("error purging node %q: %s", id, err)
+	}
+	log.Printf("[DEBUG] Purged node %q", id)
+
+	d.SetId(id)
+	d.Set("eval_ids", resp.EvalIDs)
+
+	return resourceNodePurgeRead(ctx, d, meta)
+}
+
+// resourceNodePurgeDelete only removes the purge from state, since a purged
+// node can't be restored. The node registers itself again if its client agent
+// is restarted.
+func resourceNodePurgeDelete(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// resourceNodePurgeRead doesn't refresh anything, a node that registers again
+// after being purged doesn't mean the purge needs to be repeated.
+func resourceNodePurgeRead(_ context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
+	return nil
+}
+
+// checkNodePurgeable returns an error if the node is still in use and force
+// isn't set, since purging a live node disrupts its allocations.
+func checkNodePurgeable(node *api.Node, force bool) error {
+	if force || node.Status == api.NodeStatusDown {
+		return nil
+	}
+	return fmt.Errorf("node %q is %s, set force to purge a node that isn't %s",
+		node.ID, node.Status, api.NodeStatusDown)
+}
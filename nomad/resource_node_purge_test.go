@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/shoenig/test/must"
+)
+
+func TestResourceNodePurge_missingNode(t *testing.T) {
+	// No node can have this ID since Nomad generates random UUIDs.
+	nodeID := "00000000-0000-0000-0000-000000000000"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceNodePurge_config(nodeID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nomad_node_purge.test", "id", nodeID),
+					resource.TestCheckResourceAttr("nomad_node_purge.test", "eval_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testResourceNodePurge_config(nodeID string) string {
+	return fmt.Sprintf(`
+resource "nomad_node_purge" "test" {
+  node_id = %q
+}
+`, nodeID)
+}
+
+func TestCheckNodePurgeable(t *testing.T) {
+	cases := []struct {
+		status string
+		force  bool
+		err    bool
+	}{
+		{status: api.NodeStatusDown},
+		{status: api.NodeStatusReady, err: true},
+		{status: api.NodeStatusDisconnected, err: true},
+		{status: api.NodeStatusReady, force: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s-%v", tc.status, tc.force), func(t *testing.T) {
+			err := checkNodePurgeable(&api.Node{ID: "n1", Status: tc.status}, tc.force)
+			if tc.err {
+				must.Error(t, err)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
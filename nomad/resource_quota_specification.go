@@ -6,7 +6,6 @@ package nomad
 import (
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -44,6 +43,18 @@ func resourceQuotaSpecification() *schema.Resource {
 				Type:        schema.TypeSet,
 				Elem:        resourceQuotaSpecificationLimits(),
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the quota specification was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the quota specification was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -78,6 +89,60 @@ func resourceQuotaSpecificationRegionLimits() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"cores": {
+				Description: "The number of CPU cores that can be reserved.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"memory_max_mb": {
+				Description: "The maximum amount of memory allocations can use when memory oversubscription is enabled.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"secrets_mb": {
+				Description: "The amount of memory that can be used by task secrets directories.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"device": {
+				Description: "The number of devices of a type that can be used.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The device name, in the form <type>, <vendor>/<type> or <vendor>/<type>/<name>.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"count": {
+							Description: "The number of devices.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"storage": {
+				Description: "The storage limits applied to this region.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"variables_mb": {
+							Description: "The maximum total size of all variables, in megabytes.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"host_volumes_mb": {
+							Description: "The maximum provisioned size of all dynamic host volumes, in megabytes.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -155,6 +220,8 @@ func resourceQuotaSpecificationRead(d *schema.ResourceData, meta interface{}) er
 
 	d.Set("name", spec.Name)
 	d.Set("description", spec.Description)
+	d.Set("create_index", int(spec.CreateIndex))
+	d.Set("modify_index", int(spec.ModifyIndex))
 	err = d.Set("limits", flattenQuotaLimits(spec.Limits))
 	if err != nil {
 		return fmt.Errorf("error setting quota specification limits for %q: %s", name, err.Error())
@@ -172,7 +239,7 @@ func resourceQuotaSpecificationExists(d *schema.ResourceData, meta interface{})
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			return false, nil
 		}
 
@@ -210,6 +277,34 @@ func flattenQuotaRegionLimit(limit *api.QuotaResources) *schema.Set {
 	if limit.MemoryMB != nil {
 		result["memory_mb"] = *limit.MemoryMB
 	}
+	result["cores"] = intValue(limit.Cores)
+	result["memory_max_mb"] = intValue(limit.MemoryMaxMB)
+	result["secrets_mb"] = intValue(limit.SecretsMB)
+
+	devices := make([]interface{}, 0, len(limit.Devices))
+	for _, dev := range limit.Devices {
+		if dev == nil {
+			continue
+		}
+		device := map[string]interface{}{
+			"name":  dev.Name,
+			"count": 0,
+		}
+		if dev.Count != nil {
+			device["count"] = int(*dev.Count)
+		}
+		devices = append(devices, device)
+	}
+	result["device"] = devices
+
+	if limit.Storage != nil {
+		result["storage"] = []interface{}{
+			map[string]interface{}{
+				"variables_mb":    limit.Storage.VariablesMB,
+				"host_volumes_mb": limit.Storage.HostVolumesMB,
+			},
+		}
+	}
 	return schema.NewSet(schema.HashResource(resourceQuotaSpecificationRegionLimits()),
 		[]interface{}{result})
 }
@@ -264,5 +359,50 @@ func expandRegionLimit(limit interface{}) (*api.QuotaResources, error) {
 		}
 		res.MemoryMB = &m
 	}
+	if cores, ok := regLimit["cores"].(int); ok && cores != 0 {
+		res.Cores = &cores
+	}
+	if memMax, ok := regLimit["memory_max_mb"].(int); ok && memMax != 0 {
+		res.MemoryMaxMB = &memMax
+	}
+	if secrets, ok := regLimit["secrets_mb"].(int); ok && secrets != 0 {
+		res.SecretsMB = &secrets
+	}
+
+	devices, _ := regLimit["device"].([]interface{})
+	for _, raw := range devices {
+		device, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected map[string]interface{} for device, got %T", raw)
+		}
+		dev := &api.RequestedDevice{
+			Name: device["name"].(string),
+		}
+		// 0 is the value of an unset count, which must not be sent as a limit.
+		if count, ok := device["count"].(int); ok && count != 0 {
+			c := uint64(count)
+			dev.Count = &c
+		}
+		res.Devices = append(res.Devices, dev)
+	}
+
+	storage, _ := regLimit["storage"].([]interface{})
+	if len(storage) > 0 && storage[0] != nil {
+		s, ok := storage[0].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected map[string]interface{} for storage, got %T", storage[0])
+		}
+		res.Storage = &api.QuotaStorageResources{
+			VariablesMB:   s["variables_mb"].(int),
+			HostVolumesMB: s["host_volumes_mb"].(int),
+		}
+	}
 	return &res, nil
 }
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -376,3 +377,46 @@ func testResourceQuotaSpecification_updateCheck(name string) resource.TestCheckF
 		return nil
 	}
 }
+
+func TestQuotaRegionLimit_roundTrip(t *testing.T) {
+	cpu, memory, cores, memoryMax, secrets := 2400, 1200, 4, 2400, 64
+	gpus := uint64(2)
+	limit := &api.QuotaResources{
+		CPU:         &cpu,
+		MemoryMB:    &memory,
+		Cores:       &cores,
+		MemoryMaxMB: &memoryMax,
+		SecretsMB:   &secrets,
+		Devices: []*api.RequestedDevice{
+			{Name: "nvidia/gpu", Count: &gpus},
+		},
+		Storage: &api.QuotaStorageResources{
+			VariablesMB:   100,
+			HostVolumesMB: 10240,
+		},
+	}
+
+	got, err := expandRegionLimit(flattenQuotaRegionLimit(limit))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(limit, got); diff != "" {
+		t.Errorf("region limit mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestQuotaRegionLimit_deviceWithoutCount(t *testing.T) {
+	limit := &api.QuotaResources{
+		Devices: []*api.RequestedDevice{
+			{Name: "nvidia/gpu"},
+		},
+	}
+
+	got, err := expandRegionLimit(flattenQuotaRegionLimit(limit))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Devices) != 1 || got.Devices[0].Count != nil {
+		t.Errorf("expected a device without a count, got %#v", got.Devices)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	recommendationActionApply   = "apply"
+	recommendationActionDismiss = "dismiss"
+)
+
+func resourceRecommendationAction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRecommendationActionCreate,
+		DeleteContext: resourceRecommendationActionDelete,
+		ReadContext:   resourceRecommendationActionRead,
+
+		Schema: map[string]*schema.Schema{
+			"recommendation_ids": {
+				Description: "The IDs of the recommendations to apply or dismiss.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"action": {
+				Description: "The action to take on the recommendations, one of 'apply' or 'dismiss'.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					recommendationActionApply,
+					recommendationActionDismiss,
+				}, false),
+			},
+			"policy_override": {
+				Description: "Override soft-mandatory Sentinel policies when applying the recommendations.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"updated_jobs": {
+				Description: "The jobs updated by applying the recommendations.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"namespace": {
+							Description: "The namespace of the job.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"job_id": {
+							Description: "The ID of the job.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"eval_id": {
+							Description: "The ID of the evaluation created for the new version of the job.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"warnings": {
+							Description: "The warnings returned when updating the job.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceRecommendationActionCreate(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(ProviderConfig).client
+
+	var ids []string
+	for _, raw := range d.Get("recommendation_ids").(*schema.Set).List() {
+		ids = append(ids, raw.(string))
+	}
+	sort.Strings(ids)
+	action := d.Get("action").(string)
+
+	updatedJobs := []any{}
+	switch action {
+	case recommendationActionApply:
+		log.Printf("[DEBUG] Applying recommendations %s", strings.Join(ids, ", "))
+		resp, _, err := client.Recommendations().Apply(ids, d.Get("policy_override").(bool))
+		if err != nil {
+			return diag.Errorf("error applying recommendations: %s", err)
+		}
+		if err := recommendationApplyErrors(resp.Errors); err != nil {
+			return diag.FromErr(err)
+		}
+		log.Printf("[DEBUG] Applied recommendations %s", strings.Join(ids, ", "))
+
+		for _, job := range resp.UpdatedJobs {
+			updatedJobs = append(updatedJobs, map[string]any{
+				"namespace": job.Namespace,
+				"job_id":    job.JobID,
+				"eval_id":   job.EvalID,
+				"warnings":  job.Warnings,
+			})
+		}
+
+	case recommendationActionDismiss:
+		log.Printf("[DEBUG] Dismissing recommendations %s", strings.Join(ids, ", "))
+		if _, err := client.Recommendations().Delete(ids, nil); err != nil {
+			return diag.Errorf("error dismissing recommendations: %s", err)
+		}
+		log.Printf("[DEBUG] Dismissed recommendations %s", strings.Join(ids, ", "))
+	}
+
+	d.SetId(id.UniqueId())
+	d.Set("updated_jobs", updatedJobs)
+
+	return nil
+}
+
+// resourceRecommendationActionDelete only removes the action from state, since
+// applied or dismissed recommendations can't be restored.
+func resourceRecommendationActionDelete(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// resourceRecommendationActionRead doesn't refresh anything, recommendations
+// are removed once they are applied or dismissed.
+func resourceRecommendationActionRead(_ context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
+	return nil
+}
+
+// recommendationApplyErrors returns an error listing the jobs that couldn't
+// be updated, or nil if all the recommendations were applied.
+func recommendationApplyErrors(errs []*api.SingleRecommendationApplyError) error {
+	var result error
+	for _, e := range errs {
+		result = errors.Join(result, fmt.Errorf("failed to apply recommendations %s to job %q in namespace %q: %s",
+			strings.Join(e.Recommendations, ", "), e.JobID, e.Namespace, e.Error))
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/shoenig/test/must"
+)
+
+func TestResourceRecommendationAction_dismiss(t *testing.T) {
+	// The recommendation must exist before the configuration is written, since
+	// there is no data source to look it up.
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccPreCheck(t)
+	testCheckEnt(t)
+
+	jobID := acctest.RandomWithPrefix("tf-nomad-test")
+	recID := testResourceRecommendationAction_create(t, jobID)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceRecommendationAction_config(recID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nomad_recommendation_action.test", "updated_jobs.#", "0"),
+					func(*terraform.State) error {
+						client := testProvider.Meta().(ProviderConfig).client
+						_, _, err := client.Recommendations().Info(recID, nil)
+						if err == nil {
+							return fmt.Errorf("recommendation %q was not dismissed", recID)
+						}
+						if !strings.Contains(err.Error(), "404") {
+							return err
+						}
+						return nil
+					},
+				),
+			},
+		},
+		CheckDestroy: testResourceDeploymentPromote_deregister(jobID),
+	})
+}
+
+func testResourceRecommendationAction_config(recID string) string {
+	return fmt.Sprintf(`
+resource "nomad_recommendation_action" "test" {
+  recommendation_ids = [%q]
+  action             = "dismiss"
+}
+`, recID)
+}
+
+// testResourceRecommendationAction_create registers a job and creates a CPU
+// recommendation for its task.
+func testResourceRecommendationAction_create(t *testing.T, jobID string) string {
+	testResourceJobEval_register(t, jobID)
+
+	client := testProvider.Meta().(ProviderConfig).client
+	rec, _, err := client.Recommendations().Upsert(&api.Recommendation{
+		Namespace: api.DefaultNamespace,
+		JobID:     jobID,
+		Group:     "test",
+		Task:      "test",
+		Resource:  "CPU",
+		Value:     200,
+	}, nil)
+	must.NoError(t, err)
+	return rec.ID
+}
+
+func TestRecommendationApplyErrors(t *testing.T) {
+	must.NoError(t, recommendationApplyErrors(nil))
+
+	err := recommendationApplyErrors([]*api.SingleRecommendationApplyError{
+		{Namespace: "default", JobID: "api", Recommendations: []string{"r1", "r2"}, Error: "job not found"},
+		{Namespace: "prod", JobID: "web", Recommendations: []string{"r3"}, Error: "policy violation"},
+	})
+	must.EqError(t, err, `failed to apply recommendations r1, r2 to job "api" in namespace "default": job not found
+failed to apply recommendations r3 to job "web" in namespace "prod": policy violation`)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceRootKeyRotation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRootKeyRotationCreate,
+		Delete: resourceRootKeyRotationDelete,
+		Read:   resourceRootKeyRotationRead,
+
+		Schema: map[string]*schema.Schema{
+			"triggers": {
+				Description: "Arbitrary map of values that, when changed, will trigger a new root key rotation.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"full": {
+				Description: "Decrypt all existing variables and re-encrypt them with the new root key.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"prepublish": {
+				Description: `Prepublish the new root key and only make it active after this duration, such as "24h".`,
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"key_id": {
+				Description: "The ID of the new root key.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"algorithm": {
+				Description: "The encryption algorithm of the new root key.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"state": {
+				Description: "The state of the new root key.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"create_time": {
+				Description: "The timestamp the new root key was created.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"publish_time": {
+				Description: "The timestamp the new root key will become active, if it was prepublished.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"create_index": {
+				Description: "The Raft index at which the root key was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the root key was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceRootKeyRotationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(ProviderConfig).client
+
+	opts := &api.KeyringRotateOptions{
+		Full: d.Get("full").(bool),
+	}
+	if prepublish := d.Get("prepublish").(string); prepublish != "" {
+		duration, err := time.ParseDuration(prepublish)
+		if err != nil {
+			return fmt.Errorf("failed to parse prepublish: %v", err)
+		}
+		opts.PublishTime = time.Now().Add(duration).UnixNano()
+	}
+
+	log.Printf("[DEBUG] Rotating root key")
+	key, _, err := client.Keyring().Rotate(opts, nil)
+	if err != nil {
+		return fmt.Errorf("error rotating root key: %s", err)
+	}
+	log.Printf("[DEBUG] Rotated root key, new key is %q", key.KeyID)
+
+	d.SetId(key.KeyID)
+	setRootKeyMeta(d, key)
+
+	return nil
+}
+
+// resourceRootKeyRotationDelete only removes the rotation from state, since
+// rotating the root key can't be undone.
+func resourceRootKeyRotationDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func resourceRootKeyRotationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(ProviderConfig).client
+	keyID := d.Id()
+
+	log.Printf("[DEBUG] Reading root key %q", keyID)
+	keys, _, err := client.Keyring().List(nil)
+	if err != nil {
+		return fmt.Errorf("error reading root keys: %s", err)
+	}
+
+	for _, key := range keys {
+		if key.KeyID == keyID {
+			setRootKeyMeta(d, key)
+			return nil
+		}
+	}
+
+	// Old root keys are eventually removed from the keyring once they are no
+	// longer used, but that doesn't mean the rotation needs to be repeated.
+	log.Printf("[DEBUG] Root key %q not found in keyring", keyID)
+	return nil
+}
+
+func setRootKeyMeta(d *schema.ResourceData, key *api.RootKeyMeta) {
+	var publishTime string
+	if key.PublishTime > 0 {
+		publishTime = time.Unix(0, key.PublishTime).UTC().Format(time.RFC3339)
+	}
+
+	d.Set("key_id", key.KeyID)
+	d.Set("algorithm", string(key.Algorithm))
+	d.Set("state", string(key.State))
+	d.Set("create_time", time.Unix(0, key.CreateTime).UTC().Format(time.RFC3339))
+	d.Set("publish_time", publishTime)
+	d.Set("create_index", int(key.CreateIndex))
+	d.Set("modify_index", int(key.ModifyIndex))
+}
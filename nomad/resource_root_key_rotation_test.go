@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceRootKeyRotation_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.4.0") },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceRootKeyRotation_config("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("nomad_root_key_rotation.test", "key_id"),
+					resource.TestCheckResourceAttr("nomad_root_key_rotation.test", "state", string(api.RootKeyStateActive)),
+					testResourceRootKeyRotation_checkActive("nomad_root_key_rotation.test"),
+				),
+			},
+			{
+				Config: testResourceRootKeyRotation_config("2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nomad_root_key_rotation.test", "triggers.rotation", "2"),
+					testResourceRootKeyRotation_checkActive("nomad_root_key_rotation.test"),
+				),
+			},
+		},
+	})
+}
+
+func testResourceRootKeyRotation_config(rotation string) string {
+	return fmt.Sprintf(`
+resource "nomad_root_key_rotation" "test" {
+  triggers = {
+    rotation = %q
+  }
+}
+`, rotation)
+}
+
+func testResourceRootKeyRotation_checkActive(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %q not found in state", name)
+		}
+
+		client := testProvider.Meta().(ProviderConfig).client
+		keys, _, err := client.Keyring().List(nil)
+		if err != nil {
+			return fmt.Errorf("error reading root keys: %v", err)
+		}
+
+		for _, key := range keys {
+			if key.KeyID == rs.Primary.ID {
+				if key.State != api.RootKeyStateActive {
+					return fmt.Errorf("expected root key %q to be active, got %q", key.KeyID, key.State)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("root key %q not found in keyring", rs.Primary.ID)
+	}
+}
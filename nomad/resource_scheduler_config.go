@@ -4,6 +4,7 @@
 package nomad
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -20,25 +21,30 @@ func resourceSchedulerConfig() *schema.Resource {
 		Delete: resourceSchedulerConfigurationDelete,
 		Read:   resourceSchedulerConfigurationRead,
 
+		CustomizeDiff: resourceSchedulerConfigurationCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
+			"partial_management": {
+				Description: "When true, only the attributes set in the configuration are managed and the others are left untouched.",
+				Type:        schema.TypeBool,
+				Default:     false,
+				Optional:    true,
+			},
 			"memory_oversubscription_enabled": {
 				Description: "When true, tasks may exceed their reserved memory limit.",
 				Type:        schema.TypeBool,
-				Default:     false,
 				Optional:    true,
+				Computed:    true,
 			},
 			"scheduler_algorithm": {
 				Description: "Specifies whether scheduler binpacks or spreads allocations on available nodes.",
 				Type:        schema.TypeString,
-				Default:     "binpack",
 				Optional:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-					ValidateFunc: validation.StringInSlice([]string{
-						"binpack",
-						"spread",
-					}, false),
-				},
+				Computed:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(api.SchedulerAlgorithmBinpack),
+					string(api.SchedulerAlgorithmSpread),
+				}, false),
 			},
 			// TODO(jrasell) once the Terraform SDK has been updated within
 			//  this provider, we should add validation.MapKeyMatch to this
@@ -47,50 +53,101 @@ func resourceSchedulerConfig() *schema.Resource {
 			"preemption_config": {
 				Description: "Options to enable preemption for various schedulers.",
 				Optional:    true,
+				Computed:    true,
 				Type:        schema.TypeMap,
 				Elem: &schema.Schema{
 					Type: schema.TypeBool,
 				},
 			},
+			"reject_job_registration": {
+				Description: "When true, new job registrations are rejected unless they use a management token.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"pause_eval_broker": {
+				Description: "When true, the evaluation broker is paused and no evaluations are processed.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"create_index": {
+				Description: "The Raft index at which the scheduler configuration was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the scheduler configuration was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
 
-func resourceSchedulerConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(ProviderConfig).client
-	operator := client.Operator()
+// schedulerConfigDefaults are the values used for the attributes that are
+// not set in the configuration when partial_management is false.
+var schedulerConfigDefaults = map[string]any{
+	"memory_oversubscription_enabled": false,
+	"scheduler_algorithm":             string(api.SchedulerAlgorithmBinpack),
+	"preemption_config": map[string]any{
+		"batch_scheduler_enabled":    false,
+		"service_scheduler_enabled":  false,
+		"sysbatch_scheduler_enabled": false,
+		"system_scheduler_enabled":   false,
+	},
+	"reject_job_registration": false,
+	"pause_eval_broker":       false,
+}
 
-	config := api.SchedulerConfiguration{
-		SchedulerAlgorithm:            api.SchedulerAlgorithm(d.Get("scheduler_algorithm").(string)),
-		PreemptionConfig:              api.PreemptionConfig{},
-		MemoryOversubscriptionEnabled: d.Get("memory_oversubscription_enabled").(bool),
+// resourceSchedulerConfigurationCustomizeDiff plans the default values for
+// the attributes that are not set in the configuration, unless
+// partial_management is set in which case they keep their current value.
+func resourceSchedulerConfigurationCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Get("partial_management").(bool) {
+		return nil
 	}
 
-	// Unpack the preemption block.
-	preempt, ok := d.GetOk("preemption_config")
-	if ok {
-		preemptMap, ok := preempt.(map[string]interface{})
-		if !ok {
-			return errors.New("failed to unpack preemption configuration block")
-		}
-
-		if val, ok := preemptMap["batch_scheduler_enabled"].(bool); ok {
-			config.PreemptionConfig.BatchSchedulerEnabled = val
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	for key, value := range schedulerConfigDefaults {
+		if !config.GetAttr(key).IsNull() {
+			continue
 		}
-		if val, ok := preemptMap["service_scheduler_enabled"].(bool); ok {
-			config.PreemptionConfig.ServiceSchedulerEnabled = val
+		if err := d.SetNew(key, value); err != nil {
+			return err
 		}
-		if val, ok := preemptMap["sysbatch_scheduler_enabled"].(bool); ok {
-			config.PreemptionConfig.SysBatchSchedulerEnabled = val
+	}
+	return nil
+}
+
+func resourceSchedulerConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(ProviderConfig).client
+	operator := client.Operator()
+
+	config := &api.SchedulerConfiguration{}
+	if d.Get("partial_management").(bool) {
+		// Start from the current configuration so the attributes that are
+		// not set are left untouched.
+		resp, _, err := operator.SchedulerGetConfiguration(nil)
+		if err != nil {
+			return fmt.Errorf("error reading scheduler configuration: %s", err.Error())
 		}
-		if val, ok := preemptMap["system_scheduler_enabled"].(bool); ok {
-			config.PreemptionConfig.SystemSchedulerEnabled = val
+		if resp.SchedulerConfig != nil {
+			config = resp.SchedulerConfig
 		}
 	}
+	if err := expandSchedulerConfiguration(d, config); err != nil {
+		return err
+	}
 
 	// Perform the config write.
 	log.Printf("[DEBUG] Upserting Scheduler configuration")
-	if _, _, err := operator.SchedulerSetConfiguration(&config, nil); err != nil {
+	if _, _, err := operator.SchedulerSetConfiguration(config, nil); err != nil {
 		return fmt.Errorf("error upserting scheduler configuration: %s", err.Error())
 	}
 	log.Printf("[DEBUG] Upserted scheduler configuration")
@@ -98,6 +155,60 @@ func resourceSchedulerConfigurationCreate(d *schema.ResourceData, meta interface
 	return resourceSchedulerConfigurationRead(d, meta)
 }
 
+// expandSchedulerConfiguration updates config with the attributes set in the
+// raw configuration of the resource. When partial_management is false all
+// the attributes are set since CustomizeDiff plans a default value for them.
+func expandSchedulerConfiguration(d *schema.ResourceData, config *api.SchedulerConfiguration) error {
+	partial := d.Get("partial_management").(bool)
+	raw := d.GetRawConfig()
+	isSet := func(key string) bool {
+		if !partial {
+			return true
+		}
+		return !raw.IsNull() && raw.IsKnown() && !raw.GetAttr(key).IsNull()
+	}
+
+	if isSet("scheduler_algorithm") {
+		config.SchedulerAlgorithm = api.SchedulerAlgorithm(d.Get("scheduler_algorithm").(string))
+		if config.SchedulerAlgorithm == "" {
+			config.SchedulerAlgorithm = api.SchedulerAlgorithmBinpack
+		}
+	}
+	if isSet("memory_oversubscription_enabled") {
+		config.MemoryOversubscriptionEnabled = d.Get("memory_oversubscription_enabled").(bool)
+	}
+	if isSet("reject_job_registration") {
+		config.RejectJobRegistration = d.Get("reject_job_registration").(bool)
+	}
+	if isSet("pause_eval_broker") {
+		config.PauseEvalBroker = d.Get("pause_eval_broker").(bool)
+	}
+
+	if !isSet("preemption_config") {
+		return nil
+	}
+
+	// Unpack the preemption block. Schedulers that are not set are disabled
+	// unless partial_management is set.
+	preemptMap, ok := d.Get("preemption_config").(map[string]interface{})
+	if !ok {
+		return errors.New("failed to unpack preemption configuration block")
+	}
+	setPreemption := func(key string, field *bool) {
+		if val, ok := preemptMap[key].(bool); ok {
+			*field = val
+		} else if !partial {
+			*field = false
+		}
+	}
+	setPreemption("batch_scheduler_enabled", &config.PreemptionConfig.BatchSchedulerEnabled)
+	setPreemption("service_scheduler_enabled", &config.PreemptionConfig.ServiceSchedulerEnabled)
+	setPreemption("sysbatch_scheduler_enabled", &config.PreemptionConfig.SysBatchSchedulerEnabled)
+	setPreemption("system_scheduler_enabled", &config.PreemptionConfig.SystemSchedulerEnabled)
+
+	return nil
+}
+
 // resourceSchedulerConfigurationDelete does not do anything:
 //
 // There is not a correct way to destroy this "resource" nor check it was
@@ -143,6 +254,22 @@ func resourceSchedulerConfigurationRead(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
+	if err := d.Set("reject_job_registration", config.SchedulerConfig.RejectJobRegistration); err != nil {
+		return err
+	}
+
+	if err := d.Set("pause_eval_broker", config.SchedulerConfig.PauseEvalBroker); err != nil {
+		return err
+	}
+
+	if err := d.Set("create_index", int(config.SchedulerConfig.CreateIndex)); err != nil {
+		return err
+	}
+
+	if err := d.Set("modify_index", int(config.SchedulerConfig.ModifyIndex)); err != nil {
+		return err
+	}
+
 	premptMap := map[string]bool{
 		"batch_scheduler_enabled":    config.SchedulerConfig.PreemptionConfig.BatchSchedulerEnabled,
 		"service_scheduler_enabled":  config.SchedulerConfig.PreemptionConfig.ServiceSchedulerEnabled,
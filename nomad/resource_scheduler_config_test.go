@@ -171,6 +171,61 @@ func TestSchedulerConfig_memoryOversubscriptionEnabledOutsideTest(t *testing.T)
 	})
 }
 
+func TestSchedulerConfig_partialManagement(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testProviders,
+		CheckDestroy: testFinalConfiguration,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNomadSchedulerConfigMemoryOversubscription,
+			},
+			{
+				Config: testAccNomadSchedulerConfigPartial,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"nomad_scheduler_config.config",
+						"scheduler_algorithm",
+						"spread",
+					),
+					resource.TestCheckResourceAttr(
+						"nomad_scheduler_config.config",
+						"memory_oversubscription_enabled",
+						"true",
+					),
+					resource.TestCheckResourceAttr(
+						"nomad_scheduler_config.config",
+						"preemption_config.service_scheduler_enabled",
+						"true",
+					),
+					resource.TestCheckResourceAttr(
+						"nomad_scheduler_config.config",
+						"reject_job_registration",
+						"false",
+					),
+				),
+			},
+			{
+				Config: testAccNomadSchedulerConfigSpread,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"nomad_scheduler_config.config",
+						"memory_oversubscription_enabled",
+						"false",
+					),
+				),
+			},
+		},
+	})
+}
+
+const testAccNomadSchedulerConfigPartial = `
+resource "nomad_scheduler_config" "config" {
+	partial_management  = true
+	scheduler_algorithm = "spread"
+}
+`
+
 const testAccNomadSchedulerConfigSpread = `
 resource "nomad_scheduler_config" "config" {
 	scheduler_algorithm = "spread"
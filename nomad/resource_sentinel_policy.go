@@ -6,6 +6,7 @@ package nomad
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -58,14 +59,27 @@ func resourceSentinelPolicy() *schema.Resource {
 			},
 
 			"policy": {
-				Description: "The Sentinel policy.",
-				Required:    true,
-				Type:        schema.TypeString,
+				Description:  "The Sentinel policy.",
+				Required:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validateSentinelPolicyFunc,
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 					// TODO: this should probably parse the AST to avoid false positives
 					return strings.TrimSpace(old) == strings.TrimSpace(new)
 				},
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the Sentinel policy was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the Sentinel policy was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -123,6 +137,8 @@ func resourceSentinelPolicyRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("scope", policy.Scope)
 	d.Set("enforcement_level", policy.EnforcementLevel)
 	d.Set("policy", policy.Policy)
+	d.Set("create_index", int(policy.CreateIndex))
+	d.Set("modify_index", int(policy.ModifyIndex))
 
 	return nil
 }
@@ -136,7 +152,7 @@ func resourceSentinelPolicyExists(d *schema.ResourceData, meta interface{}) (boo
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			return false, nil
 		}
 
@@ -150,3 +166,100 @@ func resourceSentinelPolicyExists(d *schema.ResourceData, meta interface{}) (boo
 
 	return true, nil
 }
+
+var sentinelMainRule = regexp.MustCompile(`(?m)^\s*main\s*=`)
+
+func validateSentinelPolicyFunc(i interface{}, k string) ([]string, []error) {
+	policy, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if err := validateSentinelPolicy(policy); err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid Sentinel policy: %v", k, err)}
+	}
+	return nil, nil
+}
+
+// validateSentinelPolicy performs a lexical check of a Sentinel policy to
+// catch common mistakes before the policy is submitted to Nomad: unterminated
+// strings and comments, unbalanced brackets, and a missing main rule. It is
+// not a full parser, Nomad still validates the policy when it's written.
+func validateSentinelPolicy(policy string) error {
+	type open struct {
+		char rune
+		line int
+	}
+	closing := map[rune]rune{')': '(', ']': '[', '}': '{'}
+
+	var stack []open
+	line := 1
+	runes := []rune(policy)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\n':
+			line++
+
+		case c == '#' || (c == '/' && i+1 < len(runes) && runes[i+1] == '/'):
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			line++
+
+		case c == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			start := line
+			i += 2
+			for ; i < len(runes); i++ {
+				if runes[i] == '\n' {
+					line++
+				}
+				if runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '/' {
+					i++
+					break
+				}
+			}
+			if i >= len(runes) {
+				return fmt.Errorf("line %d: unterminated comment", start)
+			}
+
+		case c == '"' || c == '`':
+			start := line
+			i++
+			for ; i < len(runes) && runes[i] != c; i++ {
+				switch {
+				case runes[i] == '\\' && c == '"':
+					i++
+				case runes[i] == '\n' && c == '"':
+					return fmt.Errorf("line %d: unterminated string", start)
+				case runes[i] == '\n':
+					line++
+				}
+			}
+			if i >= len(runes) {
+				return fmt.Errorf("line %d: unterminated string", start)
+			}
+
+		case c == '(' || c == '[' || c == '{':
+			stack = append(stack, open{char: c, line: line})
+
+		case c == ')' || c == ']' || c == '}':
+			if len(stack) == 0 {
+				return fmt.Errorf("line %d: unexpected %q", line, c)
+			}
+			last := stack[len(stack)-1]
+			if last.char != closing[c] {
+				return fmt.Errorf("line %d: unexpected %q, %q opened on line %d is not closed", line, c, last.char, last.line)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if len(stack) > 0 {
+		last := stack[len(stack)-1]
+		return fmt.Errorf("line %d: %q is not closed", last.line, last.char)
+	}
+	if !sentinelMainRule.MatchString(policy) {
+		return fmt.Errorf("the policy must define a main rule")
+	}
+	return nil
+}
@@ -227,3 +227,85 @@ func testResourceSentinelPolicy_delete(t *testing.T, name string) func() {
 		}
 	}
 }
+
+func TestValidateSentinelPolicy(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy string
+		err    string
+	}{
+		{
+			name:   "simple",
+			policy: `main = rule { true }`,
+		},
+		{
+			name: "complex",
+			policy: `
+import "strings"
+
+/* Only allow exec based tasks
+   in the "prod" namespace. */
+main = rule { job.namespace is not "prod" or all_drivers_exec }
+
+// all_drivers_exec checks that all the drivers in use are exec
+all_drivers_exec = rule {
+    all job.task_groups as tg {
+        all tg.tasks as task {
+            task.driver is "exec" and not strings.has_prefix(task.name, "}")
+        }
+    }
+}
+`,
+		},
+		{
+			name:   "missing main",
+			policy: `allowed = rule { true }`,
+			err:    "must define a main rule",
+		},
+		{
+			name:   "main in a comment",
+			policy: "# main = rule { true }\nallowed = rule { true }",
+			err:    "must define a main rule",
+		},
+		{
+			name:   "unclosed brace",
+			policy: "main = rule {\n  all job.task_groups as tg {\n    true\n}",
+			err:    `line 1: '{' is not closed`,
+		},
+		{
+			name:   "mismatched bracket",
+			policy: "main = rule { [1, 2) }",
+			err:    `line 1: unexpected ')', '[' opened on line 1 is not closed`,
+		},
+		{
+			name:   "unexpected closing",
+			policy: "main = rule { true }\n}",
+			err:    `line 2: unexpected '}'`,
+		},
+		{
+			name:   "unterminated string",
+			policy: "main = rule {\n  job.name is \"example\n}",
+			err:    "line 2: unterminated string",
+		},
+		{
+			name:   "unterminated comment",
+			policy: "main = rule { true }\n/* comment",
+			err:    "line 2: unterminated comment",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSentinelPolicy(tc.policy)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSystemGC() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSystemGCCreate,
+		Delete: resourceSystemGCDelete,
+		Read:   resourceSystemGCRead,
+
+		Schema: map[string]*schema.Schema{
+			"triggers": {
+				Description: "Arbitrary map of values that, when changed, will trigger a new garbage collection.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"reconcile_summaries": {
+				Description: "Reconcile the summaries of all the jobs after the garbage collection.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func resourceSystemGCCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(ProviderConfig).client
+
+	log.Printf("[DEBUG] Running system garbage collection")
+	if err := client.System().GarbageCollect(); err != nil {
+		return fmt.Errorf("error running system garbage collection: %s", err)
+	}
+	log.Printf("[DEBUG] Ran system garbage collection")
+
+	if d.Get("reconcile_summaries").(bool) {
+		log.Printf("[DEBUG] Reconciling job summaries")
+		if err := client.System().ReconcileSummaries(); err != nil {
+			return fmt.Errorf("error reconciling job summaries: %s", err)
+		}
+		log.Printf("[DEBUG] Reconciled job summaries")
+	}
+
+	d.SetId(id.UniqueId())
+
+	return nil
+}
+
+// resourceSystemGCDelete only removes the garbage collection from state, since
+// there is nothing to undo.
+func resourceSystemGCDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func resourceSystemGCRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceSystemGC_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceSystemGC_config("1", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("nomad_system_gc.test", "id"),
+					resource.TestCheckResourceAttr("nomad_system_gc.test", "reconcile_summaries", "false"),
+				),
+			},
+			{
+				Config: testResourceSystemGC_config("2", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nomad_system_gc.test", "triggers.cleanup", "2"),
+					resource.TestCheckResourceAttr("nomad_system_gc.test", "reconcile_summaries", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testResourceSystemGC_config(cleanup string, reconcile bool) string {
+	return fmt.Sprintf(`
+resource "nomad_system_gc" "test" {
+  reconcile_summaries = %t
+
+  triggers = {
+    cleanup = %q
+  }
+}
+`, reconcile, cleanup)
+}
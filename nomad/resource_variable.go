@@ -4,6 +4,8 @@
 package nomad
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -14,6 +16,8 @@ import (
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
 )
 
 const (
@@ -34,8 +38,10 @@ func resourceVariable() *schema.Resource {
 		Read:   resourceVariableRead,
 		Exists: resourceVariableExists,
 
+		CustomizeDiff: resourceVariableCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceVariableImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -47,22 +53,96 @@ func resourceVariable() *schema.Resource {
 				ValidateDiagFunc: pathValidation(),
 			},
 			"namespace": {
-				Description: "Variable namespace",
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Default:     api.DefaultNamespace,
+				Description:      "Variable namespace",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          api.DefaultNamespace,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
 			},
 			"items": {
-				Description: "A map of strings to be added as items in the variable",
+				Description:  "A map of strings to be added as items in the variable",
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"items", "items_wo"},
+			},
+			"items_wo": {
+				Description:  "A JSON encoded map of strings to be added as items in the variable. This value is write-only and is never stored in state",
+				Type:         schema.TypeString,
+				Optional:     true,
+				WriteOnly:    true,
+				ValidateFunc: validation.StringIsJSON,
+				RequiredWith: []string{"items_wo_version"},
+			},
+			"items_wo_version": {
+				Description:  "The version of items_wo, must be changed for new items_wo values to be written",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"items_wo"},
+			},
+			"retain_on_destroy": retainOnDestroySchema("variable"),
+			"cas": {
+				Description: "Whether to use check-and-set when writing or deleting the variable, failing if it was modified since it was last read",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"nonsensitive": {
+				Description: "Whether the items are not secrets. When true, the items are also exported in nonsensitive_items so they are shown in plans",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"nonsensitive_items": {
+				Description: "The items of the variable when nonsensitive is true",
 				Type:        schema.TypeMap,
-				Required:    true,
-				Sensitive:   true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"merge": {
+				Description:   "Whether to only manage the items set in this resource, keeping the other items of the variable",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"items_wo", "cas"},
+			},
+			"create_index": {
+				Description: "The Raft index at which the variable was created",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"modify_index": {
+				Description: "The Raft index at which the variable was last modified",
+				Type:        schema.TypeInt,
+				Computed:    true,
 			},
 		},
 	}
 }
 
+func resourceVariableCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta any) error {
+	if d.Id() != "" && d.HasChanges("items", "items_wo_version") {
+		if err := d.SetNewComputed("modify_index"); err != nil {
+			return err
+		}
+	}
+
+	// Show the new items in the plan when they are not sensitive.
+	if !d.HasChanges("items", "nonsensitive") {
+		return nil
+	}
+	switch {
+	case !d.Get("nonsensitive").(bool):
+		return d.SetNew("nonsensitive_items", map[string]any{})
+	case !d.NewValueKnown("items"):
+		return d.SetNewComputed("nonsensitive_items")
+	default:
+		return d.SetNew("nonsensitive_items", d.Get("items"))
+	}
+}
+
 func resourceVariableWrite(d *schema.ResourceData, meta any) error {
 	client := meta.(ProviderConfig).client
 
@@ -76,9 +156,36 @@ func resourceVariableWrite(d *schema.ResourceData, meta any) error {
 		variable.Items[name] = value.(string)
 	}
 
+	itemsWO, ok, err := getWriteOnlyString(d, cty.GetAttrPath("items_wo"))
+	if err != nil {
+		return err
+	}
+	if ok {
+		items, err := parseVariableItemsJSON(itemsWO)
+		if err != nil {
+			return err
+		}
+		variable.Items = items
+	}
+
 	log.Printf("[DEBUG] Upserting variable %s@%s", variable.Path, variable.Namespace)
-	if _, _, err := client.Variables().Create(variable, nil); err != nil {
-		return fmt.Errorf("error creating variable %s@%s: %s", variable.Path, variable.Namespace, err.Error())
+	switch {
+	case d.Get("merge").(bool):
+		oldItems, _ := d.GetChange("items")
+		err = writeMergedVariable(client, variable, oldItems.(map[string]any))
+	case !d.Get("cas").(bool):
+		_, _, err = client.Variables().Create(variable, nil)
+	case d.IsNewResource():
+		_, _, err = client.Variables().CheckedCreate(variable, nil)
+	default:
+		// The new modify index is computed once the items change, the
+		// update is checked against the index in the state.
+		modifyIndex, _ := d.GetChange("modify_index")
+		variable.ModifyIndex = uint64(modifyIndex.(int))
+		_, _, err = client.Variables().CheckedUpdate(variable, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("error creating variable %s@%s: %s", variable.Path, variable.Namespace, variableWriteError(err))
 	}
 
 	log.Printf("[DEBUG] Created variable %s@%s", variable.Path, variable.Namespace)
@@ -94,9 +201,24 @@ func resourceVariableDelete(d *schema.ResourceData, meta any) error {
 	path := d.Get("path").(string)
 	ns := d.Get("namespace").(string)
 
+	if skipDeregister(d, "variable") {
+		return nil
+	}
+
 	log.Printf("[DEBUG] Deleting variable %q", variableID)
-	if _, err := client.Variables().Delete(path, &api.WriteOptions{Namespace: ns}); err != nil {
-		return fmt.Errorf("error deleting variable %s: %v", variableID, err)
+	var err error
+	switch {
+	case d.Get("merge").(bool):
+		// Only remove the items managed by this resource.
+		variable := &api.Variable{Namespace: ns, Path: path, Items: make(map[string]string)}
+		err = writeMergedVariable(client, variable, d.Get("items").(map[string]any))
+	case d.Get("cas").(bool):
+		_, err = client.Variables().CheckedDelete(path, uint64(d.Get("modify_index").(int)), &api.WriteOptions{Namespace: ns})
+	default:
+		_, err = client.Variables().Delete(path, &api.WriteOptions{Namespace: ns})
+	}
+	if err != nil {
+		return fmt.Errorf("error deleting variable %s: %v", variableID, variableWriteError(err))
 	}
 
 	log.Printf("[DEBUG] Deleted variable %q", d.Id())
@@ -105,6 +227,22 @@ func resourceVariableDelete(d *schema.ResourceData, meta any) error {
 	return nil
 }
 
+// resourceVariableImport imports variables using the pattern
+// <path>@<namespace>, which is also the ID of the resource. Variables in the
+// default namespace can be imported with their path only.
+func resourceVariableImport(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+	path, ns, err := helper.ParseNamespacedImportID(d.Id(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(path + "@" + ns)
+	d.Set("path", path)
+	d.Set("namespace", ns)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceVariableRead(d *schema.ResourceData, meta any) error {
 	client := meta.(ProviderConfig).client
 
@@ -119,7 +257,116 @@ func resourceVariableRead(d *schema.ResourceData, meta any) error {
 	}
 
 	d.SetId(variableID)
-	return d.Set("items", variable.Items)
+	d.Set("create_index", int(variable.CreateIndex))
+	d.Set("modify_index", int(variable.ModifyIndex))
+
+	// Items written with items_wo must never be stored in state.
+	if _, ok := d.GetOk("items_wo_version"); ok {
+		d.Set("nonsensitive_items", nil)
+		return d.Set("items", nil)
+	}
+
+	// Items that are not managed by this resource are left out of the state.
+	// Imported variables have no items in state yet, so they get all items.
+	items := variable.Items
+	if managed, ok := d.Get("items").(map[string]any); ok && d.Get("merge").(bool) && len(managed) > 0 {
+		items = make(map[string]string, len(managed))
+		for name := range managed {
+			if value, ok := variable.Items[name]; ok {
+				items[name] = value
+			}
+		}
+	}
+
+	if err := d.Set("nonsensitive_items", nonsensitiveVariableItems(d, items)); err != nil {
+		return err
+	}
+	return d.Set("items", items)
+}
+
+// nonsensitiveVariableItems returns the items to set in nonsensitive_items,
+// which are empty unless nonsensitive is set.
+func nonsensitiveVariableItems(d *schema.ResourceData, items map[string]string) map[string]string {
+	if d.Get("nonsensitive").(bool) {
+		return items
+	}
+	return map[string]string{}
+}
+
+// writeMergedVariable writes the items of variable into the existing variable
+// at the same path, keeping the items it doesn't manage. The items in
+// oldItems that are no longer in variable are removed, and the variable is
+// deleted once it has no items left. The write uses check-and-set so items
+// written concurrently by others are not lost.
+func writeMergedVariable(client *api.Client, variable *api.Variable, oldItems map[string]any) error {
+	q := &api.QueryOptions{Namespace: variable.Namespace}
+	existing, _, err := client.Variables().Peek(variable.Path, q)
+	if err != nil {
+		return err
+	}
+
+	var existingItems map[string]string
+	if existing != nil {
+		existingItems = existing.Items
+	}
+	items := mergeVariableItems(existingItems, oldItems, variable.Items)
+
+	switch {
+	case existing == nil && len(items) == 0:
+		return nil
+	case existing == nil:
+		variable.Items = items
+		_, _, err = client.Variables().CheckedCreate(variable, nil)
+	case len(items) == 0:
+		_, err = client.Variables().CheckedDelete(variable.Path, existing.ModifyIndex, &api.WriteOptions{Namespace: variable.Namespace})
+	default:
+		variable.Items = items
+		variable.ModifyIndex = existing.ModifyIndex
+		_, _, err = client.Variables().CheckedUpdate(variable, nil)
+	}
+	return err
+}
+
+// mergeVariableItems returns the existing items without those in oldItems,
+// overwritten by newItems.
+func mergeVariableItems(existing map[string]string, oldItems map[string]any, newItems map[string]string) map[string]string {
+	items := make(map[string]string, len(existing)+len(newItems))
+	for name, value := range existing {
+		if _, ok := oldItems[name]; !ok {
+			items[name] = value
+		}
+	}
+	for name, value := range newItems {
+		items[name] = value
+	}
+	return items
+}
+
+// variableWriteError returns a descriptive error for check-and-set conflicts
+// and err unchanged otherwise.
+func variableWriteError(err error) error {
+	var conflict api.ErrCASConflict
+	if !errors.As(err, &conflict) {
+		return err
+	}
+	if conflict.Conflict == nil {
+		return fmt.Errorf("the variable was modified since it was last read, expected modify index %d", conflict.CheckIndex)
+	}
+	return fmt.Errorf("the variable was modified since it was last read, expected modify index %d but found %d; refresh and plan again to review the changes",
+		conflict.CheckIndex, conflict.Conflict.ModifyIndex)
+}
+
+// parseVariableItemsJSON decodes the JSON encoded map of variable items set
+// in items_wo.
+func parseVariableItemsJSON(raw string) (map[string]string, error) {
+	var items map[string]string
+	if err := json.Unmarshal([]byte(raw), &items); err != nil {
+		return nil, fmt.Errorf("failed to parse items_wo, it must be a JSON encoded map of strings: %v", err)
+	}
+	if items == nil {
+		items = make(map[string]string)
+	}
+	return items, nil
 }
 
 func resourceVariableExists(d *schema.ResourceData, meta any) (bool, error) {
@@ -133,7 +380,7 @@ func resourceVariableExists(d *schema.ResourceData, meta any) (bool, error) {
 
 	_, _, err := client.Variables().Read(path, &api.QueryOptions{Namespace: ns})
 	if err != nil {
-		if strings.Contains(err.Error(), "404") || errors.Is(err, api.ErrVariablePathNotFound) {
+		if isNotFoundError(err) {
 			return false, nil
 		}
 		return true, fmt.Errorf("error checking for variable %s: %#v", variableID, err)
@@ -4,11 +4,17 @@
 package nomad
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -32,6 +38,32 @@ func TestResourceVariable_basic(t *testing.T) {
 	})
 }
 
+func TestResourceVariable_import(t *testing.T) {
+	path := acctest.RandomWithPrefix("tf-nomad-test")
+	namespace := acctest.RandomWithPrefix("tf-nomad-test")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.4.0") },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceVariable_initialConfigWithNamespace(namespace, path),
+				Check:  testResourceVariable_initialCheck(namespace, path),
+			},
+			{
+				ResourceName:      "nomad_variable.test",
+				ImportState:       true,
+				ImportStateId:     path + "@" + namespace,
+				ImportStateVerify: true,
+				// These only configure how the provider writes the variable.
+				ImportStateVerifyIgnore: []string{"cas", "merge", "nonsensitive"},
+			},
+		},
+
+		CheckDestroy: testResourceVariable_checkDestroy(namespace, path),
+	})
+}
+
 func TestResourceVariable_pathChange(t *testing.T) {
 	path := acctest.RandomWithPrefix("tf-nomad-test")
 	newPath := acctest.RandomWithPrefix("tf-nomad-test")
@@ -79,6 +111,304 @@ func TestResourceVariable_namespaceChange(t *testing.T) {
 	})
 }
 
+func TestResourceVariable_writeOnly(t *testing.T) {
+	path := acctest.RandomWithPrefix("tf-nomad-test")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.4.0") },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceVariable_writeOnlyConfig(path, 1, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("nomad_variable.test", "items_wo"),
+					resource.TestCheckResourceAttr("nomad_variable.test", "items.%", "0"),
+					resource.TestCheckResourceAttr("nomad_variable.test", "items_wo_version", "1"),
+					testResourceVariable_checkItems(path, map[string]string{"password": "first"}),
+				),
+			},
+			{
+				Config: testResourceVariable_writeOnlyConfig(path, 2, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("nomad_variable.test", "items_wo"),
+					resource.TestCheckResourceAttr("nomad_variable.test", "items_wo_version", "2"),
+					testResourceVariable_checkItems(path, map[string]string{"password": "second"}),
+				),
+			},
+		},
+
+		CheckDestroy: testResourceVariable_checkDestroy(api.DefaultNamespace, path),
+	})
+}
+
+func TestResourceVariable_cas(t *testing.T) {
+	path := acctest.RandomWithPrefix("tf-nomad-test")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.4.0") },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceVariable_casConfig(path, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("nomad_variable.test", "modify_index"),
+					testResourceVariable_checkItems(path, map[string]string{"password": "first"}),
+				),
+			},
+			{
+				Config: testResourceVariable_casConfig(path, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("nomad_variable.test", "modify_index"),
+					testResourceVariable_checkItems(path, map[string]string{"password": "second"}),
+				),
+			},
+		},
+
+		CheckDestroy: testResourceVariable_checkDestroy(api.DefaultNamespace, path),
+	})
+}
+
+func testResourceVariable_casConfig(path, password string) string {
+	return fmt.Sprintf(`
+resource "nomad_variable" "test" {
+  path = "%s"
+  cas  = true
+  items = {
+    password = "%s"
+  }
+}
+`, path, password)
+}
+
+func TestResourceVariable_merge(t *testing.T) {
+	path := acctest.RandomWithPrefix("tf-nomad-test")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.4.0") },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testResourceVariable_write(t, path, map[string]string{"owner": "team-a"}) },
+				Config:    testResourceVariable_mergeConfig(path, `password = "first"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nomad_variable.test", "items.%", "1"),
+					testResourceVariable_checkItems(path, map[string]string{"owner": "team-a", "password": "first"}),
+				),
+			},
+			{
+				Config: testResourceVariable_mergeConfig(path, `user = "admin"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nomad_variable.test", "items.%", "1"),
+					testResourceVariable_checkItems(path, map[string]string{"owner": "team-a", "user": "admin"}),
+				),
+			},
+		},
+
+		// Destroying the resource must only remove the items it manages.
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testResourceVariable_checkItems(path, map[string]string{"owner": "team-a"})(s); err != nil {
+				return err
+			}
+			client := testProvider.Meta().(ProviderConfig).client
+			_, err := client.Variables().Delete(path, nil)
+			return err
+		},
+	})
+}
+
+func testResourceVariable_mergeConfig(path, items string) string {
+	return fmt.Sprintf(`
+resource "nomad_variable" "test" {
+  path  = "%s"
+  merge = true
+  items = {
+    %s
+  }
+}
+`, path, items)
+}
+
+func TestResourceVariable_nonsensitive(t *testing.T) {
+	path := acctest.RandomWithPrefix("tf-nomad-test")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.4.0") },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceVariable_nonsensitiveConfig(path, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nomad_variable.test", "items.log_level", "info"),
+					resource.TestCheckResourceAttr("nomad_variable.test", "nonsensitive_items.%", "0"),
+				),
+			},
+			{
+				Config: testResourceVariable_nonsensitiveConfig(path, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nomad_variable.test", "nonsensitive_items.%", "1"),
+					resource.TestCheckResourceAttr("nomad_variable.test", "nonsensitive_items.log_level", "info"),
+				),
+			},
+		},
+
+		CheckDestroy: testResourceVariable_checkDestroy(api.DefaultNamespace, path),
+	})
+}
+
+func testResourceVariable_nonsensitiveConfig(path string, nonsensitive bool) string {
+	return fmt.Sprintf(`
+resource "nomad_variable" "test" {
+  path         = "%s"
+  nonsensitive = %t
+  items = {
+    log_level = "info"
+  }
+}
+`, path, nonsensitive)
+}
+
+// testResourceVariable_write writes a variable outside of Terraform.
+func testResourceVariable_write(t *testing.T, path string, items map[string]string) {
+	client := testProvider.Meta().(ProviderConfig).client
+	_, _, err := client.Variables().Create(&api.Variable{Path: path, Items: items}, nil)
+	if err != nil {
+		t.Fatalf("error writing variable %q: %v", path, err)
+	}
+}
+
+func TestMergeVariableItems(t *testing.T) {
+	existing := map[string]string{"owner": "team-a", "password": "old", "user": "admin"}
+	oldItems := map[string]any{"password": "old", "user": "admin"}
+	newItems := map[string]string{"password": "new"}
+
+	got := mergeVariableItems(existing, oldItems, newItems)
+	want := map[string]string{"owner": "team-a", "password": "new"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := mergeVariableItems(nil, nil, newItems); !reflect.DeepEqual(got, newItems) {
+		t.Errorf("expected %v, got %v", newItems, got)
+	}
+}
+
+func TestResourceVariableWrite_casUpdate(t *testing.T) {
+	var cas string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPut {
+			cas = req.URL.Query().Get("cas")
+		}
+		json.NewEncoder(w).Encode(&api.Variable{
+			Namespace:   "default",
+			Path:        "example",
+			Items:       map[string]string{"user": "admin"},
+			ModifyIndex: 43,
+		})
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := ProviderConfig{client: client}
+
+	r := resourceVariable()
+	state := &terraform.InstanceState{
+		ID: "example@default",
+		Attributes: map[string]string{
+			"id":           "example@default",
+			"path":         "example",
+			"namespace":    "default",
+			"cas":          "true",
+			"items.%":      "1",
+			"items.user":   "root",
+			"modify_index": "42",
+		},
+	}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]any{
+		"path":      "example",
+		"namespace": "default",
+		"cas":       true,
+		"items":     map[string]any{"user": "admin"},
+	}), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff.RawConfig = cty.ObjectVal(map[string]cty.Value{"items_wo": cty.NullVal(cty.String)})
+
+	// The update is checked against the modify index in the state, not the
+	// computed one.
+	if _, diags := r.Apply(context.Background(), state, diff, meta); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if cas != "42" {
+		t.Errorf("expected the update to be checked against index 42, got %q", cas)
+	}
+}
+
+func TestVariableWriteError(t *testing.T) {
+	err := variableWriteError(fmt.Errorf("wrapped: %w", api.ErrCASConflict{
+		CheckIndex: 10,
+		Conflict:   &api.Variable{ModifyIndex: 12},
+	}))
+	if !strings.Contains(err.Error(), "expected modify index 10 but found 12") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	other := errors.New("permission denied")
+	if err := variableWriteError(other); err != other {
+		t.Errorf("expected error to be returned unchanged, got %v", err)
+	}
+}
+
+func TestParseVariableItemsJSON(t *testing.T) {
+	items, err := parseVariableItemsJSON(`{"user": "admin", "password": "hunter2"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 || items["user"] != "admin" || items["password"] != "hunter2" {
+		t.Errorf("unexpected items: %v", items)
+	}
+
+	if _, err := parseVariableItemsJSON(`{"port": 8080}`); err == nil {
+		t.Error("expected error for non-string item value")
+	}
+	if _, err := parseVariableItemsJSON(`["a", "b"]`); err == nil {
+		t.Error("expected error for JSON list")
+	}
+}
+
+func testResourceVariable_writeOnlyConfig(path string, version int, password string) string {
+	return fmt.Sprintf(`
+resource "nomad_variable" "test" {
+  path             = "%s"
+  items_wo         = jsonencode({ password = "%s" })
+  items_wo_version = %d
+}
+`, path, password, version)
+}
+
+func testResourceVariable_checkItems(path string, expected map[string]string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testProvider.Meta().(ProviderConfig).client
+		variable, _, err := client.Variables().Read(path, nil)
+		if err != nil {
+			return fmt.Errorf("error reading back variable %q: %s", path, err)
+		}
+
+		if len(variable.Items) != len(expected) {
+			return fmt.Errorf("expected %d items, got %d", len(expected), len(variable.Items))
+		}
+		for k, v := range expected {
+			if variable.Items[k] != v {
+				return fmt.Errorf("expected item %q to be %q, is %q in API", k, v, variable.Items[k])
+			}
+		}
+		return nil
+	}
+}
+
 func testResourceVariable_initialConfig(namespace, path string) string {
 	return fmt.Sprintf(`
 resource "nomad_variable" "test" {
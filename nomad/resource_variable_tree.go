@@ -0,0 +1,338 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
+)
+
+func resourceVariableTree() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVariableTreeWrite,
+		Update: resourceVariableTreeWrite,
+		Delete: resourceVariableTreeDelete,
+		Read:   resourceVariableTreeRead,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVariableTreeImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"prefix": {
+				Description:      "The path under which the variables are stored",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: pathValidation(),
+			},
+			"namespace": {
+				Description:      "Variables namespace",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          api.DefaultNamespace,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
+			},
+			"variables": {
+				Description:      "A JSON encoded map of the variables to store under the prefix, indexed by their path relative to the prefix, each being a map of strings of its items",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: variableTreeDiffSuppress,
+				ExactlyOneOf:     []string{"variables", "variables_wo"},
+			},
+			"variables_wo": {
+				Description:  "A JSON encoded map of the variables to store under the prefix, as in variables. This value is write-only and is never stored in state",
+				Type:         schema.TypeString,
+				Optional:     true,
+				WriteOnly:    true,
+				ValidateFunc: validation.StringIsJSON,
+				RequiredWith: []string{"variables_wo_version"},
+			},
+			"variables_wo_version": {
+				Description:  "The version of variables_wo, must be changed for new variables_wo values to be written",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"variables_wo"},
+			},
+			"paths": {
+				Description: "The paths of the variables managed by the resource, relative to the prefix",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceVariableTreeWrite(d *schema.ResourceData, meta any) error {
+	client := meta.(ProviderConfig).client
+
+	prefix := d.Get("prefix").(string)
+	ns := d.Get("namespace").(string)
+
+	raw := d.Get("variables").(string)
+	variablesWO, ok, err := getWriteOnlyString(d, cty.GetAttrPath("variables_wo"))
+	if err != nil {
+		return err
+	}
+	if ok {
+		raw = variablesWO
+	}
+	desired, err := parseVariableTree(raw)
+	if err != nil {
+		return err
+	}
+	for rel := range desired {
+		if diags := pathValidation()(variableTreePath(prefix, rel), cty.GetAttrPath("variables")); diags.HasError() {
+			return fmt.Errorf("invalid variable path %q: %s", rel, diags[0].Summary)
+		}
+	}
+
+	// Only the variables managed by the resource are compared, so the ones
+	// written under the prefix outside of Terraform are left alone.
+	current, err := readVariableTree(client, prefix, ns, variableTreeManagedPaths(d))
+	if err != nil {
+		return err
+	}
+
+	upserts, deletes := diffVariableTree(current, desired)
+	for _, rel := range upserts {
+		path := variableTreePath(prefix, rel)
+		log.Printf("[DEBUG] Upserting variable %s@%s", path, ns)
+		variable := &api.Variable{Namespace: ns, Path: path, Items: desired[rel]}
+		if _, _, err := client.Variables().Create(variable, nil); err != nil {
+			return fmt.Errorf("error creating variable %s@%s: %s", path, ns, err)
+		}
+		log.Printf("[DEBUG] Upserted variable %s@%s", path, ns)
+	}
+	if err := deleteVariableTreePaths(client, prefix, ns, deletes); err != nil {
+		return err
+	}
+
+	d.SetId(prefix + "@" + ns)
+	d.Set("paths", variableTreePaths(desired))
+
+	return resourceVariableTreeRead(d, meta)
+}
+
+func resourceVariableTreeDelete(d *schema.ResourceData, meta any) error {
+	client := meta.(ProviderConfig).client
+
+	prefix := d.Get("prefix").(string)
+	ns := d.Get("namespace").(string)
+
+	// Only delete the variables managed by the resource.
+	if err := deleteVariableTreePaths(client, prefix, ns, variableTreeManagedPaths(d)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceVariableTreeRead(d *schema.ResourceData, meta any) error {
+	client := meta.(ProviderConfig).client
+
+	prefix := d.Get("prefix").(string)
+	ns := d.Get("namespace").(string)
+
+	tree, err := readVariableTree(client, prefix, ns, variableTreeManagedPaths(d))
+	if err != nil {
+		return err
+	}
+
+	d.Set("paths", variableTreePaths(tree))
+
+	// Variables written with variables_wo must never be stored in state.
+	if _, ok := d.GetOk("variables_wo_version"); ok {
+		return d.Set("variables", nil)
+	}
+
+	raw, err := json.Marshal(tree)
+	if err != nil {
+		return fmt.Errorf("failed to encode variables: %v", err)
+	}
+
+	d.Set("variables", string(raw))
+	return nil
+}
+
+// resourceVariableTreeImport imports all the variables under the prefix, so
+// they are managed by the resource from then on.
+func resourceVariableTreeImport(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	client := meta.(ProviderConfig).client
+
+	idx := strings.LastIndex(d.Id(), "@")
+	if idx <= 0 {
+		return nil, fmt.Errorf("expected ID in the form <prefix>@<namespace>, got %q", d.Id())
+	}
+	prefix, ns := d.Id()[:idx], d.Id()[idx+1:]
+
+	tree, err := readVariableTree(client, prefix, ns, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("prefix", prefix)
+	d.Set("namespace", ns)
+	d.Set("paths", variableTreePaths(tree))
+	return []*schema.ResourceData{d}, nil
+}
+
+// variableTreeManagedPaths returns the paths of the variables managed by the
+// resource, relative to its prefix.
+func variableTreeManagedPaths(d *schema.ResourceData) []string {
+	paths := make([]string, 0)
+	for _, rel := range d.Get("paths").([]any) {
+		paths = append(paths, rel.(string))
+	}
+	return paths
+}
+
+// readVariableTree returns the items of the variables at paths, relative to
+// prefix, or of all the variables under prefix when paths is nil, indexed by
+// their relative path. The variables that don't exist are skipped.
+func readVariableTree(client *api.Client, prefix, ns string, paths []string) (map[string]map[string]string, error) {
+	q := &api.QueryOptions{Namespace: ns}
+
+	if paths != nil {
+		tree := make(map[string]map[string]string, len(paths))
+		for _, rel := range paths {
+			path := variableTreePath(prefix, rel)
+			variable, _, err := client.Variables().Peek(path, q)
+			if err != nil {
+				return nil, fmt.Errorf("error reading variable %s@%s: %s", path, ns, err)
+			}
+			if variable != nil {
+				tree[rel] = variable.Items
+			}
+		}
+		log.Printf("[DEBUG] Read %d variables under %s@%s", len(tree), prefix, ns)
+		return tree, nil
+	}
+
+	log.Printf("[DEBUG] Listing variables under %s@%s", prefix, ns)
+	metas, _, err := client.Variables().PrefixList(prefix+"/", q)
+	if err != nil {
+		return nil, fmt.Errorf("error listing variables under %s@%s: %s", prefix, ns, err)
+	}
+
+	tree := make(map[string]map[string]string, len(metas))
+	for _, meta := range metas {
+		rel, ok := strings.CutPrefix(meta.Path, prefix+"/")
+		if !ok || meta.Namespace != ns {
+			continue
+		}
+
+		variable, _, err := client.Variables().Peek(meta.Path, q)
+		if err != nil {
+			return nil, fmt.Errorf("error reading variable %s@%s: %s", meta.Path, ns, err)
+		}
+		if variable == nil {
+			continue
+		}
+		tree[rel] = variable.Items
+	}
+	log.Printf("[DEBUG] Read %d variables under %s@%s", len(tree), prefix, ns)
+
+	return tree, nil
+}
+
+// deleteVariableTreePaths deletes the variables at the paths relative to
+// prefix, ignoring those that don't exist.
+func deleteVariableTreePaths(client *api.Client, prefix, ns string, paths []string) error {
+	for _, rel := range paths {
+		path := variableTreePath(prefix, rel)
+		log.Printf("[DEBUG] Deleting variable %s@%s", path, ns)
+		if _, err := client.Variables().Delete(path, &api.WriteOptions{Namespace: ns}); err != nil {
+			return fmt.Errorf("error deleting variable %s@%s: %s", path, ns, err)
+		}
+		log.Printf("[DEBUG] Deleted variable %s@%s", path, ns)
+	}
+	return nil
+}
+
+func variableTreePath(prefix, rel string) string {
+	return prefix + "/" + rel
+}
+
+// variableTreePaths returns the sorted relative paths of the variables of
+// tree.
+func variableTreePaths(tree map[string]map[string]string) []string {
+	paths := make([]string, 0, len(tree))
+	for rel := range tree {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// parseVariableTree decodes the JSON encoded map of variables set in
+// variables.
+func parseVariableTree(raw string) (map[string]map[string]string, error) {
+	tree := make(map[string]map[string]string)
+	if raw == "" {
+		return tree, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &tree); err != nil {
+		return nil, fmt.Errorf("failed to parse variables, it must be a JSON encoded map of maps of strings: %v", err)
+	}
+	for rel, items := range tree {
+		if rel == "" || strings.HasPrefix(rel, "/") || strings.HasSuffix(rel, "/") {
+			return nil, fmt.Errorf("invalid variable path %q, paths must be relative to the prefix", rel)
+		}
+		if items == nil {
+			tree[rel] = make(map[string]string)
+		}
+	}
+	return tree, nil
+}
+
+// diffVariableTree returns the sorted paths of the variables that must be
+// written and of those that must be deleted to go from current to desired.
+func diffVariableTree(current, desired map[string]map[string]string) (upserts, deletes []string) {
+	for rel, items := range desired {
+		if existing, ok := current[rel]; !ok || !maps.Equal(existing, items) {
+			upserts = append(upserts, rel)
+		}
+	}
+	for rel := range current {
+		if _, ok := desired[rel]; !ok {
+			deletes = append(deletes, rel)
+		}
+	}
+	sort.Strings(upserts)
+	sort.Strings(deletes)
+	return upserts, deletes
+}
+
+// variableTreeDiffSuppress ignores differences in the formatting of the JSON
+// encoded variables.
+func variableTreeDiffSuppress(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	oldTree, err := parseVariableTree(oldValue)
+	if err != nil {
+		return false
+	}
+	newTree, err := parseVariableTree(newValue)
+	if err != nil {
+		return false
+	}
+	upserts, deletes := diffVariableTree(oldTree, newTree)
+	return len(upserts) == 0 && len(deletes) == 0
+}
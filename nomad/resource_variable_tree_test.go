@@ -0,0 +1,247 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceVariableTree_basic(t *testing.T) {
+	prefix := acctest.RandomWithPrefix("tf-nomad-test")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.4.0") },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceVariableTree_config(prefix, `{
+    "api/config"      = { port = "8080" }
+    "api/credentials" = { user = "admin", password = "first" }
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceVariable_checkItems(prefix+"/api/config", map[string]string{"port": "8080"}),
+					testResourceVariable_checkItems(prefix+"/api/credentials", map[string]string{"user": "admin", "password": "first"}),
+				),
+			},
+			{
+				Config: testResourceVariableTree_config(prefix, `{
+    "api/credentials" = { user = "admin", password = "second" }
+    "web/config"      = { port = "80" }
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceVariable_checkDestroy(api.DefaultNamespace, prefix+"/api/config"),
+					testResourceVariable_checkItems(prefix+"/api/credentials", map[string]string{"user": "admin", "password": "second"}),
+					testResourceVariable_checkItems(prefix+"/web/config", map[string]string{"port": "80"}),
+				),
+			},
+			{
+				ResourceName:      "nomad_variable_tree.test",
+				ImportState:       true,
+				ImportStateId:     prefix + "@default",
+				ImportStateVerify: true,
+			},
+		},
+
+		CheckDestroy: func(s *terraform.State) error {
+			for _, path := range []string{"api/config", "api/credentials", "web/config"} {
+				if err := testResourceVariable_checkDestroy(api.DefaultNamespace, prefix+"/"+path)(s); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
+
+func testResourceVariableTree_config(prefix, variables string) string {
+	return fmt.Sprintf(`
+resource "nomad_variable_tree" "test" {
+  prefix    = "%s"
+  variables = jsonencode(%s)
+}
+`, prefix, variables)
+}
+
+func TestResourceVariableTree_writeOnly(t *testing.T) {
+	prefix := acctest.RandomWithPrefix("tf-nomad-test")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t); testCheckMinVersion(t, "1.4.0") },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceVariableTree_writeOnlyConfig(prefix, 1, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("nomad_variable_tree.test", "variables_wo"),
+					resource.TestCheckResourceAttr("nomad_variable_tree.test", "variables", ""),
+					resource.TestCheckResourceAttr("nomad_variable_tree.test", "paths.#", "1"),
+					resource.TestCheckResourceAttr("nomad_variable_tree.test", "paths.0", "api/credentials"),
+					testResourceVariable_checkItems(prefix+"/api/credentials", map[string]string{"password": "first"}),
+				),
+			},
+			{
+				Config: testResourceVariableTree_writeOnlyConfig(prefix, 2, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nomad_variable_tree.test", "variables", ""),
+					resource.TestCheckResourceAttr("nomad_variable_tree.test", "variables_wo_version", "2"),
+					testResourceVariable_checkItems(prefix+"/api/credentials", map[string]string{"password": "second"}),
+				),
+			},
+		},
+
+		CheckDestroy: testResourceVariable_checkDestroy(api.DefaultNamespace, prefix+"/api/credentials"),
+	})
+}
+
+func testResourceVariableTree_writeOnlyConfig(prefix string, version int, password string) string {
+	return fmt.Sprintf(`
+resource "nomad_variable_tree" "test" {
+  prefix               = "%s"
+  variables_wo         = jsonencode({ "api/credentials" = { password = "%s" } })
+  variables_wo_version = %d
+}
+`, prefix, password, version)
+}
+
+func TestParseVariableTree(t *testing.T) {
+	tree, err := parseVariableTree(`{"api/config": {"port": "8080"}, "empty": null}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]map[string]string{
+		"api/config": {"port": "8080"},
+		"empty":      {},
+	}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("expected %v, got %v", want, tree)
+	}
+
+	for _, raw := range []string{`{"/api": {}}`, `{"api/": {}}`, `{"api": {"port": 8080}}`, `["api"]`} {
+		if _, err := parseVariableTree(raw); err == nil {
+			t.Errorf("expected %s to be invalid", raw)
+		}
+	}
+}
+
+func TestDiffVariableTree(t *testing.T) {
+	current := map[string]map[string]string{
+		"same":    {"a": "1"},
+		"changed": {"a": "1"},
+		"removed": {"a": "1"},
+	}
+	desired := map[string]map[string]string{
+		"same":    {"a": "1"},
+		"changed": {"a": "2"},
+		"added":   {"a": "1"},
+	}
+
+	upserts, deletes := diffVariableTree(current, desired)
+	if want := []string{"added", "changed"}; !reflect.DeepEqual(upserts, want) {
+		t.Errorf("expected upserts %v, got %v", want, upserts)
+	}
+	if want := []string{"removed"}; !reflect.DeepEqual(deletes, want) {
+		t.Errorf("expected deletes %v, got %v", want, deletes)
+	}
+}
+
+func TestResourceVariableTreeWrite_unmanagedVariables(t *testing.T) {
+	var lock sync.Mutex
+	vars := map[string]map[string]string{
+		"app/api/config": {"port": "8080"},
+		"app/old":        {"a": "1"},
+		"app/other":      {"b": "2"},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		path := strings.TrimPrefix(req.URL.Path, "/v1/var/")
+		switch req.Method {
+		case http.MethodGet:
+			items, ok := vars[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(&api.Variable{Namespace: "default", Path: path, Items: items})
+		case http.MethodPut:
+			var variable api.Variable
+			json.NewDecoder(req.Body).Decode(&variable)
+			vars[path] = variable.Items
+			json.NewEncoder(w).Encode(&variable)
+		case http.MethodDelete:
+			delete(vars, path)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := ProviderConfig{client: client}
+
+	r := resourceVariableTree()
+	state := &terraform.InstanceState{
+		ID: "app@default",
+		Attributes: map[string]string{
+			"id":        "app@default",
+			"prefix":    "app",
+			"namespace": "default",
+			"variables": `{"api/config":{"port":"8080"},"old":{"a":"1"}}`,
+			"paths.#":   "2",
+			"paths.0":   "api/config",
+			"paths.1":   "old",
+		},
+	}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]any{
+		"prefix":    "app",
+		"namespace": "default",
+		"variables": `{"api/config":{"port":"9090"}}`,
+	}), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff.RawConfig = cty.ObjectVal(map[string]cty.Value{"variables_wo": cty.NullVal(cty.String)})
+
+	newState, diags := r.Apply(context.Background(), state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// The variable written outside of Terraform is left alone.
+	want := map[string]map[string]string{
+		"app/api/config": {"port": "9090"},
+		"app/other":      {"b": "2"},
+	}
+	if !reflect.DeepEqual(vars, want) {
+		t.Errorf("expected variables %v, got %v", want, vars)
+	}
+	if got := newState.Attributes["paths.#"]; got != "1" {
+		t.Errorf("expected 1 managed path, got %s", got)
+	}
+
+	// Destroying the resource only deletes the variables it manages.
+	diff = &terraform.InstanceDiff{Destroy: true}
+	if _, diags := r.Apply(context.Background(), newState, diff, meta); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if want := map[string]map[string]string{"app/other": {"b": "2"}}; !reflect.DeepEqual(vars, want) {
+		t.Errorf("expected variables %v, got %v", want, vars)
+	}
+}
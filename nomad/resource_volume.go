@@ -10,11 +10,11 @@ import (
 	"fmt"
 	"hash/crc32"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-nomad/nomad/helper"
 )
 
 func resourceVolume() *schema.Resource {
@@ -46,11 +46,12 @@ func resourceVolume() *schema.Resource {
 			},
 
 			"namespace": {
-				ForceNew:    true,
-				Description: "The namespace in which to create the volume.",
-				Optional:    true,
-				Default:     "default",
-				Type:        schema.TypeString,
+				ForceNew:         true,
+				Description:      "The namespace in which to create the volume.",
+				Optional:         true,
+				Default:          api.DefaultNamespace,
+				Type:             schema.TypeString,
+				DiffSuppressFunc: helper.NamespaceDiffSuppress,
 			},
 
 			"volume_id": {
@@ -232,12 +233,10 @@ func resourceVolume() *schema.Resource {
 				},
 			},
 
-			"deregister_on_destroy": {
-				Description: "If true, the volume will be deregistered on destroy.",
-				Optional:    true,
-				Default:     true,
-				Type:        schema.TypeBool,
-			},
+			"deregister_on_destroy": deregisterOnDestroySchema("volume"),
+			"retain_on_destroy":     retainOnDestroySchema("volume"),
+
+			"force": forceDestroySchema("volume"),
 
 			"controller_required": {
 				Computed: true,
@@ -328,6 +327,18 @@ func resourceVolume() *schema.Resource {
 					}, false),
 				},
 			},
+
+			"create_index": {
+				Description: "The Raft index at which the volume was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"modify_index": {
+				Description: "The Raft index at which the volume was last modified.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
@@ -451,11 +462,7 @@ func resourceVolumeDelete(d *schema.ResourceData, meta interface{}) error {
 	client := providerConfig.client
 
 	// If deregistration is disabled, then do nothing
-	deregister_on_destroy := d.Get("deregister_on_destroy").(bool)
-	if !deregister_on_destroy {
-		log.Printf(
-			"[WARN] volume %q will not deregister since "+
-				"'deregister_on_destroy' is %t", d.Id(), deregister_on_destroy)
+	if skipDeregister(d, "volume") {
 		return nil
 	}
 
@@ -467,7 +474,7 @@ func resourceVolumeDelete(d *schema.ResourceData, meta interface{}) error {
 	if opts.Namespace == "" {
 		opts.Namespace = "default"
 	}
-	err := client.CSIVolumes().Deregister(id, true, opts)
+	err := client.CSIVolumes().Deregister(id, destroyFlag(d, "force", true), opts)
 	if err != nil {
 		return fmt.Errorf("error deregistering volume: %s", err)
 	}
@@ -491,7 +498,7 @@ func resourceVolumeRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		// As of Nomad 0.4.1, the API client returns an error for 404
 		// rather than a nil result, so we must check this way.
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			log.Printf("[DEBUG] volume %q does not exist, so removing", id)
 			d.SetId("")
 			return nil
@@ -512,6 +519,8 @@ func resourceVolumeRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("nodes_expected", volume.NodesExpected)
 	d.Set("schedulable", volume.Schedulable)
 	d.Set("topologies", flattenVolumeTopologies(volume.Topologies))
+	d.Set("create_index", int(volume.CreateIndex))
+	d.Set("modify_index", int(volume.ModifyIndex))
 	d.Set("topology_request", flattenVolumeTopologyRequests(volume.RequestedTopologies))
 	// The Nomad API redacts `mount_options` and `secrets`, so we don't update them
 	// with the response payload; they will remain as is.
@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the tracer used to create the spans of the
+// provider.
+const tracerName = "github.com/hashicorp/terraform-provider-nomad"
+
+// tracingParent holds the span context set in the TRACEPARENT environment
+// variable, used as the parent of the spans of the provider.
+var tracingParent = context.Background()
+
+// SetupTracing configures the export of the spans of the provider when the
+// standard OpenTelemetry environment variables, like
+// OTEL_EXPORTER_OTLP_ENDPOINT, configure an OTLP endpoint. The returned
+// function flushes the spans and must be called before the provider exits.
+func SetupTracing(ctx context.Context) (func(context.Context) error, error) {
+	if !tracingEnabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the OTLP exporter: %w", err)
+	}
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", "terraform-provider-nomad")),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the OpenTelemetry resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	// Terraform, or the CI running it, may set the trace the spans of the
+	// provider belong to.
+	if traceparent := os.Getenv("TRACEPARENT"); traceparent != "" {
+		tracingParent = otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier{
+			"traceparent": traceparent,
+		})
+	}
+
+	log.Printf("[DEBUG] Exporting OpenTelemetry traces")
+	return tp.Shutdown, nil
+}
+
+// tracingEnabled returns whether the OpenTelemetry environment variables
+// configure the export of the traces.
+func tracingEnabled() bool {
+	switch os.Getenv("OTEL_TRACES_EXPORTER") {
+	case "otlp":
+		return true
+	case "none":
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// withTracing wraps the functions of the resources that send requests to the
+// Nomad API to record them in spans.
+func withTracing(resources map[string]*schema.Resource, dataSource bool) map[string]*schema.Resource {
+	for typeName, r := range resources {
+		op := func(action string) apiOperation {
+			return apiOperation{action: action, typeName: typeName, dataSource: dataSource}
+		}
+
+		if r.CreateContext != nil {
+			r.CreateContext = tracingContextFunc(r, op("create"), r.CreateContext)
+		}
+		if r.ReadContext != nil {
+			r.ReadContext = schema.ReadContextFunc(tracingContextFunc(r, op("read"), schema.CreateContextFunc(r.ReadContext)))
+		}
+		if r.UpdateContext != nil {
+			r.UpdateContext = schema.UpdateContextFunc(tracingContextFunc(r, op("update"), schema.CreateContextFunc(r.UpdateContext)))
+		}
+		if r.DeleteContext != nil {
+			r.DeleteContext = schema.DeleteContextFunc(tracingContextFunc(r, op("delete"), schema.CreateContextFunc(r.DeleteContext)))
+		}
+		if r.Exists != nil {
+			r.Exists = tracingExistsFunc(r, op("exists"), r.Exists)
+		}
+	}
+	return resources
+}
+
+func tracingContextFunc(r *schema.Resource, op apiOperation, f schema.CreateContextFunc) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		ctx, span := startOperationSpan(ctx, r, op, d, meta)
+		defer span.End()
+
+		diags := f(ctx, d, meta)
+		endOperationSpan(span, r, d, diagnosticsErrorText(diags))
+		return diags
+	}
+}
+
+func tracingExistsFunc(r *schema.Resource, op apiOperation, f schema.ExistsFunc) schema.ExistsFunc {
+	return func(d *schema.ResourceData, meta any) (bool, error) {
+		_, span := startOperationSpan(context.Background(), r, op, d, meta)
+		defer span.End()
+
+		exists, err := f(d, meta)
+		errText := ""
+		if err != nil {
+			errText = err.Error()
+		}
+		span.SetAttributes(attribute.Bool("nomad.exists", exists))
+		endOperationSpan(span, r, d, errText)
+		return exists, err
+	}
+}
+
+func startOperationSpan(ctx context.Context, r *schema.Resource, op apiOperation, d *schema.ResourceData, meta any) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		if parent := trace.SpanContextFromContext(tracingParent); parent.IsValid() {
+			ctx = trace.ContextWithRemoteSpanContext(ctx, parent)
+		}
+	}
+
+	name := op.typeName
+	if op.dataSource {
+		name = "data." + name
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("nomad.type", op.typeName),
+		attribute.String("nomad.operation", op.action),
+		attribute.Bool("nomad.data_source", op.dataSource),
+	}
+	if config, ok := meta.(ProviderConfig); ok && config.config != nil {
+		attrs = append(attrs,
+			attribute.String("nomad.address", config.config.Address),
+			attribute.String("nomad.region", config.config.Region),
+		)
+	}
+	if _, ok := r.Schema["namespace"]; ok {
+		if namespace, _ := d.Get("namespace").(string); namespace != "" {
+			attrs = append(attrs, attribute.String("nomad.namespace", namespace))
+		}
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, fmt.Sprintf("%s %s", name, op.action), trace.WithAttributes(attrs...))
+}
+
+func endOperationSpan(span trace.Span, r *schema.Resource, d *schema.ResourceData, errText string) {
+	if id := d.Id(); id != "" {
+		span.SetAttributes(attribute.String("nomad.id", id))
+	}
+	if _, ok := r.Schema["modify_index"]; ok {
+		span.SetAttributes(attribute.String("nomad.modify_index", fmt.Sprint(d.Get("modify_index"))))
+	}
+	if errText != "" {
+		span.SetStatus(codes.Error, errText)
+	}
+}
+
+// withTracingTransport returns httpClient with a transport that records each
+// request sent to the Nomad API in a span when the export of the traces is
+// enabled, so the time spent in an operation can be split between its
+// requests. The client is returned as-is otherwise.
+func withTracingTransport(httpClient *http.Client) *http.Client {
+	if httpClient == nil || !tracingEnabled() {
+		return httpClient
+	}
+	httpClient.Transport = &tracingTransport{base: httpClient.Transport}
+	return httpClient
+}
+
+// tracingTransport records the requests it sends in spans, with their method,
+// path, status and the X-Nomad-Index header of their response. The spans are
+// children of the span in the context of the request when there is one, like
+// for the blocking queries, and of the trace set in TRACEPARENT otherwise.
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		if parent := trace.SpanContextFromContext(tracingParent); parent.IsValid() {
+			ctx = trace.ContextWithRemoteSpanContext(ctx, parent)
+		}
+	}
+	_, span := otel.Tracer(tracerName).Start(ctx, req.Method+" "+req.URL.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+			attribute.String("server.address", req.URL.Host),
+		),
+	)
+	defer span.End()
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if index := resp.Header.Get("X-Nomad-Index"); index != "" {
+		span.SetAttributes(attribute.String("nomad.index", index))
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+func diagnosticsErrorText(diags diag.Diagnostics) string {
+	var errs []string
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			errs = append(errs, d.Summary, d.Detail)
+		}
+	}
+	return strings.Join(errs, "\n")
+}
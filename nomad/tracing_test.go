@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	resources := withTracing(map[string]*schema.Resource{
+		"nomad_job": {
+			Schema: map[string]*schema.Schema{
+				"namespace": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"modify_index": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+			CreateContext: func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+				d.SetId("example")
+				d.Set("modify_index", "42")
+				return nil
+			},
+			ReadContext: func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+				return diag.Errorf("error reading job: Unexpected response code: 500")
+			},
+		},
+	}, false)
+
+	r := resources["nomad_job"]
+	d := r.TestResourceData()
+	must.NoError(t, d.Set("namespace", "prod"))
+	meta := ProviderConfig{config: &api.Config{Address: "http://127.0.0.1:4646", Region: "global"}}
+
+	must.Len(t, 0, r.CreateContext(context.Background(), d, meta))
+	must.Len(t, 1, r.ReadContext(context.Background(), d, meta))
+
+	spans := recorder.Ended()
+	must.Len(t, 2, spans)
+
+	must.Eq(t, "nomad_job create", spans[0].Name())
+	attrs := attribute.NewSet(spans[0].Attributes()...)
+	for key, want := range map[attribute.Key]string{
+		"nomad.type":         "nomad_job",
+		"nomad.operation":    "create",
+		"nomad.address":      "http://127.0.0.1:4646",
+		"nomad.region":       "global",
+		"nomad.namespace":    "prod",
+		"nomad.id":           "example",
+		"nomad.modify_index": "42",
+	} {
+		got, ok := attrs.Value(key)
+		must.True(t, ok, must.Sprintf("missing attribute %s", key))
+		must.Eq(t, want, got.AsString())
+	}
+	must.Eq(t, codes.Unset, spans[0].Status().Code)
+
+	must.Eq(t, "nomad_job read", spans[1].Name())
+	must.Eq(t, codes.Error, spans[1].Status().Code)
+	must.StrContains(t, spans[1].Status().Description, "Unexpected response code: 500")
+}
+
+func TestTracingEnabled(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	must.False(t, tracingEnabled())
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+	must.True(t, tracingEnabled())
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "none")
+	must.False(t, tracingEnabled())
+}
+
+func TestTracingTransport(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	t.Setenv("OTEL_TRACES_EXPORTER", "otlp")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/v1/namespace/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Nomad-Index", "42")
+		w.Write([]byte(`{"Name": "default"}`))
+	}))
+	defer srv.Close()
+
+	conf := &api.Config{Address: srv.URL}
+	httpClient, err := newHttpClient(conf)
+	must.NoError(t, err)
+	conf.HttpClient = withRateLimitRetries(httpClient, 2)
+	client, err := api.NewClient(conf)
+	must.NoError(t, err)
+
+	// The spans of the requests sent with the context of an operation are
+	// its children.
+	ctx, parent := tp.Tracer(tracerName).Start(context.Background(), "nomad_namespace read")
+	_, _, err = client.Namespaces().Info("default", (&api.QueryOptions{}).WithContext(ctx))
+	must.NoError(t, err)
+	parent.End()
+	_, _, err = client.Namespaces().Info("missing", nil)
+	must.Error(t, err)
+
+	spans := recorder.Ended()
+	must.Len(t, 3, spans)
+
+	must.Eq(t, "GET /v1/namespace/default", spans[0].Name())
+	must.Eq(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	attrs := attribute.NewSet(spans[0].Attributes()...)
+	index, ok := attrs.Value("nomad.index")
+	must.True(t, ok)
+	must.Eq(t, "42", index.AsString())
+	status, ok := attrs.Value("http.response.status_code")
+	must.True(t, ok)
+	must.Eq(t, http.StatusOK, status.AsInt64())
+	must.Eq(t, codes.Unset, spans[0].Status().Code)
+
+	must.Eq(t, "GET /v1/namespace/missing", spans[2].Name())
+	must.Eq(t, codes.Error, spans[2].Status().Code)
+
+	// The exec client gets a client without the wrapping transports.
+	_, ok = conf.HttpClient.Transport.(*rateLimitTransport).base.(*tracingTransport)
+	must.True(t, ok)
+	exec, err := withoutRateLimitRetries(client, conf)
+	must.NoError(t, err)
+	must.NotEq(t, client, exec)
+}
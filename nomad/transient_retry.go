@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 5
+	retryWaitMin      = 1 * time.Second
+	retryWaitMax      = 30 * time.Second
+)
+
+// transientErrors are returned when a request never reached a server able to
+// process it, so it is safe to send it again. This routinely happens while the
+// Nomad servers are being upgraded and the cluster is electing a new leader.
+var transientErrors = []string{
+	"No cluster leader",
+	"connection refused",
+	"Unexpected response code: 503",
+}
+
+// transientReadErrors are returned when the connection to the server is
+// interrupted, or by a proxy in front of it that may have forwarded the
+// request before failing. The request may already have been processed so it is
+// only retried for reads.
+var transientReadErrors = []string{
+	"EOF",
+	"connection reset by peer",
+	"Unexpected response code: 502",
+	"Unexpected response code: 504",
+}
+
+// withTransientRetries returns httpClient with a transport that retries the
+// requests failing with a transient error up to maxRetries times, and the
+// requests rate limited with a 429 response as withRateLimitRetries does. The
+// requests themselves are retried rather than the CRUD functions sending them,
+// so the writes that succeeded before an error are not sent again. The client
+// is returned as-is when maxRetries is 0.
+func withTransientRetries(httpClient *http.Client, maxRetries int) *http.Client {
+	if httpClient == nil || maxRetries <= 0 {
+		return httpClient
+	}
+	httpClient.Transport = &transientRetryTransport{
+		base:       httpClient.Transport,
+		maxRetries: maxRetries,
+	}
+	return withRateLimitRetries(httpClient, maxRetries)
+}
+
+// transientRetryTransport retries the requests that failed with a transient
+// error, with an exponential backoff. Only the reads are retried after errors
+// that don't show the request was never processed.
+type transientRetryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+}
+
+func (t *transientRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	read := req.Method == http.MethodGet || req.Method == http.MethodHead
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.maxRetries {
+			return resp, err
+		}
+
+		var errText string
+		if err != nil {
+			errText = err.Error()
+		} else {
+			errText = transientResponseText(resp)
+		}
+		if errText == "" || !isTransientError(errText, read) {
+			return resp, err
+		}
+		retry, ok := rewindRequest(req)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		wait := retryBackoff(attempt)
+		log.Printf("[WARN] Request %s %s failed with a transient error, retrying in %s: %s", req.Method, req.URL.Path, wait, errText)
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		req = retry
+	}
+}
+
+// transientResponseText returns the error the Nomad API client reports for a
+// server error response, as matched by isTransientError, or "" for the other
+// responses. The body of the response is read, and replaced so it can still be
+// read by the caller.
+func transientResponseText(resp *http.Response) string {
+	if resp.StatusCode < http.StatusInternalServerError {
+		return ""
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return fmt.Sprintf("Unexpected response code: %d (%s)", resp.StatusCode, bytes.TrimSpace(body))
+}
+
+// isTransientError returns whether errText reports a transient error. Errors
+// caused by interrupted connections are only considered transient for reads.
+func isTransientError(errText string, read bool) bool {
+	for _, e := range transientErrors {
+		if strings.Contains(errText, e) {
+			return true
+		}
+	}
+	if read {
+		for _, e := range transientReadErrors {
+			if strings.Contains(errText, e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// retryBackoff returns how long to wait before retrying a request that failed
+// on the given attempt.
+func retryBackoff(attempt int) time.Duration {
+	wait := retryWaitMin
+	for i := 0; i < attempt && wait < retryWaitMax; i++ {
+		wait *= 2
+	}
+	return min(wait, retryWaitMax)
+}
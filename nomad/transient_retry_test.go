@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+)
+
+func TestIsTransientError(t *testing.T) {
+	testCases := []struct {
+		err       string
+		transient bool
+		read      bool
+	}{
+		{
+			err:       "error reading job: Unexpected response code: 500 (No cluster leader)",
+			transient: true,
+			read:      true,
+		},
+		{
+			err:       `Put "http://127.0.0.1:4646/v1/jobs": dial tcp 127.0.0.1:4646: connect: connection refused`,
+			transient: true,
+			read:      true,
+		},
+		{
+			err:       "Unexpected response code: 502 (Bad Gateway)",
+			transient: false,
+			read:      true,
+		},
+		{
+			err:       "Unexpected response code: 504 (Gateway Timeout)",
+			transient: false,
+			read:      true,
+		},
+		{
+			err:       "Unexpected response code: 503 (Service Unavailable)",
+			transient: true,
+			read:      true,
+		},
+		{
+			err:       `Get "http://127.0.0.1:4646/v1/job/example": EOF`,
+			transient: false,
+			read:      true,
+		},
+		{
+			err:       "read tcp 127.0.0.1:4646: read: connection reset by peer",
+			transient: false,
+			read:      true,
+		},
+		{
+			err:       "Unexpected response code: 500 (rpc error: job not found)",
+			transient: false,
+			read:      false,
+		},
+		{
+			err:       "Unexpected response code: 403 (Permission denied)",
+			transient: false,
+			read:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.err, func(t *testing.T) {
+			must.Eq(t, tc.transient, isTransientError(tc.err, false))
+			must.Eq(t, tc.transient || tc.read, isTransientError(tc.err, true))
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	must.Eq(t, time.Second, retryBackoff(0))
+	must.Eq(t, 2*time.Second, retryBackoff(1))
+	must.Eq(t, 16*time.Second, retryBackoff(4))
+	must.Eq(t, retryWaitMax, retryBackoff(5))
+	must.Eq(t, retryWaitMax, retryBackoff(100))
+}
+
+func TestTransientRetryTransport(t *testing.T) {
+	var requests, failures atomic.Int32
+	var status atomic.Int32
+	var body atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		if failures.Add(-1) >= 0 {
+			w.WriteHeader(int(status.Load()))
+			w.Write([]byte(body.Load().(string)))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	conf := &api.Config{Address: srv.URL, TLSConfig: &api.TLSConfig{}}
+	httpClient, err := newHttpClient(conf)
+	must.NoError(t, err)
+	conf.HttpClient = withTransientRetries(httpClient, 5)
+	client, err := api.NewClient(conf)
+	must.NoError(t, err)
+
+	fail := func(count, code int, msg string) {
+		requests.Store(0)
+		failures.Store(int32(count))
+		status.Store(int32(code))
+		body.Store(msg)
+	}
+
+	// Writes are retried when the cluster has no leader, the request was not
+	// processed.
+	fail(1, http.StatusInternalServerError, "No cluster leader")
+	_, err = client.Namespaces().Register(&api.Namespace{Name: "ops"}, nil)
+	must.NoError(t, err)
+	must.Eq(t, 2, requests.Load())
+
+	// Gateway errors are only retried for reads, the request may have been
+	// processed.
+	fail(1, http.StatusBadGateway, "Bad Gateway")
+	_, err = client.Namespaces().Register(&api.Namespace{Name: "ops"}, nil)
+	must.ErrorContains(t, err, "Unexpected response code: 502 (Bad Gateway)")
+	must.Eq(t, 1, requests.Load())
+
+	fail(1, http.StatusBadGateway, "Bad Gateway")
+	_, _, err = client.Namespaces().Info("ops", nil)
+	must.NoError(t, err)
+	must.Eq(t, 2, requests.Load())
+
+	// The other errors are returned as-is.
+	fail(1, http.StatusInternalServerError, "rpc error: permission denied")
+	_, err = client.Namespaces().Register(&api.Namespace{Name: "ops"}, nil)
+	must.ErrorContains(t, err, "rpc error: permission denied")
+	must.Eq(t, 1, requests.Load())
+
+	// Retries stop when the request is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fail(10, http.StatusServiceUnavailable, "Service Unavailable")
+	_, _, err = client.Namespaces().Info("ops", (&api.QueryOptions{}).WithContext(ctx))
+	must.Error(t, err)
+	must.LessEq(t, 1, requests.Load())
+}
@@ -0,0 +1,310 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// versionRequirement is a Nomad version required by a resource or one of its
+// arguments.
+type versionRequirement struct {
+	// attribute is the argument that requires the version, or empty when the
+	// whole resource requires it.
+	attribute string
+
+	// min is the minimum Nomad version required, if any.
+	min string
+
+	// enterprise is set when Nomad Enterprise is required.
+	enterprise bool
+}
+
+// versionRequirements are the Nomad versions required by the resources and
+// data sources, so they can be reported during plan instead of the errors
+// returned by agents that don't support them.
+var versionRequirements = map[string][]versionRequirement{
+	"nomad_acl_auth_method":                  {{min: "1.5.0"}},
+	"nomad_acl_binding_rule":                 {{min: "1.5.0"}},
+	"nomad_acl_role":                         {{min: "1.4.0"}},
+	"nomad_acl_roles":                        {{min: "1.4.0"}},
+	"nomad_dynamic_host_volume":              {{min: "1.10.0"}},
+	"nomad_dynamic_host_volume_registration": {{min: "1.10.0"}},
+	"nomad_node_pool":                        {{min: "1.6.0"}, {attribute: "scheduler_config", min: "1.6.0", enterprise: true}},
+	"nomad_node_pools":                       {{min: "1.6.0"}},
+	"nomad_quota_specification":              {{enterprise: true}},
+	"nomad_quota_usage":                      {{enterprise: true}},
+	"nomad_sentinel_policy":                  {{enterprise: true}},
+	"nomad_variable":                         {{min: "1.4.0"}},
+	"nomad_variable_tree":                    {{min: "1.4.0"}},
+}
+
+// agentVersion caches the version of the Nomad agent the provider sends its
+// requests to.
+type agentVersion struct {
+	once    sync.Once
+	version *version.Version
+}
+
+// nomadVersion returns the version of the Nomad agent, and false when it can't
+// be detected, for example because the ACL token of the provider is not
+// allowed to read the agent.
+func (c ProviderConfig) nomadVersion() (*version.Version, bool) {
+	if c.version == nil || c.client == nil {
+		return nil, false
+	}
+
+	c.version.once.Do(func() {
+		self, err := c.client.Agent().Self()
+		if err != nil {
+			log.Printf("[WARN] Unable to read the Nomad agent to detect its version, skipping version checks: %s", err)
+			return
+		}
+		v, err := parseAgentVersion(self)
+		if err != nil {
+			log.Printf("[WARN] Unable to detect the version of the Nomad agent, skipping version checks: %s", err)
+			return
+		}
+		log.Printf("[DEBUG] Detected Nomad agent version %s", v)
+		c.version.version = v
+	})
+	return c.version.version, c.version.version != nil
+}
+
+// parseAgentVersion returns the version of the agent from its configuration,
+// or from its member tags when the configuration is not available.
+func parseAgentVersion(self *api.AgentSelf) (*version.Version, error) {
+	if conf, ok := self.Config["Version"].(map[string]any); ok {
+		v, _ := conf["Version"].(string)
+		if pre, _ := conf["VersionPrerelease"].(string); pre != "" {
+			v += "-" + pre
+		}
+		if meta, _ := conf["VersionMetadata"].(string); meta != "" {
+			v += "+" + meta
+		}
+		return version.NewVersion(v)
+	}
+	if build := self.Member.Tags["build"]; build != "" {
+		return version.NewVersion(build)
+	}
+	return nil, errors.New("the agent didn't return its version")
+}
+
+// checkVersionRequirement returns an error when v doesn't meet req. subject is
+// what requires the version.
+func checkVersionRequirement(v *version.Version, req versionRequirement, subject, address string) error {
+	ok := !req.enterprise || v.Metadata() == "ent"
+	if req.min != "" {
+		ok = ok && v.Core().GreaterThanOrEqual(version.Must(version.NewVersion(req.min)))
+	}
+	if ok {
+		return nil
+	}
+
+	var msg string
+	switch {
+	case req.enterprise && req.min != "":
+		msg = fmt.Sprintf("%s is only supported in Nomad Enterprise %s or later", subject, req.min)
+	case req.enterprise:
+		msg = fmt.Sprintf("%s is only supported in Nomad Enterprise", subject)
+	default:
+		msg = fmt.Sprintf("%s requires Nomad %s or later", subject, req.min)
+	}
+	return fmt.Errorf("%s, but the Nomad agent at %s runs Nomad %s", msg, address, v)
+}
+
+// withVersionRequirements checks the versionRequirements of the resources
+// during plan, when they are created or the arguments that require a version
+// are set, and of the data sources before they are read.
+func withVersionRequirements(resources map[string]*schema.Resource, dataSource bool) map[string]*schema.Resource {
+	for typeName, r := range resources {
+		reqs := versionRequirements[typeName]
+		if len(reqs) == 0 {
+			continue
+		}
+
+		if dataSource {
+			if r.ReadContext != nil {
+				r.ReadContext = versionReadContextFunc(typeName, reqs, r.ReadContext)
+			}
+			continue
+		}
+		r.CustomizeDiff = versionCustomizeDiffFunc(typeName, reqs, r.CustomizeDiff)
+	}
+	return resources
+}
+
+func versionReadContextFunc(typeName string, reqs []versionRequirement, f schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		if err := checkVersionRequirements(meta, typeName, reqs, func(string) bool { return true }); err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, meta)
+	}
+}
+
+func versionCustomizeDiffFunc(typeName string, reqs []versionRequirement, f schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+		// Only the requirements of the resources being created, or of the
+		// arguments being set, are checked so existing resources don't
+		// require reading the agent on every plan.
+		required := func(attribute string) bool {
+			if attribute == "" {
+				return d.Id() == ""
+			}
+			_, set := d.GetOk(attribute)
+			return set && d.HasChange(attribute)
+		}
+		if err := checkVersionRequirements(meta, typeName, reqs, required); err != nil {
+			return err
+		}
+
+		if f == nil {
+			return nil
+		}
+		return f(ctx, d, meta)
+	}
+}
+
+// checkVersionRequirements checks the requirements for which required returns
+// true against the version of the Nomad agent. The requirements are not
+// checked when the version can't be detected.
+func checkVersionRequirements(meta any, typeName string, reqs []versionRequirement, required func(attribute string) bool) error {
+	config, ok := meta.(ProviderConfig)
+	if !ok || config.offline {
+		return nil
+	}
+
+	for _, req := range reqs {
+		if !required(req.attribute) {
+			continue
+		}
+		v, ok := config.nomadVersion()
+		if !ok {
+			return nil
+		}
+
+		subject := typeName
+		if req.attribute != "" {
+			subject = req.attribute
+		}
+		address := ""
+		if config.config != nil {
+			address = config.config.Address
+		}
+		if err := checkVersionRequirement(v, req, subject, address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jobBlockVersionRequirements are the Nomad versions required by the blocks of
+// jobspecs, so jobs using them are reported during plan instead of failing to
+// register.
+var jobBlockVersionRequirements = map[string]versionRequirement{
+	"action":            {min: "1.7.0"},
+	"disconnect":        {min: "1.8.0"},
+	"numa":              {min: "1.7.0", enterprise: true},
+	"schedule":          {min: "1.8.0", enterprise: true},
+	"transparent_proxy": {min: "1.8.0"},
+	"ui":                {min: "1.8.0"},
+}
+
+// jobBlock is a block of a jobspec that requires a Nomad version.
+type jobBlock struct {
+	// name is the key of the block in jobBlockVersionRequirements.
+	name string
+
+	// path is where the block is in the jobspec.
+	path string
+}
+
+// jobVersionedBlocks returns the blocks of job listed in
+// jobBlockVersionRequirements.
+func jobVersionedBlocks(job *api.Job) []jobBlock {
+	var blocks []jobBlock
+	add := func(name, path string) {
+		blocks = append(blocks, jobBlock{name: name, path: path})
+	}
+	services := func(path string, services []*api.Service) {
+		for _, s := range services {
+			if s.Connect != nil && s.Connect.SidecarService != nil && s.Connect.SidecarService.Proxy != nil &&
+				s.Connect.SidecarService.Proxy.TransparentProxy != nil {
+				add("transparent_proxy", fmt.Sprintf("%s.service[%q].connect.sidecar_service.proxy", path, s.Name))
+			}
+		}
+	}
+
+	if job.UI != nil {
+		add("ui", "job")
+	}
+	for _, tg := range job.TaskGroups {
+		var tgName string
+		if tg.Name != nil {
+			tgName = *tg.Name
+		}
+		tgPath := fmt.Sprintf("group[%q]", tgName)
+		if tg.Disconnect != nil {
+			add("disconnect", tgPath)
+		}
+		services(tgPath, tg.Services)
+
+		for _, task := range tg.Tasks {
+			taskPath := fmt.Sprintf("%s.task[%q]", tgPath, task.Name)
+			if len(task.Actions) > 0 {
+				add("action", taskPath)
+			}
+			if task.Schedule != nil {
+				add("schedule", taskPath)
+			}
+			if task.Resources != nil && task.Resources.NUMA != nil {
+				add("numa", taskPath+".resources")
+			}
+			services(taskPath, task.Services)
+		}
+	}
+	return blocks
+}
+
+// checkJobVersionRequirements returns an error listing the blocks of job that
+// are not supported by the version of the Nomad agent. The requirements are
+// not checked when the version can't be detected.
+func checkJobVersionRequirements(meta any, job *api.Job) error {
+	config, ok := meta.(ProviderConfig)
+	if !ok || config.offline {
+		return nil
+	}
+
+	blocks := jobVersionedBlocks(job)
+	if len(blocks) == 0 {
+		return nil
+	}
+	v, ok := config.nomadVersion()
+	if !ok {
+		return nil
+	}
+
+	address := ""
+	if config.config != nil {
+		address = config.config.Address
+	}
+	var errs []error
+	for _, block := range blocks {
+		subject := fmt.Sprintf("the %s block of %s", block.name, block.path)
+		if err := checkVersionRequirement(v, jobBlockVersionRequirements[block.name], subject, address); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/shoenig/test/must"
+)
+
+func TestParseAgentVersion(t *testing.T) {
+	v, err := parseAgentVersion(&api.AgentSelf{
+		Config: map[string]any{
+			"Version": map[string]any{
+				"Version":           "1.9.0",
+				"VersionPrerelease": "beta.1",
+				"VersionMetadata":   "ent",
+			},
+		},
+	})
+	must.NoError(t, err)
+	must.Eq(t, "1.9.0-beta.1+ent", v.String())
+
+	v, err = parseAgentVersion(&api.AgentSelf{
+		Member: api.AgentMember{Tags: map[string]string{"build": "1.7.5"}},
+	})
+	must.NoError(t, err)
+	must.Eq(t, "1.7.5", v.String())
+
+	_, err = parseAgentVersion(&api.AgentSelf{})
+	must.Error(t, err)
+}
+
+func TestCheckVersionRequirement(t *testing.T) {
+	testCases := []struct {
+		version string
+		req     versionRequirement
+		err     string
+	}{
+		{
+			version: "1.6.0",
+			req:     versionRequirement{min: "1.6.0"},
+		},
+		{
+			version: "1.6.0-rc.1",
+			req:     versionRequirement{min: "1.6.0"},
+		},
+		{
+			version: "1.5.3",
+			req:     versionRequirement{min: "1.6.0"},
+			err:     "nomad_node_pool requires Nomad 1.6.0 or later, but the Nomad agent at http://127.0.0.1:4646 runs Nomad 1.5.3",
+		},
+		{
+			version: "1.7.0",
+			req:     versionRequirement{enterprise: true},
+			err:     "nomad_node_pool is only supported in Nomad Enterprise, but the Nomad agent at http://127.0.0.1:4646 runs Nomad 1.7.0",
+		},
+		{
+			version: "1.5.0+ent",
+			req:     versionRequirement{min: "1.6.0", enterprise: true},
+			err:     "nomad_node_pool is only supported in Nomad Enterprise 1.6.0 or later, but the Nomad agent at http://127.0.0.1:4646 runs Nomad 1.5.0+ent",
+		},
+		{
+			version: "1.7.0+ent",
+			req:     versionRequirement{min: "1.6.0", enterprise: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.version, func(t *testing.T) {
+			err := checkVersionRequirement(version.Must(version.NewVersion(tc.version)), tc.req, "nomad_node_pool", "http://127.0.0.1:4646")
+			if tc.err == "" {
+				must.NoError(t, err)
+			} else {
+				must.EqError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWithVersionRequirements(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/agent/self" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		requests.Add(1)
+		fmt.Fprint(w, `{"config": {"Version": {"Version": "1.7.5"}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := api.NewClient(&api.Config{Address: ts.URL})
+	must.NoError(t, err)
+	meta := ProviderConfig{
+		client:  client,
+		config:  &api.Config{Address: ts.URL},
+		version: &agentVersion{},
+	}
+
+	r := Provider().ResourcesMap["nomad_node_pool"]
+	diff := func(state *terraform.InstanceState, config map[string]any) error {
+		_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+		return err
+	}
+
+	// Node pools are supported.
+	must.NoError(t, diff(nil, map[string]any{"name": "prod"}))
+
+	// scheduler_config requires Nomad Enterprise.
+	err = diff(nil, map[string]any{
+		"name": "prod",
+		"scheduler_config": []any{map[string]any{
+			"scheduler_algorithm": "spread",
+		}},
+	})
+	must.EqError(t, err, fmt.Sprintf("scheduler_config is only supported in Nomad Enterprise 1.6.0 or later, but the Nomad agent at %s runs Nomad 1.7.5", ts.URL))
+
+	// The version is only read once.
+	must.Eq(t, 1, requests.Load())
+
+	// The version is not checked when the provider is offline.
+	meta.offline = true
+	must.NoError(t, diff(nil, map[string]any{
+		"name": "prod",
+		"scheduler_config": []any{map[string]any{
+			"scheduler_algorithm": "spread",
+		}},
+	}))
+}
+
+func TestCheckJobVersionRequirements(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"config": {"Version": {"Version": "1.7.5"}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := api.NewClient(&api.Config{Address: ts.URL})
+	must.NoError(t, err)
+	meta := ProviderConfig{
+		client:  client,
+		config:  &api.Config{Address: ts.URL},
+		version: &agentVersion{},
+	}
+
+	parse := func(jobspec string) *api.Job {
+		job, err := parseJobspec(jobspec, JobParserConfig{HCL2: HCL2JobParserConfig{Enabled: true}})
+		must.NoError(t, err)
+		return job
+	}
+
+	// Actions are supported since Nomad 1.7.
+	must.NoError(t, checkJobVersionRequirements(meta, parse(`
+job "example" {
+  group "web" {
+    task "server" {
+      driver = "docker"
+      action "ping" {
+        command = "/bin/true"
+      }
+    }
+  }
+}`)))
+
+	err = checkJobVersionRequirements(meta, parse(`
+job "example" {
+  ui {
+    description = "Example"
+  }
+  group "web" {
+    service {
+      name = "web"
+      connect {
+        sidecar_service {
+          proxy {
+            transparent_proxy {}
+          }
+        }
+      }
+    }
+    task "server" {
+      driver = "docker"
+      resources {
+        numa {
+          affinity = "require"
+        }
+      }
+    }
+  }
+}`))
+	must.EqError(t, err, fmt.Sprintf(`the ui block of job requires Nomad 1.8.0 or later, but the Nomad agent at %[1]s runs Nomad 1.7.5
+the transparent_proxy block of group["web"].service["web"].connect.sidecar_service.proxy requires Nomad 1.8.0 or later, but the Nomad agent at %[1]s runs Nomad 1.7.5
+the numa block of group["web"].task["server"].resources is only supported in Nomad Enterprise 1.7.0 or later, but the Nomad agent at %[1]s runs Nomad 1.7.5`, ts.URL))
+
+	// The version is not checked when the provider is offline.
+	meta.offline = true
+	must.NoError(t, checkJobVersionRequirements(meta, parse(`
+job "example" {
+  ui {
+    description = "Example"
+  }
+  group "web" {
+    task "server" {
+      driver = "docker"
+    }
+  }
+}`)))
+}
@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// waitDoneError returns the error of a waiter whose context is done, either
+// because its timeout expired or because the operation was canceled, for
+// example with Ctrl-C or when a Terraform Cloud run is canceled. lastErr is
+// the reason the waiter was still waiting, if any.
+func waitDoneError(ctx context.Context, timeout time.Duration, what string, lastErr error) error {
+	var err error
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timeout after %s waiting for %s", timeout, what)
+	} else {
+		err = fmt.Errorf("canceled while waiting for %s", what)
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("%w: %s", err, lastErr)
+	}
+	return err
+}
+
+// dataSourceWaitForSchema returns the schema of the wait_for block of the data
+// sources that can wait for the objects they read to match a condition.
+func dataSourceWaitForSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Wait for the objects read to match a condition before returning.",
+		Optional:    true,
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"condition": {
+					Description:  "The boolean expression the objects must match, using the fields of the objects returned by the Nomad API.",
+					Required:     true,
+					Type:         schema.TypeString,
+					ValidateFunc: validateExpression,
+				},
+				"min_count": {
+					Description:  "The minimum number of objects that must match the condition.",
+					Optional:     true,
+					Type:         schema.TypeInt,
+					Default:      1,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"timeout": {
+					Description:  "How long to wait for the objects to match the condition.",
+					Optional:     true,
+					Type:         schema.TypeString,
+					Default:      "5m",
+					ValidateFunc: validatePositiveDuration,
+				},
+				"poll_interval": {
+					Description:  "How often to read the objects again while waiting.",
+					Optional:     true,
+					Type:         schema.TypeString,
+					Default:      "5s",
+					ValidateFunc: validatePositiveDuration,
+				},
+			},
+		},
+	}
+}
+
+// validateExpression checks that an argument, like the condition of a
+// wait_for block, is a valid boolean expression.
+func validateExpression(i any, k string) ([]string, []error) {
+	condition, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if condition == "" {
+		return nil, nil
+	}
+	if _, err := bexpr.CreateEvaluator(condition); err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: %v", k, err)}
+	}
+	return nil, nil
+}
+
+// validatePositiveDuration checks that an argument, like the timeout of a
+// wait_for block, is a duration greater than 0.
+func validatePositiveDuration(i any, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: %v", k, err)}
+	}
+	if d <= 0 {
+		return nil, []error{fmt.Errorf("invalid %s: it must be greater than 0", k)}
+	}
+	return nil, nil
+}
+
+// dataSourceWaitFor reads the objects of a data source with list until
+// min_count of them match the condition of its wait_for block, or the timeout
+// of the block expires. The objects are only read once when the block is not
+// set. what describes the objects in errors.
+func dataSourceWaitFor[T any](ctx context.Context, d *schema.ResourceData, what string, list func(context.Context) ([]T, error)) ([]T, error) {
+	waitList, ok := d.Get("wait_for").([]any)
+	if !ok || len(waitList) == 0 || waitList[0] == nil {
+		return list(ctx)
+	}
+	wait := waitList[0].(map[string]any)
+
+	condition := wait["condition"].(string)
+	eval, err := bexpr.CreateEvaluator(condition)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wait_for condition: %w", err)
+	}
+	minCount := wait["min_count"].(int)
+	timeout, err := time.ParseDuration(wait["timeout"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse wait_for timeout: %w", err)
+	}
+	interval, err := time.ParseDuration(wait["poll_interval"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse wait_for poll_interval: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	log.Printf("[DEBUG] waiting for %d %s to match %q", minCount, what, condition)
+	var lastErr error
+	for {
+		objs, err := list(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, waitDoneError(ctx, timeout, fmt.Sprintf("%s to match the wait_for condition", what), lastErr)
+			}
+			return nil, err
+		}
+
+		matching := 0
+		for _, obj := range objs {
+			match, err := eval.Evaluate(obj)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate wait_for condition: %w", err)
+			}
+			if match {
+				matching++
+			}
+		}
+		if matching >= minCount {
+			return objs, nil
+		}
+		lastErr = fmt.Errorf("%d of the %d %s read match the condition, %d required", matching, len(objs), what, minCount)
+		log.Printf("[DEBUG] %s", lastErr)
+
+		select {
+		case <-ctx.Done():
+			return nil, waitDoneError(ctx, timeout, fmt.Sprintf("%s to match the wait_for condition", what), lastErr)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// pollConfig controls how often the waiters of the resources, like nomad_job
+// monitoring its deployment, query the objects they wait for. The queries
+// are blocking queries that return as soon as the object changes, so by
+// default a query is sent after each change. On busy clusters that can be a
+// lot of requests, so interval sets the minimum time between two queries,
+// growing by backoff after each of them up to maxInterval.
+type pollConfig struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	backoff     float64
+}
+
+// pacer returns the pollPacer of a single wait.
+func (p pollConfig) pacer() *pollPacer {
+	return &pollPacer{config: p, next: p.interval}
+}
+
+type pollPacer struct {
+	config pollConfig
+	next   time.Duration
+}
+
+// wait blocks until the next query of the waiter can be sent, or until ctx is
+// done. The query then fails with the error of ctx.
+func (p *pollPacer) wait(ctx context.Context) {
+	if p.next <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(p.next)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return
+	case <-timer.C:
+	}
+
+	if p.config.backoff > 1 {
+		p.next = time.Duration(float64(p.next) * p.config.backoff)
+		if p.config.maxInterval > 0 && p.next > p.config.maxInterval {
+			p.next = p.config.maxInterval
+		}
+	}
+}
+
+// expandPollConfig returns the pollConfig set in the poll block of the
+// provider.
+func expandPollConfig(raw []any) (pollConfig, error) {
+	p := pollConfig{backoff: 1}
+	if len(raw) == 0 || raw[0] == nil {
+		return p, nil
+	}
+	m := raw[0].(map[string]any)
+
+	var err error
+	if p.interval, err = time.ParseDuration(m["interval"].(string)); err != nil {
+		return p, fmt.Errorf("failed to parse poll interval: %w", err)
+	}
+	if p.maxInterval, err = time.ParseDuration(m["max_interval"].(string)); err != nil {
+		return p, fmt.Errorf("failed to parse poll max_interval: %w", err)
+	}
+	p.backoff = m["backoff"].(float64)
+	return p, nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+)
+
+func TestDataSourceAllocations_waitFor(t *testing.T) {
+	// The allocations are only running after a few reads.
+	var reads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		status := api.AllocClientStatusPending
+		if reads.Add(1) >= 3 {
+			status = api.AllocClientStatusRunning
+		}
+		json.NewEncoder(w).Encode([]*api.AllocationListStub{
+			{ID: "alloc1", ClientStatus: status},
+			{ID: "alloc2", ClientStatus: status},
+		})
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	must.NoError(t, err)
+	meta := ProviderConfig{client: client}
+
+	r := dataSourceAllocations()
+	d := r.TestResourceData()
+	must.NoError(t, d.Set("wait_for", []any{map[string]any{
+		"condition":     `ClientStatus == "running"`,
+		"min_count":     2,
+		"timeout":       "5s",
+		"poll_interval": "10ms",
+	}}))
+
+	diags := r.ReadContext(context.Background(), d, meta)
+	must.False(t, diags.HasError(), must.Sprintf("unexpected error: %v", diags))
+	must.Eq(t, 3, reads.Load())
+	must.Eq(t, "running", d.Get("allocations.0.client_status").(string))
+
+	// The read fails when the condition is not met before the timeout.
+	reads.Store(-1000)
+	must.NoError(t, d.Set("wait_for", []any{map[string]any{
+		"condition":     `ClientStatus == "running"`,
+		"min_count":     1,
+		"timeout":       "50ms",
+		"poll_interval": "10ms",
+	}}))
+	diags = r.ReadContext(context.Background(), d, meta)
+	must.True(t, diags.HasError())
+	must.Eq(t, "error reading allocations: timeout after 50ms waiting for allocations to match the wait_for condition: 0 of the 2 allocations read match the condition, 1 required", diags[0].Summary)
+}
+
+func TestValidateExpression(t *testing.T) {
+	_, errs := validateExpression(`ClientStatus == "running"`, "condition")
+	must.SliceEmpty(t, errs)
+
+	_, errs = validateExpression(`ClientStatus ==`, "condition")
+	must.Len(t, 1, errs)
+}
+
+func TestValidatePositiveDuration(t *testing.T) {
+	_, errs := validatePositiveDuration("5m", "timeout")
+	must.SliceEmpty(t, errs)
+
+	for _, v := range []string{"5", "soon", "0s", "-1m"} {
+		_, errs = validatePositiveDuration(v, "timeout")
+		must.Len(t, 1, errs, must.Sprintf("expected %q to be invalid", v))
+	}
+}
+
+func TestPollPacer(t *testing.T) {
+	// The queries are sent as soon as the objects change by default.
+	p, err := expandPollConfig(nil)
+	must.NoError(t, err)
+	pacer := p.pacer()
+	pacer.wait(context.Background())
+	must.Eq(t, 0, pacer.next)
+
+	p, err = expandPollConfig([]any{map[string]any{
+		"interval":     "10ms",
+		"max_interval": "25ms",
+		"backoff":      2.0,
+	}})
+	must.NoError(t, err)
+	pacer = p.pacer()
+	start := time.Now()
+	pacer.wait(context.Background())
+	must.GreaterEq(t, 10*time.Millisecond, time.Since(start))
+	must.Eq(t, 20*time.Millisecond, pacer.next)
+	pacer.wait(context.Background())
+	must.Eq(t, 25*time.Millisecond, pacer.next)
+
+	// The waiter doesn't wait once its context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pacer = pollConfig{interval: time.Hour}.pacer()
+	pacer.wait(ctx)
+	must.Eq(t, time.Hour, pacer.next)
+
+	_, err = expandPollConfig([]any{map[string]any{
+		"interval":     "soon",
+		"max_interval": "1m",
+		"backoff":      1.0,
+	}})
+	must.ErrorContains(t, err, "failed to parse poll interval")
+}
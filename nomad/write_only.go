@@ -0,0 +1,26 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// getWriteOnlyString returns the value of the write-only attribute at path.
+// Write-only values are never stored in state so they are only available in
+// the configuration, and only during apply. The second return value is false
+// when the attribute is not set.
+func getWriteOnlyString(d *schema.ResourceData, path cty.Path) (string, bool, error) {
+	value, diags := d.GetRawConfigAt(path)
+	if diags.HasError() {
+		return "", false, fmt.Errorf("error reading write-only attribute: %v", diags)
+	}
+	if !value.Type().Equals(cty.String) || !value.IsKnown() || value.IsNull() {
+		return "", false, nil
+	}
+	return value.AsString(), true, nil
+}